@@ -0,0 +1,372 @@
+package gogis
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+const (
+	// arrayDelimiter is the delimiter of geometry arrays (typdelim of the geometry type).
+	arrayDelimiter = ':'
+
+	// ErrMalformedArray occurs when a PostgreSQL array literal cannot be parsed.
+	ErrMalformedArray = ewkb.Error("malformed array literal")
+
+	// ErrNullArrayElement occurs when a NULL element is scanned into a typed array.
+	// Use GeometryArray to read arrays with NULL elements.
+	ErrNullArrayElement = ewkb.Error("NULL element in a typed array")
+)
+
+// arrayParser parses PostgreSQL array literals, such as:
+//
+//	{}
+//	{0101...:NULL:"0101..."}
+//	[0:1]={{0101...:0101...}:{0101...:0101...}}
+//
+// Multi-dimensional arrays are flattened in row-major order.
+type arrayParser struct {
+	data      []byte
+	pos       int
+	delimiter byte
+}
+
+func parseArray(data []byte, delimiter byte) ([][]byte, error) {
+	parser := &arrayParser{
+		data:      data,
+		delimiter: delimiter,
+	}
+
+	parser.skipDimensions()
+	parser.skipSpaces()
+
+	elements, _, err := parser.parseLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	parser.skipSpaces()
+
+	if parser.pos != len(parser.data) {
+		return nil, parser.errorf("unexpected trailing data")
+	}
+
+	return elements, nil
+}
+
+func (a *arrayParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", ErrMalformedArray, fmt.Sprintf(format, args...), a.pos)
+}
+
+// skipDimensions ignores the optional dimension decoration "[lb:ub]...=".
+func (a *arrayParser) skipDimensions() {
+	a.skipSpaces()
+
+	if a.pos >= len(a.data) || a.data[a.pos] != '[' {
+		return
+	}
+
+	if idx := bytes.IndexByte(a.data[a.pos:], '='); idx >= 0 {
+		a.pos += idx + 1
+	}
+}
+
+func (a *arrayParser) skipSpaces() {
+	for a.pos < len(a.data) && isArraySpace(a.data[a.pos]) {
+		a.pos++
+	}
+}
+
+// parseLevel parses a {...} level. The shape is the number of elements
+// of each nested level, to check that the array is rectangular.
+func (a *arrayParser) parseLevel() ([][]byte, []int, error) {
+	if a.pos >= len(a.data) || a.data[a.pos] != '{' {
+		return nil, nil, a.errorf("expected '{'")
+	}
+
+	a.pos++
+
+	output := [][]byte{}
+
+	var (
+		shape []int
+		count int
+	)
+
+	a.skipSpaces()
+
+	if a.pos < len(a.data) && a.data[a.pos] == '}' {
+		a.pos++
+
+		return output, []int{0}, nil
+	}
+
+	for {
+		a.skipSpaces()
+
+		if a.pos >= len(a.data) {
+			return nil, nil, a.errorf("unexpected end")
+		}
+
+		if a.data[a.pos] == '{' {
+			if count > 0 && shape == nil {
+				return nil, nil, a.errorf("mixed elements and sub-arrays")
+			}
+
+			elements, subShape, err := a.parseLevel()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if shape != nil && !equalShapes(shape, subShape) {
+				return nil, nil, a.errorf("sub-arrays must have matching dimensions")
+			}
+
+			shape = subShape
+			output = append(output, elements...)
+		} else {
+			if shape != nil {
+				return nil, nil, a.errorf("mixed elements and sub-arrays")
+			}
+
+			element, err := a.parseElement()
+			if err != nil {
+				return nil, nil, err
+			}
+
+			output = append(output, element)
+		}
+
+		count++
+
+		a.skipSpaces()
+
+		if a.pos >= len(a.data) {
+			return nil, nil, a.errorf("unexpected end")
+		}
+
+		switch a.data[a.pos] {
+		case a.delimiter:
+			a.pos++
+		case '}':
+			a.pos++
+
+			return output, append([]int{count}, shape...), nil
+		default:
+			return nil, nil, a.errorf("unexpected character %q", a.data[a.pos])
+		}
+	}
+}
+
+// parseElement parses a quoted or unquoted element. NULL gives a nil element.
+func (a *arrayParser) parseElement() ([]byte, error) {
+	if a.data[a.pos] == '"' {
+		return a.parseQuoted()
+	}
+
+	output := []byte{}
+	lastSignificant := 0
+
+	for a.pos < len(a.data) {
+		char := a.data[a.pos]
+
+		switch {
+		case char == a.delimiter || char == '}':
+			output = output[:lastSignificant]
+
+			if len(output) == 0 {
+				return nil, a.errorf("empty element")
+			}
+
+			if bytes.EqualFold(output, []byte("NULL")) {
+				return nil, nil
+			}
+
+			return output, nil
+		case char == '{' || char == '"':
+			return nil, a.errorf("unexpected character %q", char)
+		case char == '\\':
+			a.pos++
+
+			if a.pos >= len(a.data) {
+				return nil, a.errorf("unexpected end")
+			}
+
+			output = append(output, a.data[a.pos])
+			lastSignificant = len(output)
+		default:
+			output = append(output, char)
+
+			if !isArraySpace(char) {
+				lastSignificant = len(output)
+			}
+		}
+
+		a.pos++
+	}
+
+	return nil, a.errorf("unexpected end")
+}
+
+func (a *arrayParser) parseQuoted() ([]byte, error) {
+	a.pos++
+
+	output := []byte{}
+
+	for a.pos < len(a.data) {
+		char := a.data[a.pos]
+		a.pos++
+
+		switch char {
+		case '"':
+			return output, nil
+		case '\\':
+			if a.pos >= len(a.data) {
+				return nil, a.errorf("unexpected end")
+			}
+
+			output = append(output, a.data[a.pos])
+			a.pos++
+		default:
+			output = append(output, char)
+		}
+	}
+
+	return nil, a.errorf("unterminated quoted element")
+}
+
+// formatArray writes a one-dimension PostgreSQL array literal. Nil elements are NULL.
+func formatArray(elements [][]byte, delimiter byte) []byte {
+	output := []byte{'{'}
+
+	for idx, element := range elements {
+		if idx > 0 {
+			output = append(output, delimiter)
+		}
+
+		output = append(output, quoteArrayElement(element, delimiter)...)
+	}
+
+	return append(output, '}')
+}
+
+func quoteArrayElement(element []byte, delimiter byte) []byte {
+	if element == nil {
+		return []byte("NULL")
+	}
+
+	needQuotes := len(element) == 0 || bytes.EqualFold(element, []byte("NULL"))
+
+	for _, char := range element {
+		if char == delimiter || char == '{' || char == '}' || char == '"' || char == '\\' || isArraySpace(char) {
+			needQuotes = true
+
+			break
+		}
+	}
+
+	if !needQuotes {
+		return element
+	}
+
+	output := []byte{'"'}
+
+	for _, char := range element {
+		if char == '"' || char == '\\' {
+			output = append(output, '\\')
+		}
+
+		output = append(output, char)
+	}
+
+	return append(output, '"')
+}
+
+func isArraySpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\v' || char == '\f'
+}
+
+func equalShapes(left []int, right []int) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for idx := range left {
+		if left[idx] != right[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// scanArray scans a geometry array literal: build allocates the output, scan reads
+// each element. NULL elements are rejected unless nullable.
+func scanArray(
+	value interface{},
+	nullable bool,
+	build func(size int),
+	scan func(idx int, element []byte) error,
+) error {
+	if value == nil {
+		return nil
+	}
+
+	if strData, ok := value.(string); ok {
+		return scanArray([]byte(strData), nullable, build, scan)
+	}
+
+	dataByte, ok := value.([]byte)
+	if !ok {
+		return ewkb.ErrIncompatibleFormat
+	}
+
+	if dataByte == nil {
+		return nil
+	}
+
+	elements, err := parseArray(dataByte, arrayDelimiter)
+	if err != nil {
+		return err
+	}
+
+	build(len(elements))
+
+	for idx, element := range elements {
+		if element == nil && !nullable {
+			return fmt.Errorf("%w: index %d", ErrNullArrayElement, idx)
+		}
+
+		if err := scan(idx, element); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// arrayValue builds a geometry array literal from the driver value of each element.
+func arrayValue(size int, value func(idx int) (driver.Value, error)) (driver.Value, error) {
+	elements := make([][]byte, size)
+
+	for idx := range elements {
+		drvValue, err := value(idx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch data := drvValue.(type) {
+		case nil:
+		case []byte:
+			elements[idx] = data
+		case string:
+			elements[idx] = []byte(data)
+		default:
+			return nil, fmt.Errorf("%w: %T", ewkb.ErrIncompatibleFormat, drvValue)
+		}
+	}
+
+	return formatArray(elements, arrayDelimiter), nil
+}
@@ -1,6 +1,7 @@
 package gogis
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/landru29/gogis/ewkb"
@@ -52,6 +53,10 @@ func WithWellKnownGeometry(binding ...Binding) func(interface{}) {
 		case *GeometryCollection:
 			wellknown = append(wellknown, out.wellknown...)
 
+			out.wellknown = wellknown
+		case *LazyGeometry:
+			wellknown = append(wellknown, out.wellknown...)
+
 			out.wellknown = wellknown
 		}
 	}
@@ -70,3 +75,31 @@ func (b BindSet) pick(geoType ewkb.GeometryType) (ModelConverter, error) { //nol
 
 	return nil, ewkb.ErrWrongGeometryType
 }
+
+// byModel finds the binding of the model type.
+func (b BindSet) byModel(modelType reflect.Type) (Binding, error) {
+	for _, bind := range b {
+		if reflect.TypeOf(bind.modelType) == modelType {
+			return bind, nil
+		}
+	}
+
+	return Binding{}, fmt.Errorf("%w: no binding for %s", ewkb.ErrWrongGeometryType, modelType)
+}
+
+// newEWKB creates a fresh EWKB geometry of the binding. Collections
+// know all the geometries of the set.
+func (b BindSet) newEWKB(bind Binding) ewkb.Geometry { //nolint: ireturn
+	if _, ok := bind.ewkbType.(*ewkb.GeometryCollection); ok {
+		wellKnown := make([]ewkb.Geometry, len(b))
+		for idx, binding := range b {
+			wellKnown[idx] = binding.ewkbType
+		}
+
+		return ewkb.NewGeometryCollection(wellKnown...)
+	}
+
+	out, _ := reflect.New(reflect.TypeOf(bind.ewkbType).Elem()).Interface().(ewkb.Geometry)
+
+	return out
+}
@@ -0,0 +1,360 @@
+package gogis
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// ErrEmptyGeometry occurs when a geometry has no coordinate.
+const ErrEmptyGeometry = ewkb.Error("empty geometry")
+
+const (
+	box2DPrefix = "BOX("
+	box3DPrefix = "BOX3D("
+)
+
+// Box2D is BOX2D in database (as returned by ST_Extent or Box2D(geom)).
+type Box2D struct {
+	XMin float64
+	YMin float64
+	XMax float64
+	YMax float64
+}
+
+// NullBox2D represents a Box2D that may be null.
+// NullBox2D implements the SQL driver.Scanner interface so it
+// can be used as a scan destination:
+//
+//	var box gogis.NullBox2D
+//	err := db.QueryRow("SELECT ST_Extent(coordinate) FROM foo").Scan(&box)
+//	...
+//	if box.Valid {
+//	   // use box.Box2D
+//	} else {
+//	   // NULL value
+//	}
+type NullBox2D struct {
+	Box2D Box2D
+	Valid bool
+}
+
+// Box3D is BOX3D in database (as returned by ST_3DExtent or Box3D(geom)).
+type Box3D struct {
+	XMin float64
+	YMin float64
+	ZMin float64
+	XMax float64
+	YMax float64
+	ZMax float64
+}
+
+// NullBox3D represents a Box3D that may be null.
+// NullBox3D implements the SQL driver.Scanner interface so it
+// can be used as a scan destination:
+//
+//	var box gogis.NullBox3D
+//	err := db.QueryRow("SELECT ST_3DExtent(coordinate) FROM foo").Scan(&box)
+//	...
+//	if box.Valid {
+//	   // use box.Box3D
+//	} else {
+//	   // NULL value
+//	}
+type NullBox3D struct {
+	Box3D Box3D
+	Valid bool
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (b *Box2D) Scan(value interface{}) error {
+	values, err := parseBox(value, box2DPrefix, 2) //nolint: gomnd
+	if err != nil {
+		return err
+	}
+
+	*b = Box2D{
+		XMin: values[0],
+		YMin: values[1],
+		XMax: values[2],
+		YMax: values[3],
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (b Box2D) Value() (driver.Value, error) {
+	return fmt.Sprintf(
+		"%s%s %s,%s %s)",
+		box2DPrefix,
+		formatBoxFloat(b.XMin),
+		formatBoxFloat(b.YMin),
+		formatBoxFloat(b.XMax),
+		formatBoxFloat(b.YMax),
+	), nil
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (b *NullBox2D) Scan(value interface{}) error {
+	if isNullValue(value) {
+		*b = NullBox2D{}
+
+		return nil
+	}
+
+	if err := (&b.Box2D).Scan(value); err != nil {
+		return err
+	}
+
+	b.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (b NullBox2D) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+
+	return b.Box2D.Value()
+}
+
+// FromPolygon sets the box to the extent of the polygon.
+func (b *Box2D) FromPolygon(polygon Polygon) error {
+	box3D := Box3D{}
+
+	if err := box3D.FromPolygon(polygon); err != nil {
+		return err
+	}
+
+	*b = box3D.Box2D()
+
+	return nil
+}
+
+// Polygon converts the box to a polygon, in the same vertex order as ST_Envelope.
+func (b Box2D) Polygon() Polygon {
+	return Polygon{
+		LineString{
+			{Coordinate: ewkb.Coordinate{'x': b.XMin, 'y': b.YMin}},
+			{Coordinate: ewkb.Coordinate{'x': b.XMin, 'y': b.YMax}},
+			{Coordinate: ewkb.Coordinate{'x': b.XMax, 'y': b.YMax}},
+			{Coordinate: ewkb.Coordinate{'x': b.XMax, 'y': b.YMin}},
+			{Coordinate: ewkb.Coordinate{'x': b.XMin, 'y': b.YMin}},
+		},
+	}
+}
+
+// Contains checks if the box contains the extent of the geometry, arcs included (PostGIS "~" operator).
+func (b Box2D) Contains(geometry EWKBConverter) bool {
+	other, err := box3DOf(geometry)
+	if err != nil {
+		return false
+	}
+
+	return b.XMin <= other.XMin && b.XMax >= other.XMax &&
+		b.YMin <= other.YMin && b.YMax >= other.YMax
+}
+
+// Intersects checks if the box intersects the bounding box of the geometry (PostGIS "&&" operator).
+func (b Box2D) Intersects(geometry EWKBConverter) bool {
+	other, err := box3DOf(geometry)
+	if err != nil {
+		return false
+	}
+
+	return b.XMin <= other.XMax && b.XMax >= other.XMin &&
+		b.YMin <= other.YMax && b.YMax >= other.YMin
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (b *Box3D) Scan(value interface{}) error {
+	values, err := parseBox(value, box3DPrefix, 3) //nolint: gomnd
+	if err != nil {
+		return err
+	}
+
+	*b = Box3D{
+		XMin: values[0],
+		YMin: values[1],
+		ZMin: values[2],
+		XMax: values[3],
+		YMax: values[4],
+		ZMax: values[5],
+	}
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (b Box3D) Value() (driver.Value, error) {
+	return fmt.Sprintf(
+		"%s%s %s %s,%s %s %s)",
+		box3DPrefix,
+		formatBoxFloat(b.XMin),
+		formatBoxFloat(b.YMin),
+		formatBoxFloat(b.ZMin),
+		formatBoxFloat(b.XMax),
+		formatBoxFloat(b.YMax),
+		formatBoxFloat(b.ZMax),
+	), nil
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (b *NullBox3D) Scan(value interface{}) error {
+	if isNullValue(value) {
+		*b = NullBox3D{}
+
+		return nil
+	}
+
+	if err := (&b.Box3D).Scan(value); err != nil {
+		return err
+	}
+
+	b.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (b NullBox3D) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+
+	return b.Box3D.Value()
+}
+
+// FromPolygon sets the box to the extent of the polygon.
+// Missing Z coordinates are considered as 0, as PostGIS does.
+func (b *Box3D) FromPolygon(polygon Polygon) error {
+	box, err := box3DOf(polygon)
+	if err != nil {
+		return err
+	}
+
+	*b = box
+
+	return nil
+}
+
+// Box2D drops the Z extent of the box.
+func (b Box3D) Box2D() Box2D {
+	return Box2D{
+		XMin: b.XMin,
+		YMin: b.YMin,
+		XMax: b.XMax,
+		YMax: b.YMax,
+	}
+}
+
+// Polygon converts the box to a polygon. The Z extent is dropped.
+func (b Box3D) Polygon() Polygon {
+	return b.Box2D().Polygon()
+}
+
+// Contains checks if the box contains the extent of the geometry, arcs included (PostGIS "@>" operator in 3D).
+func (b Box3D) Contains(geometry EWKBConverter) bool {
+	other, err := box3DOf(geometry)
+	if err != nil {
+		return false
+	}
+
+	return b.XMin <= other.XMin && b.XMax >= other.XMax &&
+		b.YMin <= other.YMin && b.YMax >= other.YMax &&
+		b.ZMin <= other.ZMin && b.ZMax >= other.ZMax
+}
+
+// Intersects checks if the box intersects the bounding box of the geometry (PostGIS "&&&" operator).
+func (b Box3D) Intersects(geometry EWKBConverter) bool {
+	other, err := box3DOf(geometry)
+	if err != nil {
+		return false
+	}
+
+	return b.XMin <= other.XMax && b.XMax >= other.XMin &&
+		b.YMin <= other.YMax && b.YMax >= other.YMin &&
+		b.ZMin <= other.ZMax && b.ZMax >= other.ZMin
+}
+
+// box3DOf is the extent of the geometry (see ewkb.EnvelopeOf), with Z at 0 when
+// the geometry has none.
+func box3DOf(geometry EWKBConverter) (Box3D, error) {
+	envelope := ewkb.EnvelopeOf(geometry.ToEWKB())
+	if envelope.IsEmpty() {
+		return Box3D{}, ErrEmptyGeometry
+	}
+
+	return Box3D{
+		XMin: envelope.XMin,
+		YMin: envelope.YMin,
+		ZMin: envelope.ZMin,
+		XMax: envelope.XMax,
+		YMax: envelope.YMax,
+		ZMax: envelope.ZMax,
+	}, nil
+}
+
+func parseBox(value interface{}, prefix string, dimension int) ([]float64, error) {
+	var text string
+
+	switch data := value.(type) {
+	case string:
+		text = data
+	case []byte:
+		text = string(data)
+	default:
+		return nil, ewkb.ErrIncompatibleFormat
+	}
+
+	text = strings.TrimSpace(text)
+
+	if !strings.HasPrefix(strings.ToUpper(text), prefix) || !strings.HasSuffix(text, ")") {
+		return nil, fmt.Errorf("%w: %q is not a %s...)", ewkb.ErrIncompatibleFormat, text, prefix)
+	}
+
+	corners := strings.Split(text[len(prefix):len(text)-1], ",")
+	if len(corners) != 2 { //nolint: gomnd
+		return nil, fmt.Errorf("%w: %q must have 2 corners", ewkb.ErrIncompatibleFormat, text)
+	}
+
+	output := make([]float64, 0, 2*dimension) //nolint: gomnd
+
+	for _, corner := range corners {
+		fields := strings.Fields(corner)
+		if len(fields) != dimension {
+			return nil, fmt.Errorf("%w: %q must have %d dimensions", ewkb.ErrIncompatibleFormat, text, dimension)
+		}
+
+		for _, field := range fields {
+			number, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ewkb.ErrIncompatibleFormat, err)
+			}
+
+			output = append(output, number)
+		}
+	}
+
+	return output, nil
+}
+
+func formatBoxFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func isNullValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	dataBytes, ok := value.([]byte)
+
+	return ok && dataBytes == nil
+}
@@ -0,0 +1,218 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBox2D(t *testing.T) {
+	fixture := gogis.Box2D{
+		XMin: -71.42,
+		YMin: 42.17,
+		XMax: -17.42,
+		YMax: 71.17,
+	}
+
+	rawData := []byte("BOX(-71.42 42.17,-17.42 71.17)")
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          rawData,
+			scanner:          &gogis.Box2D{},
+			expectedGeometry: &fixture,
+		})
+	})
+
+	t.Run("scan null data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          nil,
+			scanner:          &gogis.NullBox2D{},
+			expectedGeometry: &gogis.NullBox2D{},
+		})
+	})
+
+	t.Run("scan valid data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData: rawData,
+			scanner: &gogis.NullBox2D{},
+			expectedGeometry: &gogis.NullBox2D{
+				Box2D: fixture,
+				Valid: true,
+			},
+		})
+	})
+
+	t.Run("scan wrong format", func(t *testing.T) {
+		box := gogis.Box2D{}
+
+		assert.ErrorIs(t, box.Scan("BOX3D(1 2 3,4 5 6)"), ewkb.ErrIncompatibleFormat)
+		assert.ErrorIs(t, box.Scan("BOX(1 2)"), ewkb.ErrIncompatibleFormat)
+		assert.ErrorIs(t, box.Scan("BOX(1 a,3 4)"), ewkb.ErrIncompatibleFormat)
+		assert.ErrorIs(t, box.Scan(42), ewkb.ErrIncompatibleFormat)
+	})
+
+	t.Run("value", func(t *testing.T) {
+		out, err := fixture.Value()
+		require.NoError(t, err)
+		assert.Equal(t, string(rawData), out)
+
+		out, err = gogis.NullBox2D{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("polygon", func(t *testing.T) {
+		polygon := fixture.Polygon()
+
+		assert.Equal(t, gogis.Polygon{
+			gogis.LineString{
+				{Coordinate: ewkb.Coordinate{'x': -71.42, 'y': 42.17}},
+				{Coordinate: ewkb.Coordinate{'x': -71.42, 'y': 71.17}},
+				{Coordinate: ewkb.Coordinate{'x': -17.42, 'y': 71.17}},
+				{Coordinate: ewkb.Coordinate{'x': -17.42, 'y': 42.17}},
+				{Coordinate: ewkb.Coordinate{'x': -71.42, 'y': 42.17}},
+			},
+		}, polygon)
+
+		box := gogis.Box2D{}
+		require.NoError(t, box.FromPolygon(polygon))
+		assert.Equal(t, fixture, box)
+
+		assert.ErrorIs(t, box.FromPolygon(gogis.Polygon{}), gogis.ErrEmptyGeometry)
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		inside := gogis.Point{Coordinate: ewkb.Coordinate{'x': -50, 'y': 50}}
+		outside := gogis.Point{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}}
+		crossing := gogis.LineString{inside, outside}
+
+		assert.True(t, fixture.Contains(inside))
+		assert.False(t, fixture.Contains(outside))
+		assert.False(t, fixture.Contains(crossing))
+
+		assert.True(t, fixture.Intersects(inside))
+		assert.False(t, fixture.Intersects(outside))
+		assert.True(t, fixture.Intersects(crossing))
+		assert.True(t, fixture.Intersects(gogis.MultiPoint{outside, inside}))
+
+		assert.False(t, fixture.Intersects(gogis.LineString{}))
+	})
+
+	t.Run("arc", func(t *testing.T) {
+		// The vertices are in the box, but the circle goes through (1 1) and (1 -1).
+		circle := gogis.CircularString(line(0, 0, 2, 0, 0, 0))
+		box := gogis.Box2D{XMin: -1, YMin: -0.5, XMax: 3, YMax: 0.5}
+
+		assert.False(t, box.Contains(circle))
+		assert.True(t, box.Intersects(circle))
+
+		require.NoError(t, box.FromPolygon(gogis.Polygon{line(-1, -1, 3, -1, 3, 1, -1, 1, -1, -1)}))
+		assert.True(t, box.Contains(circle))
+	})
+}
+
+func TestBox3D(t *testing.T) {
+	fixture := gogis.Box3D{
+		XMin: 1,
+		YMin: 2,
+		ZMin: 3,
+		XMax: 4,
+		YMax: 5.5,
+		ZMax: 6,
+	}
+
+	rawData := []byte("BOX3D(1 2 3,4 5.5 6)")
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          rawData,
+			scanner:          &gogis.Box3D{},
+			expectedGeometry: &fixture,
+		})
+	})
+
+	t.Run("scan null data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          nil,
+			scanner:          &gogis.NullBox3D{},
+			expectedGeometry: &gogis.NullBox3D{},
+		})
+	})
+
+	t.Run("scan valid data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData: rawData,
+			scanner: &gogis.NullBox3D{},
+			expectedGeometry: &gogis.NullBox3D{
+				Box3D: fixture,
+				Valid: true,
+			},
+		})
+	})
+
+	t.Run("value", func(t *testing.T) {
+		out, err := fixture.Value()
+		require.NoError(t, err)
+		assert.Equal(t, string(rawData), out)
+	})
+
+	t.Run("polygon", func(t *testing.T) {
+		box := gogis.Box3D{}
+		require.NoError(t, box.FromPolygon(gogis.Polygon{
+			gogis.LineString{
+				{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2, 'z': 6}},
+				{Coordinate: ewkb.Coordinate{'x': 4, 'y': 2, 'z': 3}},
+				{Coordinate: ewkb.Coordinate{'x': 4, 'y': 5.5, 'z': 3}},
+				{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2, 'z': 6}},
+			},
+		}))
+		assert.Equal(t, fixture, box)
+		assert.Equal(t, fixture.Box2D().Polygon(), fixture.Polygon())
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		inside := gogis.Point{Coordinate: ewkb.Coordinate{'x': 2, 'y': 3, 'z': 4}}
+		above := gogis.Point{Coordinate: ewkb.Coordinate{'x': 2, 'y': 3, 'z': 10}}
+
+		assert.True(t, fixture.Contains(inside))
+		assert.False(t, fixture.Contains(above))
+		assert.True(t, fixture.Intersects(gogis.LineString{inside, above}))
+		assert.False(t, fixture.Intersects(above))
+	})
+}
+
+func TestEnvelope(t *testing.T) {
+	square := line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)
+	far := gogis.Point{Coordinate: ewkb.Coordinate{'x': 20, 'y': -5}}
+
+	for name, fixture := range map[string]struct {
+		geometry interface{ Envelope() ewkb.Box }
+		expected ewkb.Box
+	}{
+		"point":            {geometry: far, expected: ewkb.Box{XMin: 20, YMin: -5, XMax: 20, YMax: -5}},
+		"linestring":       {geometry: square, expected: ewkb.Box{XMax: 10, YMax: 10}},
+		"polygon":          {geometry: gogis.Polygon{square}, expected: ewkb.Box{XMax: 10, YMax: 10}},
+		"triangle":         {geometry: gogis.Triangle(line(0, 0, 10, 0, 0, 10, 0, 0)), expected: ewkb.Box{XMax: 10, YMax: 10}},
+		"multipoint":       {geometry: gogis.MultiPoint{far, square[2]}, expected: ewkb.Box{XMin: 10, YMin: -5, XMax: 20, YMax: 10}},
+		"multilinestring":  {geometry: gogis.MultiLineString{square, {far}}, expected: ewkb.Box{YMin: -5, XMax: 20, YMax: 10}},
+		"multipolygon":     {geometry: gogis.MultiPolygon{{square}}, expected: ewkb.Box{XMax: 10, YMax: 10}},
+		"circular string":  {geometry: gogis.CircularString(line(0, 0, 1, 1, 2, 0)), expected: ewkb.Box{XMax: 2, YMax: 1}},
+		"collection":       {geometry: gogis.GeometryCollection{Collection: []gogis.ModelConverter{&far, &square}}, expected: ewkb.Box{YMin: -5, XMax: 20, YMax: 10}},
+		"generic geometry": {geometry: gogis.Polygon{square}.Geometry(), expected: ewkb.Box{XMax: 10, YMax: 10}},
+	} {
+		fixture := fixture
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, fixture.expected, fixture.geometry.Envelope())
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, gogis.Polygon{}.Envelope().IsEmpty())
+		assert.True(t, gogis.Geometry{}.Envelope().IsEmpty())
+	})
+}
@@ -0,0 +1,410 @@
+package gogis
+
+import (
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// EndCapStyle is the shape of the ends of a buffered linestring.
+type EndCapStyle uint8
+
+const (
+	// EndCapRound ends the buffer with half circles (endcap=round).
+	EndCapRound EndCapStyle = iota
+
+	// EndCapFlat ends the buffer at the ends of the linestring (endcap=flat).
+	EndCapFlat
+
+	// EndCapSquare extends the buffer by the distance beyond the ends (endcap=square).
+	EndCapSquare
+)
+
+// JoinStyle is the shape of the corners of a buffer.
+type JoinStyle uint8
+
+const (
+	// JoinRound rounds the corners (join=round).
+	JoinRound JoinStyle = iota
+
+	// JoinMitre extends the sides to a sharp corner, up to the mitre limit (join=mitre).
+	JoinMitre
+
+	// JoinBevel cuts the corners (join=bevel).
+	JoinBevel
+)
+
+const (
+	defaultQuadrantSegments = 8
+	defaultMitreLimit       = 5
+)
+
+// BufferOptions are the parameters of a buffer, as the buffer_style_parameters
+// of ST_Buffer.
+type BufferOptions struct {
+	// QuadrantSegments is the number of segments of a quarter circle (quad_segs, 8 by default).
+	QuadrantSegments int
+
+	// EndCap is the shape of the ends of the linestrings (round by default).
+	EndCap EndCapStyle
+
+	// Join is the shape of the corners (round by default).
+	Join JoinStyle
+
+	// MitreLimit is the maximum ratio of the distance of a mitre corner to the
+	// buffer distance (mitre_limit, 5 by default); sharper corners are clipped.
+	MitreLimit float64
+}
+
+// WithQuadrantSegments sets the number of segments of a quarter circle.
+func WithQuadrantSegments(segments int) func(*BufferOptions) {
+	return func(options *BufferOptions) {
+		options.QuadrantSegments = segments
+	}
+}
+
+// WithEndCap sets the shape of the ends of the linestrings.
+func WithEndCap(style EndCapStyle) func(*BufferOptions) {
+	return func(options *BufferOptions) {
+		options.EndCap = style
+	}
+}
+
+// WithJoin sets the shape of the corners.
+func WithJoin(style JoinStyle) func(*BufferOptions) {
+	return func(options *BufferOptions) {
+		options.Join = style
+	}
+}
+
+// WithMitreLimit sets the mitre limit of the mitre joins.
+func WithMitreLimit(limit float64) func(*BufferOptions) {
+	return func(options *BufferOptions) {
+		options.MitreLimit = limit
+	}
+}
+
+// Buffer computes the area within the distance of a geometry, as ST_Buffer
+// does: a polygon or a multipolygon, empty when nothing remains.
+//
+//	corridor, err := gogis.Buffer(route, 50, gogis.WithEndCap(gogis.EndCapFlat))
+//	if err != nil {
+//		return err
+//	}
+//
+//	inside, err := gogis.Covers(corridor.Geometry.(gogis.EWKBConverter), position)
+//
+// Circles are approximated with QuadrantSegments segments per quarter. A
+// negative distance shrinks the polygons, and gives an empty polygon for points
+// and linestrings. Points have no buffer with flat end caps. Z and M are dropped,
+// and curves are not supported (ErrUnsupportedGeometry).
+func Buffer(geometry EWKBConverter, distance float64, opts ...func(*BufferOptions)) (Geometry, error) {
+	options := BufferOptions{
+		QuadrantSegments: defaultQuadrantSegments,
+		MitreLimit:       defaultMitreLimit,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.QuadrantSegments < 1 {
+		options.QuadrantSegments = 1
+	}
+
+	geo := geometry.ToEWKB()
+
+	source, err := newRelateGeometry(geo)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	builder := bufferBuilder{options: options, distance: math.Abs(distance)}
+	parts := []ewkb.Geometry{}
+
+	for _, polygon := range source.polygons {
+		part, err := builder.polygon(polygon, distance)
+		if err != nil {
+			return Geometry{}, err
+		}
+
+		parts = append(parts, part)
+	}
+
+	if distance > 0 {
+		for _, line := range source.lines {
+			parts = append(parts, builder.line(line)...)
+		}
+
+		for _, position := range source.points {
+			parts = append(parts, builder.point(position)...)
+		}
+	}
+
+	output, err := unionAll(parts)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	if output == nil {
+		output = &ewkb.Polygon{}
+	}
+
+	setSystemReferenceID(output, geo.SystemReferenceID())
+
+	return modelOf(output)
+}
+
+// unionAll computes the union of the geometries, merging them by pairs.
+func unionAll(geometries []ewkb.Geometry) (ewkb.Geometry, error) { //nolint: ireturn
+	for len(geometries) > 1 {
+		merged := make([]ewkb.Geometry, 0, (len(geometries)+1)/2) //nolint: gomnd
+
+		for idx := 0; idx < len(geometries); idx += 2 {
+			if idx+1 == len(geometries) {
+				merged = append(merged, geometries[idx])
+
+				continue
+			}
+
+			union, err := overlayGeometries(geometries[idx], geometries[idx+1], overlayUnion)
+			if err != nil {
+				return nil, err
+			}
+
+			merged = append(merged, union)
+		}
+
+		geometries = merged
+	}
+
+	if len(geometries) == 0 {
+		return nil, nil
+	}
+
+	return geometries[0], nil
+}
+
+func setSystemReferenceID(geometry ewkb.Geometry, srid *ewkb.SystemReferenceID) {
+	switch geo := geometry.(type) {
+	case *ewkb.Polygon:
+		geo.SRID = srid
+	case *ewkb.MultiPolygon:
+		geo.SRID = srid
+	}
+}
+
+// bufferBuilder builds the pieces of a buffer: their union is the buffer.
+type bufferBuilder struct {
+	options  BufferOptions
+	distance float64
+}
+
+// polygon grows (or shrinks) a polygon by the area swept along its rings.
+func (b bufferBuilder) polygon(rings [][]xy, distance float64) (ewkb.Geometry, error) { //nolint: ireturn
+	source := polygonOf(rings)
+
+	if distance == 0 {
+		return source, nil
+	}
+
+	pieces := []ewkb.Geometry{}
+
+	for _, ring := range rings {
+		pieces = append(pieces, b.path(ring, true)...)
+	}
+
+	sweep, err := unionAll(pieces)
+	if err != nil {
+		return nil, err
+	}
+
+	operation := overlayUnion
+	if distance < 0 {
+		operation = overlayDifference
+	}
+
+	return overlayGeometries(source, sweep, operation)
+}
+
+// line builds the pieces of the buffer of a linestring.
+func (b bufferBuilder) line(line []xy) []ewkb.Geometry {
+	output := b.path(line, false)
+
+	start, end := line[0], line[len(line)-1]
+	startDirection := direction(line[0], line[1])
+	endDirection := direction(line[len(line)-2], line[len(line)-1])
+
+	if b.options.EndCap == EndCapRound {
+		output = append(output,
+			b.fan(start, leftNormal(startDirection), rightNormal(startDirection), math.Pi),
+			b.fan(end, rightNormal(endDirection), leftNormal(endDirection), math.Pi),
+		)
+	}
+
+	return output
+}
+
+// point builds the buffer of a point: a circle, or a square.
+func (b bufferBuilder) point(position xy) []ewkb.Geometry {
+	switch b.options.EndCap {
+	case EndCapRound:
+		ring := make([]xy, 0, 4*b.options.QuadrantSegments+1) //nolint: gomnd
+
+		for idx := 0; idx < 4*b.options.QuadrantSegments; idx++ {
+			angle := float64(idx) * math.Pi / 2 / float64(b.options.QuadrantSegments) //nolint: gomnd
+			ring = append(ring, xy{x: position.x + b.distance*math.Cos(angle), y: position.y + b.distance*math.Sin(angle)})
+		}
+
+		return []ewkb.Geometry{polygonOf([][]xy{append(ring, ring[0])})}
+	case EndCapSquare:
+		return []ewkb.Geometry{polygonOf([][]xy{{
+			{x: position.x - b.distance, y: position.y - b.distance},
+			{x: position.x + b.distance, y: position.y - b.distance},
+			{x: position.x + b.distance, y: position.y + b.distance},
+			{x: position.x - b.distance, y: position.y + b.distance},
+			{x: position.x - b.distance, y: position.y - b.distance},
+		}})}
+	case EndCapFlat:
+	}
+
+	return nil
+}
+
+// path builds the rectangles along the segments, and the joins at the vertices
+// (at the closing vertex too for a ring). The ends of a linestring are extended
+// for square end caps.
+func (b bufferBuilder) path(path []xy, ring bool) []ewkb.Geometry {
+	output := []ewkb.Geometry{}
+	last := len(path) - 1
+
+	for idx := 1; idx < len(path); idx++ {
+		from, to := path[idx-1], path[idx]
+		unit := direction(from, to)
+		offset := leftNormal(unit).scale(b.distance)
+
+		if !ring && b.options.EndCap == EndCapSquare {
+			if idx == 1 {
+				from = from.add(unit.scale(-b.distance))
+			}
+
+			if idx == last {
+				to = to.add(unit.scale(b.distance))
+			}
+		}
+
+		// The ends are vertices of the rectangle, so that the sides of the caps
+		// and of the joins are exactly shared.
+		output = append(output, polygonOf([][]xy{{
+			from.add(offset.scale(-1)), to.add(offset.scale(-1)), to, to.add(offset), from.add(offset), from, from.add(offset.scale(-1)),
+		}}))
+
+		switch {
+		case idx < last:
+			output = append(output, b.join(path[idx-1], path[idx], path[idx+1])...)
+		case ring:
+			output = append(output, b.join(path[idx-1], path[idx], path[1])...)
+		}
+	}
+
+	return output
+}
+
+// join builds the corner at the vertex between (previous, vertex) and (vertex,
+// next), on the outer side of the turn.
+func (b bufferBuilder) join(previous xy, vertex xy, next xy) []ewkb.Geometry {
+	incoming, outgoing := direction(previous, vertex), direction(vertex, next)
+	turn := math.Atan2(incoming.x*outgoing.y-incoming.y*outgoing.x, incoming.x*outgoing.x+incoming.y*outgoing.y)
+
+	if turn == 0 {
+		return nil
+	}
+
+	// The outer side is on the right of a left turn, on the left of a right turn.
+	outerIn, outerOut := rightNormal(incoming), rightNormal(outgoing)
+	if turn < 0 {
+		outerIn, outerOut = leftNormal(incoming), leftNormal(outgoing)
+	}
+
+	first, last := vertex.add(outerIn.scale(b.distance)), vertex.add(outerOut.scale(b.distance))
+
+	switch b.options.Join {
+	case JoinRound:
+		return []ewkb.Geometry{b.fan(vertex, outerIn, outerOut, turn)}
+	case JoinMitre:
+		cos, sin := math.Cos(math.Abs(turn)/2), math.Sin(math.Abs(turn)/2) //nolint: gomnd
+
+		if cos > 0 && 1/cos <= b.options.MitreLimit {
+			// The sides meet at distance/cos on the bisector.
+			mitre := vertex.add(outerIn.add(outerOut).scale(b.distance / (2 * cos * cos))) //nolint: gomnd
+
+			return []ewkb.Geometry{polygonOf([][]xy{{vertex, first, mitre, last, vertex}})}
+		}
+
+		// Clipped mitre: the sides are cut at the mitre limit, perpendicularly to the bisector.
+		if along := b.distance * (b.options.MitreLimit - cos) / sin; along > 0 {
+			return []ewkb.Geometry{polygonOf([][]xy{{
+				vertex, first, first.add(incoming.scale(along)), last.add(outgoing.scale(-along)), last, vertex,
+			}})}
+		}
+	case JoinBevel:
+	}
+
+	if cross(vertex, first, last) == 0 {
+		return nil
+	}
+
+	return []ewkb.Geometry{polygonOf([][]xy{{vertex, first, last, vertex}})}
+}
+
+// fan builds the sector of the circle of the buffer centered on a position, from
+// the direction start to the direction end, through the signed angle sweep
+// (counter clockwise when positive).
+func (b bufferBuilder) fan(center xy, start xy, end xy, sweep float64) ewkb.Geometry { //nolint: ireturn
+	step := math.Pi / 2 / float64(b.options.QuadrantSegments) //nolint: gomnd
+	count := int(math.Ceil(math.Abs(sweep)/step - 1e-9))      //nolint: gomnd
+	startAngle := math.Atan2(start.y, start.x)
+
+	ring := []xy{center, center.add(start.scale(b.distance))}
+
+	for idx := 1; idx < count; idx++ {
+		angle := startAngle + sweep*float64(idx)/float64(count)
+		ring = append(ring, xy{x: center.x + b.distance*math.Cos(angle), y: center.y + b.distance*math.Sin(angle)})
+	}
+
+	return polygonOf([][]xy{append(ring, center.add(end.scale(b.distance)), center)})
+}
+
+// direction is the unit vector from a to b.
+func direction(a xy, b xy) xy {
+	length := distance(a, b)
+
+	return xy{x: (b.x - a.x) / length, y: (b.y - a.y) / length}
+}
+
+func leftNormal(unit xy) xy {
+	return xy{x: -unit.y, y: unit.x}
+}
+
+func rightNormal(unit xy) xy {
+	return xy{x: unit.y, y: -unit.x}
+}
+
+func (p xy) add(other xy) xy {
+	return xy{x: p.x + other.x, y: p.y + other.y}
+}
+
+func (p xy) scale(factor float64) xy {
+	return xy{x: p.x * factor, y: p.y * factor}
+}
+
+// polygonOf converts rings to a polygon.
+func polygonOf(rings [][]xy) *ewkb.Polygon {
+	output := ewkb.Polygon{CoordinateGroup: make(ewkb.CoordinateGroup, len(rings))}
+
+	for idx, ring := range rings {
+		output.CoordinateGroup[idx] = coordinatesOf(ring)
+	}
+
+	return &output
+}
@@ -0,0 +1,258 @@
+package gogis_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuffer(t *testing.T) {
+	square := gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}
+	corner := line(0, 0, 10, 0, 10, 10)
+	origin := &gogis.Point{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}}
+
+	for _, testCase := range []struct {
+		name         string
+		geometry     gogis.EWKBConverter
+		distance     float64
+		options      []func(*gogis.BufferOptions)
+		expectedType ewkb.GeometryType
+		expectedArea float64
+	}{
+		{
+			name:         "point",
+			geometry:     origin,
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 3.121445152258052,
+		},
+		{
+			name:         "quadrant segments",
+			geometry:     origin,
+			distance:     1,
+			options:      []func(*gogis.BufferOptions){gogis.WithQuadrantSegments(2)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 2 * math.Sqrt2,
+		},
+		{
+			name:         "square point",
+			geometry:     origin,
+			distance:     1,
+			options:      []func(*gogis.BufferOptions){gogis.WithEndCap(gogis.EndCapSquare)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 4,
+		},
+		{
+			name:         "multipoint",
+			geometry:     &gogis.MultiPoint{*origin, {Coordinate: ewkb.Coordinate{'x': 10, 'y': 0}}},
+			distance:     1,
+			expectedType: ewkb.GeometryTypeMultiPolygon,
+			expectedArea: 2 * 3.121445152258052,
+		},
+		{
+			name:         "round line",
+			geometry:     line(0, 0, 10, 0),
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 20 + 3.121445152258052,
+		},
+		{
+			name:         "flat line",
+			geometry:     line(0, 0, 10, 0),
+			distance:     1,
+			options:      []func(*gogis.BufferOptions){gogis.WithEndCap(gogis.EndCapFlat)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 20,
+		},
+		{
+			name:         "square line",
+			geometry:     line(0, 0, 10, 0),
+			distance:     1,
+			options:      []func(*gogis.BufferOptions){gogis.WithEndCap(gogis.EndCapSquare)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 24,
+		},
+		{
+			name:         "round join",
+			geometry:     corner,
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 39 + 3.121445152258052*5/4,
+		},
+		{
+			name:     "mitre join",
+			geometry: corner,
+			distance: 1,
+			options: []func(*gogis.BufferOptions){
+				gogis.WithJoin(gogis.JoinMitre), gogis.WithEndCap(gogis.EndCapFlat),
+			},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 40,
+		},
+		{
+			name:     "clipped mitre join",
+			geometry: corner,
+			distance: 1,
+			options: []func(*gogis.BufferOptions){
+				gogis.WithJoin(gogis.JoinMitre), gogis.WithEndCap(gogis.EndCapFlat), gogis.WithMitreLimit(1),
+			},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 39 + 2*math.Sqrt2 - 2,
+		},
+		{
+			name:     "bevel join",
+			geometry: corner,
+			distance: 1,
+			options: []func(*gogis.BufferOptions){
+				gogis.WithJoin(gogis.JoinBevel), gogis.WithEndCap(gogis.EndCapFlat),
+			},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 39.5,
+		},
+		{
+			name:         "polygon",
+			geometry:     square,
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 140 + 3.121445152258052,
+		},
+		{
+			name:         "mitre polygon",
+			geometry:     square,
+			distance:     1,
+			options:      []func(*gogis.BufferOptions){gogis.WithJoin(gogis.JoinMitre)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 144,
+		},
+		{
+			name:         "negative",
+			geometry:     square,
+			distance:     -1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 64,
+		},
+		{
+			name:         "hole filled",
+			geometry:     gogis.Polygon{square[0], line(4, 4, 4, 6, 6, 6, 6, 4, 4, 4)},
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 140 + 3.121445152258052,
+		},
+		{
+			name:         "hole grown",
+			geometry:     gogis.Polygon{square[0], line(4, 4, 4, 6, 6, 6, 6, 4, 4, 4)},
+			distance:     -1,
+			expectedType: ewkb.GeometryTypePolygon,
+			expectedArea: 64 - 12 - 3.121445152258052,
+		},
+		{
+			name:         "collapsed",
+			geometry:     square,
+			distance:     -6,
+			expectedType: ewkb.GeometryTypePolygon,
+		},
+		{
+			name:         "negative line",
+			geometry:     line(0, 0, 10, 0),
+			distance:     -1,
+			expectedType: ewkb.GeometryTypePolygon,
+		},
+		{
+			name:         "empty",
+			geometry:     &gogis.Point{},
+			distance:     1,
+			expectedType: ewkb.GeometryTypePolygon,
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := gogis.Buffer(testCase.geometry, testCase.distance, testCase.options...)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedType, result.Type)
+
+			converter, ok := result.Geometry.(gogis.EWKBConverter)
+			require.True(t, ok)
+			assert.Empty(t, gogis.Validate(converter))
+
+			switch geometry := result.Geometry.(type) {
+			case *gogis.Polygon:
+				assert.InDelta(t, testCase.expectedArea, geometry.Area(), 1e-9)
+				assert.Equal(t, testCase.expectedArea == 0, result.IsEmpty())
+			case *gogis.MultiPolygon:
+				assert.InDelta(t, testCase.expectedArea, geometry.Area(), 1e-9)
+			default:
+				assert.Fail(t, "unexpected geometry", "%T", geometry)
+			}
+		})
+	}
+
+	t.Run("corridor", func(t *testing.T) {
+		corridor, err := gogis.Buffer(line(0, 0, 10, 0, 10, 10), 2, gogis.WithEndCap(gogis.EndCapFlat))
+		require.NoError(t, err)
+
+		converter, ok := corridor.Geometry.(gogis.EWKBConverter)
+		require.True(t, ok)
+
+		for _, testCase := range []struct {
+			x        float64
+			y        float64
+			expected bool
+		}{
+			{x: 5, y: 1.5, expected: true},
+			{x: 11.5, y: 5, expected: true},
+			{x: 5, y: 2.5, expected: false},
+			{x: -1, y: 0, expected: false},
+		} {
+			covered, err := gogis.Covers(converter, &gogis.Point{Coordinate: ewkb.Coordinate{'x': testCase.x, 'y': testCase.y}})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, covered, "%v %v", testCase.x, testCase.y)
+		}
+	})
+
+	t.Run("self-intersecting routes", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1)) //nolint: gosec
+
+		for idx := 0; idx < 50; idx++ {
+			values := make([]float64, 16)
+			for valueIdx := range values {
+				values[valueIdx] = random.Float64() * 100
+			}
+
+			for _, join := range []gogis.JoinStyle{gogis.JoinRound, gogis.JoinMitre, gogis.JoinBevel} {
+				result, err := gogis.Buffer(line(values...), 3, gogis.WithJoin(join))
+				require.NoError(t, err)
+
+				converter, ok := result.Geometry.(gogis.EWKBConverter)
+				require.True(t, ok)
+				assert.Empty(t, gogis.Validate(converter), "%v", values)
+			}
+		}
+	})
+
+	t.Run("long route", func(t *testing.T) {
+		values := make([]float64, 0, 2400)
+		for idx := 0; idx < 1200; idx++ {
+			values = append(values, float64(idx)*10, 50*math.Sin(float64(idx)/7))
+		}
+
+		result, err := gogis.Buffer(line(values...), 20)
+		require.NoError(t, err)
+		require.Equal(t, ewkb.GeometryTypePolygon, result.Type)
+
+		polygon, ok := result.Geometry.(*gogis.Polygon)
+		require.True(t, ok)
+		assert.Empty(t, gogis.Validate(polygon))
+		assert.Greater(t, polygon.Area(), 40*11990.0)
+	})
+
+	t.Run("curve", func(t *testing.T) {
+		_, err := gogis.Buffer(gogis.CircularString(line(0, 0, 1, 1, 2, 0)), 1)
+		require.ErrorIs(t, err, gogis.ErrUnsupportedGeometry)
+	})
+}
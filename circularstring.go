@@ -10,6 +10,7 @@ import (
 type CircularString []Point
 
 // NullCircularString represents a CircularString that may be null.
+// It has the same semantics as Null[*CircularString].
 // NullCircularString implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullCircularString struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (c *NullCircularString) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	circle := ewkb.CircularString{}
-
-	if err := ewkb.Unmarshal(&circle, value); err != nil {
-		return err
-	}
-
-	c.Valid = true
-
-	return (&c.CircularString).FromEWKB(circle)
+	return scanNull(value, &c.CircularString, &c.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (c *CircularString) Scan(value interface{}) error {
 	circle := ewkb.CircularString{}
 
-	if err := ewkb.Unmarshal(&circle, value); err != nil {
+	if err := unmarshal(&circle, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (c NullCircularString) Value() (driver.Value, error) {
 	return c.CircularString.Value()
 }
 
+// State is the NULL / EMPTY / value state of the CircularString.
+func (c NullCircularString) State() State {
+	return stateOf(c.Valid, c.CircularString)
+}
+
 // ToEWKB implements the ModelConverter interface.
 func (c CircularString) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	var srid *ewkb.SystemReferenceID
@@ -85,6 +79,11 @@ func (c CircularString) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &circle
 }
 
+// IsEmpty checks if the CircularString has no point (CIRCULARSTRING EMPTY).
+func (c CircularString) IsEmpty() bool {
+	return len(c) == 0
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (c *CircularString) FromEWKB(from interface{}) error {
 	circular, ok := fromPtr(from).(ewkb.CircularString)
@@ -117,3 +116,37 @@ func (c CircularString) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the circular string.
+func (c CircularString) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(c.ToEWKB())
+}
+
+// CircularStringArray is an array of CircularString (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type CircularStringArray []CircularString
+
+// Scan implements the SQL driver.Scanner interface.
+func (c *CircularStringArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*c = make(CircularStringArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*c)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (c CircularStringArray) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(c), func(idx int) (driver.Value, error) {
+		return c[idx].Value()
+	})
+}
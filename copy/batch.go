@@ -0,0 +1,95 @@
+package copy //nolint: predeclared
+
+import (
+	"io"
+)
+
+// BatchEncoder splits rows into several COPY streams of at most size rows.
+//
+// A new stream is opened (with open) before the first row of each batch, and
+// is closed once the batch is full, or when the BatchEncoder is closed:
+//
+//	batch := copy.NewBatchEncoder(10000, func() (io.WriteCloser, error) {
+//		reader, writer := io.Pipe()
+//		go func() {
+//			_, err := conn.PgConn().CopyFrom(ctx, reader, query)
+//			_ = reader.CloseWithError(err)
+//		}()
+//
+//		return writer, nil
+//	}, copy.WithFormat(copy.FormatBinary))
+type BatchEncoder struct {
+	size int
+	open func() (io.WriteCloser, error)
+	opts []func(*Encoder)
+
+	encoder *Encoder
+	writer  io.WriteCloser
+	count   int
+	batches int
+}
+
+// NewBatchEncoder creates a batch encoder. A size lower than 1 means a single batch.
+func NewBatchEncoder(size int, open func() (io.WriteCloser, error), opts ...func(*Encoder)) *BatchEncoder {
+	return &BatchEncoder{
+		size: size,
+		open: open,
+		opts: opts,
+	}
+}
+
+// Encode writes a row in the current batch.
+func (b *BatchEncoder) Encode(row interface{}) error {
+	if b.encoder == nil {
+		writer, err := b.open()
+		if err != nil {
+			return err
+		}
+
+		b.writer = writer
+		b.encoder = NewEncoder(writer, b.opts...)
+		b.count = 0
+		b.batches++
+	}
+
+	if err := b.encoder.Encode(row); err != nil {
+		return err
+	}
+
+	b.count++
+
+	if b.size > 0 && b.count >= b.size {
+		return b.closeBatch()
+	}
+
+	return nil
+}
+
+// Batches is the number of batches opened so far.
+func (b *BatchEncoder) Batches() int {
+	return b.batches
+}
+
+// Close closes the current batch, if any.
+func (b *BatchEncoder) Close() error {
+	if b.encoder == nil {
+		return nil
+	}
+
+	return b.closeBatch()
+}
+
+func (b *BatchEncoder) closeBatch() error {
+	encoder, writer := b.encoder, b.writer
+
+	b.encoder = nil
+	b.writer = nil
+
+	if err := encoder.Close(); err != nil {
+		_ = writer.Close()
+
+		return err
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,53 @@
+package copy_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/landru29/gogis/copy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closingBuffer struct {
+	*bytes.Buffer
+	closed bool
+}
+
+func (c *closingBuffer) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestBatchEncoder(t *testing.T) {
+	streams := []*closingBuffer{}
+
+	batch := copy.NewBatchEncoder(2, func() (io.WriteCloser, error) {
+		stream := &closingBuffer{Buffer: bytes.NewBuffer(nil)}
+		streams = append(streams, stream)
+
+		return stream, nil
+	}, copy.WithFormat(copy.FormatBinary))
+
+	fixture := pingFixtures()[1]
+
+	for idx := 0; idx < 5; idx++ {
+		fixture.ID = int64(idx)
+		require.NoError(t, batch.Encode(fixture))
+	}
+
+	require.NoError(t, batch.Close())
+
+	assert.Equal(t, 3, batch.Batches())
+	require.Len(t, streams, 3)
+
+	for idx, expectedRows := range []int{2, 2, 1} {
+		assert.True(t, streams[idx].closed)
+
+		fake := fakeCopy{}
+		fake.parseBinary(t, streams[idx].Bytes())
+		assert.Len(t, fake.rows, expectedRows)
+	}
+}
@@ -0,0 +1,155 @@
+// Package copy generates PostgreSQL "COPY ... FROM STDIN" streams from
+// structures holding gogis geometries.
+//
+// Each exported field of the structure is a column. The column name is
+// the one of the "copy" tag, or the name of the field; the "-" tag skips
+// the field:
+//
+//	type Ping struct {
+//		ID       int64           `copy:"id"`
+//		Position gogis.Point     `copy:"position"`
+//		Zone     gogis.NullPolygon `copy:"zone"`
+//		internal string
+//	}
+//
+// The stream can be written in text or binary format:
+//
+//	query, err := copy.Statement("pings", Ping{}, copy.FormatBinary)
+//	// COPY "pings" ("id", "position", "zone") FROM STDIN WITH (FORMAT binary)
+//
+//	encoder := copy.NewEncoder(writer, copy.WithFormat(copy.FormatBinary))
+//	for _, ping := range pings {
+//		if err := encoder.Encode(ping); err != nil {
+//			return err
+//		}
+//	}
+//
+//	err := encoder.Close()
+//
+// In text format, geometries are written as hexadecimal EWKB, and the other
+// bytes ([]byte fields, driver.Valuer returning []byte) as bytea; in binary
+// format, geometries are written as raw EWKB. NULL comes from nil pointers and from
+// driver.Valuer returning nil (Null* types with Valid=false, sql.NullInt64...).
+//
+// In binary format, integers are written with the size of the Go type (int8 and
+// uint8 as smallint, uint16 as integer, uint32 as bigint), and time.Time as a
+// timestamp.
+package copy //nolint: predeclared
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Format is the format of the COPY stream.
+type Format uint8
+
+const (
+	// FormatText is the default tab-separated text format.
+	FormatText Format = 0
+
+	// FormatBinary is the PostgreSQL binary format.
+	FormatBinary Format = 1
+)
+
+const tagName = "copy"
+
+// String implements the fmt.Stringer interface.
+func (f Format) String() string {
+	if f == FormatBinary {
+		return "binary"
+	}
+
+	return "text"
+}
+
+// Columns lists the column names of the row structure.
+func Columns(row interface{}) ([]string, error) {
+	fields, err := fieldsOf(reflect.TypeOf(row))
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]string, len(fields))
+
+	for idx, field := range fields {
+		output[idx] = field.name
+	}
+
+	return output, nil
+}
+
+// Statement builds the COPY statement for the row structure. The identifiers
+// are quoted; a table name with dots is a qualified name ("schema.table").
+func Statement(table string, row interface{}, format Format) (string, error) {
+	columns, err := Columns(row)
+	if err != nil {
+		return "", err
+	}
+
+	for idx, column := range columns {
+		columns[idx] = quoteIdentifier(column)
+	}
+
+	parts := strings.Split(table, ".")
+	for idx, part := range parts {
+		parts[idx] = quoteIdentifier(part)
+	}
+
+	return fmt.Sprintf(
+		"COPY %s (%s) FROM STDIN WITH (FORMAT %s)",
+		strings.Join(parts, "."),
+		strings.Join(columns, ", "),
+		format,
+	), nil
+}
+
+// quoteIdentifier quotes an SQL identifier.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+type field struct {
+	name  string
+	index int
+}
+
+func fieldsOf(rowType reflect.Type) ([]field, error) {
+	if rowType == nil {
+		return nil, ErrNotAStructure
+	}
+
+	if rowType.Kind() == reflect.Pointer {
+		rowType = rowType.Elem()
+	}
+
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotAStructure, rowType)
+	}
+
+	output := []field{}
+
+	for idx := 0; idx < rowType.NumField(); idx++ {
+		structField := rowType.Field(idx)
+		if !structField.IsExported() {
+			continue
+		}
+
+		name := structField.Name
+
+		if tag, ok := structField.Tag.Lookup(tagName); ok {
+			if tag == "-" {
+				continue
+			}
+
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		output = append(output, field{name: name, index: idx})
+	}
+
+	return output, nil
+}
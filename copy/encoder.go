@@ -0,0 +1,456 @@
+package copy //nolint: predeclared
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/landru29/gogis"
+)
+
+const (
+	textNull      = `\N`
+	textDelimiter = '\t'
+	textEndOfRow  = '\n'
+
+	binaryNull int32 = -1
+
+	// postgresEpoch is the origin of the PostgreSQL timestamps, in Unix seconds (2000-01-01 UTC).
+	postgresEpoch = 946684800
+)
+
+// nolint: gochecknoglobals
+var (
+	binarySignature = []byte("PGCOPY\n\xff\r\n\x00")
+
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	textEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		"\t", `\t`,
+		"\n", `\n`,
+		"\r", `\r`,
+	)
+)
+
+// Encoder writes rows in the COPY format.
+type Encoder struct {
+	writer *bufio.Writer
+	format Format
+
+	rowType reflect.Type
+	fields  []field
+	started bool
+	closed  bool
+}
+
+// WithFormat sets the format of the stream (FormatText by default).
+func WithFormat(format Format) func(*Encoder) {
+	return func(encoder *Encoder) {
+		encoder.format = format
+	}
+}
+
+// NewEncoder creates a COPY encoder.
+func NewEncoder(writer io.Writer, opts ...func(*Encoder)) *Encoder {
+	output := &Encoder{
+		writer: bufio.NewWriter(writer),
+	}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return output
+}
+
+// Encode writes a row. All the rows of a stream must have the same type.
+func (e *Encoder) Encode(row interface{}) error {
+	if e.closed {
+		return ErrClosed
+	}
+
+	value := reflect.ValueOf(row)
+	if value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+
+	// nil, or a nil pointer.
+	if !value.IsValid() {
+		return fmt.Errorf("%w: %T", ErrNotAStructure, row)
+	}
+
+	if err := e.checkType(value.Type()); err != nil {
+		return err
+	}
+
+	if !e.started {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+
+		e.started = true
+	}
+
+	// Work on an addressable copy, so that pointer receivers are reachable.
+	addressable := reflect.New(value.Type()).Elem()
+	addressable.Set(value)
+
+	if e.format == FormatBinary {
+		return e.writeBinaryRow(addressable)
+	}
+
+	return e.writeTextRow(addressable)
+}
+
+// Flush writes buffered data to the underlying writer.
+func (e *Encoder) Flush() error {
+	return e.writer.Flush()
+}
+
+// Close writes the end of the stream and flushes. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return nil
+	}
+
+	e.closed = true
+
+	if e.format == FormatBinary {
+		if !e.started {
+			if err := e.writeHeader(); err != nil {
+				return err
+			}
+		}
+
+		if err := binary.Write(e.writer, binary.BigEndian, int16(-1)); err != nil {
+			return err
+		}
+	}
+
+	return e.writer.Flush()
+}
+
+func (e *Encoder) checkType(rowType reflect.Type) error {
+	if e.rowType == nil {
+		fields, err := fieldsOf(rowType)
+		if err != nil {
+			return err
+		}
+
+		e.rowType = rowType
+		e.fields = fields
+
+		return nil
+	}
+
+	if e.rowType != rowType {
+		return fmt.Errorf("%w: found %s, expected %s", ErrRowMismatch, rowType, e.rowType)
+	}
+
+	return nil
+}
+
+func (e *Encoder) writeHeader() error {
+	if e.format != FormatBinary {
+		return nil
+	}
+
+	if _, err := e.writer.Write(binarySignature); err != nil {
+		return err
+	}
+
+	// Flags and header extension length.
+	return binary.Write(e.writer, binary.BigEndian, [2]int32{0, 0})
+}
+
+func (e *Encoder) writeTextRow(row reflect.Value) error {
+	for idx, field := range e.fields {
+		if idx > 0 {
+			if err := e.writer.WriteByte(textDelimiter); err != nil {
+				return err
+			}
+		}
+
+		text, err := textValue(row.Field(field.index))
+		if err != nil {
+			return fmt.Errorf("column %s: %w", field.name, err)
+		}
+
+		if _, err := e.writer.WriteString(text); err != nil {
+			return err
+		}
+	}
+
+	return e.writer.WriteByte(textEndOfRow)
+}
+
+func (e *Encoder) writeBinaryRow(row reflect.Value) error {
+	if err := binary.Write(e.writer, binary.BigEndian, int16(len(e.fields))); err != nil {
+		return err
+	}
+
+	for _, field := range e.fields {
+		data, err := binaryValue(row.Field(field.index))
+		if err != nil {
+			return fmt.Errorf("column %s: %w", field.name, err)
+		}
+
+		if data == nil {
+			if err := binary.Write(e.writer, binary.BigEndian, binaryNull); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := binary.Write(e.writer, binary.BigEndian, int32(len(data))); err != nil {
+			return err
+		}
+
+		if _, err := e.writer.Write(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// valuer returns the driver.Valuer implemented by the value or its address.
+func valuer(value reflect.Value) (driver.Valuer, bool) {
+	if value.Type().Implements(valuerType) {
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			return nil, false
+		}
+
+		out, ok := value.Interface().(driver.Valuer)
+
+		return out, ok
+	}
+
+	if value.CanAddr() && value.Addr().Type().Implements(valuerType) {
+		out, ok := value.Addr().Interface().(driver.Valuer)
+
+		return out, ok
+	}
+
+	return nil, false
+}
+
+func textValue(value reflect.Value) (string, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return textNull, nil
+		}
+
+		if _, ok := valuer(value); !ok {
+			return textValue(value.Elem())
+		}
+	}
+
+	if val, ok := valuer(value); ok {
+		return textDriverValue(val)
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+		if value.IsNil() {
+			return textNull, nil
+		}
+
+		// bytea hex format; the backslash is escaped for the COPY text format.
+		return `\\x` + hex.EncodeToString(value.Bytes()), nil
+	}
+
+	switch value.Kind() { //nolint: exhaustive
+	case reflect.String:
+		return textEscaper.Replace(value.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil //nolint: gomnd
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil //nolint: gomnd
+	case reflect.Float32:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 32), nil //nolint: gomnd
+	case reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'g', -1, 64), nil //nolint: gomnd
+	}
+
+	if date, ok := value.Interface().(time.Time); ok {
+		return date.Format(time.RFC3339Nano), nil
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+}
+
+// textDriverValue encodes the value of a driver.Valuer. The gogis geometries
+// return hexadecimal EWKB, and their arrays the text format of arrays, written
+// as text; the other bytes are written as bytea.
+func textDriverValue(val driver.Valuer) (string, error) {
+	value, err := val.Value()
+	if err != nil {
+		return "", err
+	}
+
+	switch data := value.(type) {
+	case nil:
+		return textNull, nil
+	case []byte:
+		if !isGeometry(val) && !isGeometryArray(val) {
+			return `\\x` + hex.EncodeToString(data), nil
+		}
+
+		return textEscaper.Replace(string(data)), nil
+	case string:
+		return textEscaper.Replace(data), nil
+	case int64:
+		return strconv.FormatInt(data, 10), nil //nolint: gomnd
+	case float64:
+		return strconv.FormatFloat(data, 'g', -1, 64), nil //nolint: gomnd
+	case bool:
+		return strconv.FormatBool(data), nil
+	case time.Time:
+		return data.Format(time.RFC3339Nano), nil
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedType, value)
+}
+
+// binaryValue encodes the value in the PostgreSQL binary format. A nil output means NULL.
+func binaryValue(value reflect.Value) ([]byte, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		if _, ok := valuer(value); !ok {
+			return binaryValue(value.Elem())
+		}
+	}
+
+	if val, ok := valuer(value); ok {
+		return binaryDriverValue(val)
+	}
+
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8 {
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		return append([]byte{}, value.Bytes()...), nil
+	}
+
+	switch value.Kind() { //nolint: exhaustive
+	case reflect.String:
+		return []byte(value.String()), nil
+	case reflect.Bool:
+		if value.Bool() {
+			return []byte{1}, nil
+		}
+
+		return []byte{0}, nil
+	case reflect.Int8, reflect.Int16:
+		return binaryInteger(uint64(value.Int()), 2), nil //nolint: gomnd
+	case reflect.Uint8:
+		return binaryInteger(value.Uint(), 2), nil //nolint: gomnd
+	case reflect.Int32:
+		return binaryInteger(uint64(value.Int()), 4), nil //nolint: gomnd
+	case reflect.Uint16:
+		return binaryInteger(value.Uint(), 4), nil //nolint: gomnd
+	case reflect.Int, reflect.Int64:
+		return binaryInteger(uint64(value.Int()), 8), nil //nolint: gomnd
+	case reflect.Uint32:
+		return binaryInteger(value.Uint(), 8), nil //nolint: gomnd
+	case reflect.Uint, reflect.Uint64:
+		if value.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d overflows bigint", ErrUnsupportedType, value.Uint())
+		}
+
+		return binaryInteger(value.Uint(), 8), nil //nolint: gomnd
+	case reflect.Float32:
+		output := make([]byte, 4) //nolint: gomnd
+		binary.BigEndian.PutUint32(output, math.Float32bits(float32(value.Float())))
+
+		return output, nil
+	case reflect.Float64:
+		output := make([]byte, 8) //nolint: gomnd
+		binary.BigEndian.PutUint64(output, math.Float64bits(value.Float()))
+
+		return output, nil
+	}
+
+	if date, ok := value.Interface().(time.Time); ok {
+		// Microseconds since the PostgreSQL epoch.
+		micro := (date.Unix()-postgresEpoch)*int64(time.Second/time.Microsecond) + int64(date.Nanosecond()/int(time.Microsecond))
+
+		return binaryInteger(uint64(micro), 8), nil //nolint: gomnd
+	}
+
+	return nil, fmt.Errorf("%w: %s in binary format", ErrUnsupportedType, value.Type())
+}
+
+// binaryInteger writes the size lower bytes of the value, in big endian.
+func binaryInteger(value uint64, size int) []byte {
+	output := make([]byte, 8) //nolint: gomnd
+	binary.BigEndian.PutUint64(output, value)
+
+	return output[8-size:]
+}
+
+// binaryDriverValue encodes the value of a driver.Valuer. The gogis geometries
+// return hexadecimal EWKB, written as raw EWKB; the other values are written as
+// their type is.
+func binaryDriverValue(val driver.Valuer) ([]byte, error) {
+	drvValue, err := val.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if drvValue == nil {
+		return nil, nil
+	}
+
+	if data, ok := drvValue.([]byte); ok && isGeometry(val) {
+		output := make([]byte, hex.DecodedLen(len(data)))
+
+		if _, err := hex.Decode(output, data); err != nil {
+			return nil, fmt.Errorf("%w: %T must return hexadecimal EWKB: %s", ErrUnsupportedType, val, err)
+		}
+
+		return output, nil
+	}
+
+	// The arrays of geometries are written in the text format of arrays.
+	if isGeometryArray(val) {
+		return nil, fmt.Errorf("%w: %T in binary format", ErrUnsupportedType, val)
+	}
+
+	return binaryValue(reflect.ValueOf(drvValue))
+}
+
+// isGeometry checks whether the value is a gogis geometry: a model, a Null*
+// type or gogis.Geometry.
+func isGeometry(value interface{}) bool {
+	switch value.(type) {
+	case gogis.EWKBConverter, interface{ State() gogis.State }:
+		return true
+	}
+
+	return false
+}
+
+// isGeometryArray checks whether the value is a slice of gogis geometries, such
+// as the gogis arrays.
+func isGeometryArray(value interface{}) bool {
+	valueType := reflect.TypeOf(value)
+
+	return valueType.Kind() == reflect.Slice && isGeometry(reflect.New(valueType.Elem()).Interface())
+}
@@ -0,0 +1,302 @@
+package copy_test
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/copy"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ping struct {
+	ID       int64             `copy:"id"`
+	Label    string            `copy:"label"`
+	Speed    *float64          `copy:"speed"`
+	Position gogis.Point       `copy:"position"`
+	Zone     gogis.NullPolygon `copy:"zone"`
+	Ignored  string            `copy:"-"`
+	internal string
+}
+
+func pingFixtures() []ping {
+	speed := 12.5
+
+	return []ping{
+		{
+			ID:    1,
+			Label: "tab\tnew line\nback\\slash",
+			Speed: &speed,
+			Position: gogis.Point{
+				Coordinate: ewkb.Coordinate{'x': -71.060316, 'y': 48.432044},
+				SRID:       ewkb.WithSRID(ewkb.SystemReferenceWGS84),
+			},
+			Ignored:  "ignored",
+			internal: "internal",
+		},
+		{
+			ID:    2,
+			Label: "second",
+			Position: gogis.Point{
+				Coordinate: ewkb.Coordinate{'x': 5, 'y': 6},
+			},
+			Zone: gogis.NullPolygon{
+				Valid: true,
+				Polygon: gogis.Polygon{
+					{
+						{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}},
+						{Coordinate: ewkb.Coordinate{'x': 1, 'y': 0}},
+						{Coordinate: ewkb.Coordinate{'x': 1, 'y': 1}},
+						{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func hexEWKB(t *testing.T, geometry gogis.EWKBConverter) []byte {
+	t.Helper()
+
+	data, err := ewkb.Marshal(geometry.ToEWKB())
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestStatement(t *testing.T) {
+	query, err := copy.Statement("pings", ping{}, copy.FormatBinary)
+	require.NoError(t, err)
+	assert.Equal(t, `COPY "pings" ("id", "label", "speed", "position", "zone") FROM STDIN WITH (FORMAT binary)`, query)
+
+	query, err = copy.Statement("tracking.pings", &ping{}, copy.FormatText)
+	require.NoError(t, err)
+	assert.Equal(t, `COPY "tracking"."pings" ("id", "label", "speed", "position", "zone") FROM STDIN WITH (FORMAT text)`, query)
+
+	query, err = copy.Statement(`order`, struct {
+		Name string `copy:"Full \"name\""`
+	}{}, copy.FormatText)
+	require.NoError(t, err)
+	assert.Equal(t, `COPY "order" ("Full ""name""") FROM STDIN WITH (FORMAT text)`, query)
+
+	_, err = copy.Statement("pings", 42, copy.FormatText)
+	assert.ErrorIs(t, err, copy.ErrNotAStructure)
+
+	_, err = copy.Columns(42)
+	assert.ErrorIs(t, err, copy.ErrNotAStructure)
+}
+
+func TestEncoderText(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	encoder := copy.NewEncoder(buffer)
+
+	fixtures := pingFixtures()
+
+	for _, fixture := range fixtures {
+		require.NoError(t, encoder.Encode(fixture))
+	}
+
+	require.NoError(t, encoder.Close())
+
+	fake := fakeCopy{}
+	fake.parseText(t, buffer.Bytes())
+
+	assert.Equal(t, [][][]byte{
+		{
+			[]byte("1"),
+			[]byte("tab\tnew line\nback\\slash"),
+			[]byte("12.5"),
+			hexEWKB(t, fixtures[0].Position),
+			nil,
+		},
+		{
+			[]byte("2"),
+			[]byte("second"),
+			nil,
+			hexEWKB(t, fixtures[1].Position),
+			hexEWKB(t, fixtures[1].Zone.Polygon),
+		},
+	}, fake.rows)
+
+	assert.ErrorIs(t, encoder.Encode(fixtures[0]), copy.ErrClosed)
+}
+
+func TestEncoderBinary(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	encoder := copy.NewEncoder(buffer, copy.WithFormat(copy.FormatBinary))
+
+	fixtures := pingFixtures()
+
+	for idx := range fixtures {
+		require.NoError(t, encoder.Encode(&fixtures[idx]))
+	}
+
+	require.NoError(t, encoder.Close())
+
+	fake := fakeCopy{}
+	fake.parseBinary(t, buffer.Bytes())
+
+	require.Len(t, fake.rows, 2)
+
+	assert.Equal(t, uint64(1), binary.BigEndian.Uint64(fake.rows[0][0]))
+	assert.Equal(t, "tab\tnew line\nback\\slash", string(fake.rows[0][1]))
+	assert.Equal(t, 12.5, math.Float64frombits(binary.BigEndian.Uint64(fake.rows[0][2])))
+	assert.Nil(t, fake.rows[0][4])
+	assert.Nil(t, fake.rows[1][2])
+
+	position := gogis.Point{}
+	require.NoError(t, position.Scan(hex.EncodeToString(fake.rows[0][3])))
+	assert.Equal(t, fixtures[0].Position, position)
+
+	zone := gogis.Polygon{}
+	require.NoError(t, zone.Scan(hex.EncodeToString(fake.rows[1][4])))
+	assert.Equal(t, fixtures[1].Zone.Polygon, zone)
+}
+
+// rawBytes is a driver.Valuer returning raw bytes, such as a bytea.
+type rawBytes []byte
+
+func (r rawBytes) Value() (driver.Value, error) {
+	return []byte(r), nil
+}
+
+func TestEncoderTextBytea(t *testing.T) {
+	row := struct {
+		Raw      rawBytes
+		Position gogis.Point
+		Points   gogis.PointArray
+	}{
+		Raw:    rawBytes{0x00, 0xff, '\t', '\\'},
+		Points: gogis.PointArray{{}},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := copy.NewEncoder(buffer)
+	require.NoError(t, encoder.Encode(row))
+	require.NoError(t, encoder.Close())
+
+	fake := fakeCopy{}
+	fake.parseText(t, buffer.Bytes())
+	require.Len(t, fake.rows, 1)
+
+	points, err := row.Points.Value()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]byte{
+		[]byte(`\x00ff095c`),
+		hexEWKB(t, row.Position),
+		points.([]byte),
+	}, fake.rows[0])
+}
+
+func TestEncoderBinaryValues(t *testing.T) {
+	date := time.Date(2000, 1, 2, 0, 0, 1, 500000, time.UTC)
+
+	row := struct {
+		Count   sql.NullInt64
+		Name    sql.NullString
+		Date    sql.NullTime
+		Missing sql.NullInt64
+		Ratio   sql.NullFloat64
+		Flag    sql.NullBool
+		Raw     rawBytes
+		Small   uint8
+		Medium  uint16
+		Large   uint32
+		Huge    uint64
+		At      time.Time
+	}{
+		Count: sql.NullInt64{Int64: 42, Valid: true},
+		Name:  sql.NullString{String: "name", Valid: true},
+		Date:  sql.NullTime{Time: date, Valid: true},
+		Ratio: sql.NullFloat64{Float64: 0.5, Valid: true},
+		Flag:  sql.NullBool{Bool: true, Valid: true},
+		Raw:   rawBytes{0x01, 0xab},
+		Small: 200, Medium: 60000, Large: 4000000000, Huge: 1 << 40,
+		At: date,
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	encoder := copy.NewEncoder(buffer, copy.WithFormat(copy.FormatBinary))
+	require.NoError(t, encoder.Encode(row))
+	require.NoError(t, encoder.Close())
+
+	fake := fakeCopy{}
+	fake.parseBinary(t, buffer.Bytes())
+	require.Len(t, fake.rows, 1)
+
+	// One day, one second and 500 microseconds after the PostgreSQL epoch.
+	timestamp := []byte{0, 0, 0, 0x14, 0x1d, 0xe6, 0xa4, 0x34}
+
+	assert.Equal(t, [][]byte{
+		{0, 0, 0, 0, 0, 0, 0, 42},
+		[]byte("name"),
+		timestamp,
+		nil,
+		{0x3f, 0xe0, 0, 0, 0, 0, 0, 0},
+		{1},
+		{0x01, 0xab},
+		{0, 200},
+		{0, 0, 0xea, 0x60},
+		{0, 0, 0, 0, 0xee, 0x6b, 0x28, 0},
+		{0, 0, 1, 0, 0, 0, 0, 0},
+		timestamp,
+	}, fake.rows[0])
+
+	t.Run("overflow", func(t *testing.T) {
+		encoder := copy.NewEncoder(bytes.NewBuffer(nil), copy.WithFormat(copy.FormatBinary))
+
+		assert.ErrorIs(t, encoder.Encode(struct{ Value uint64 }{math.MaxUint64}), copy.ErrUnsupportedType)
+	})
+
+	t.Run("geometry array", func(t *testing.T) {
+		encoder := copy.NewEncoder(bytes.NewBuffer(nil), copy.WithFormat(copy.FormatBinary))
+
+		assert.ErrorIs(t, encoder.Encode(struct{ Points gogis.PointArray }{gogis.PointArray{{}}}), copy.ErrUnsupportedType)
+	})
+}
+
+func TestEncoderEmptyBinary(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+
+	require.NoError(t, copy.NewEncoder(buffer, copy.WithFormat(copy.FormatBinary)).Close())
+
+	fake := fakeCopy{}
+	fake.parseBinary(t, buffer.Bytes())
+	assert.Empty(t, fake.rows)
+}
+
+func TestEncoderErrors(t *testing.T) {
+	t.Run("row mismatch", func(t *testing.T) {
+		encoder := copy.NewEncoder(bytes.NewBuffer(nil))
+
+		require.NoError(t, encoder.Encode(pingFixtures()[0]))
+		assert.ErrorIs(t, encoder.Encode(struct{ ID int }{}), copy.ErrRowMismatch)
+	})
+
+	t.Run("not a structure", func(t *testing.T) {
+		assert.ErrorIs(t, copy.NewEncoder(bytes.NewBuffer(nil)).Encode(42), copy.ErrNotAStructure)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		assert.ErrorIs(t, copy.NewEncoder(bytes.NewBuffer(nil)).Encode(nil), copy.ErrNotAStructure)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		assert.ErrorIs(t, copy.NewEncoder(bytes.NewBuffer(nil)).Encode((*ping)(nil)), copy.ErrNotAStructure)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		encoder := copy.NewEncoder(bytes.NewBuffer(nil), copy.WithFormat(copy.FormatBinary))
+
+		assert.ErrorIs(t, encoder.Encode(struct{ Values []int }{}), copy.ErrUnsupportedType)
+	})
+}
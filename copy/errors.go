@@ -0,0 +1,22 @@
+package copy //nolint: predeclared
+
+// Error is a COPY error.
+type Error string
+
+const (
+	// ErrNotAStructure occurs when the row is not a structure.
+	ErrNotAStructure = Error("row is not a structure")
+
+	// ErrRowMismatch occurs when rows of different types are written in the same stream.
+	ErrRowMismatch = Error("row type mismatch")
+
+	// ErrUnsupportedType occurs when a value cannot be written in the chosen format.
+	ErrUnsupportedType = Error("unsupported type")
+
+	// ErrClosed occurs when writing to a closed encoder.
+	ErrClosed = Error("encoder is closed")
+)
+
+func (e Error) Error() string {
+	return string(e)
+}
@@ -0,0 +1,103 @@
+package copy_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errFakeCopy = errors.New("fake copy: malformed stream")
+
+// fakeCopy is a fake database parsing COPY ... FROM STDIN streams.
+// A NULL field is a nil slice.
+type fakeCopy struct {
+	rows [][][]byte
+}
+
+func (f *fakeCopy) parseText(t *testing.T, stream []byte) {
+	t.Helper()
+
+	unescaper := strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n", `\r`, "\r")
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(stream), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+
+		row := [][]byte{}
+
+		for _, field := range strings.Split(line, "\t") {
+			if field == `\N` {
+				row = append(row, nil)
+
+				continue
+			}
+
+			row = append(row, []byte(unescaper.Replace(field)))
+		}
+
+		f.rows = append(f.rows, row)
+	}
+}
+
+func (f *fakeCopy) parseBinary(t *testing.T, stream []byte) {
+	t.Helper()
+
+	require.NoError(t, f.readBinary(bytes.NewBuffer(stream)))
+}
+
+func (f *fakeCopy) readBinary(reader io.Reader) error {
+	signature := make([]byte, 11)
+	if _, err := io.ReadFull(reader, signature); err != nil {
+		return err
+	}
+
+	if string(signature) != "PGCOPY\n\xff\r\n\x00" {
+		return errFakeCopy
+	}
+
+	var header [2]int32
+	if err := binary.Read(reader, binary.BigEndian, &header); err != nil {
+		return err
+	}
+
+	for {
+		var count int16
+		if err := binary.Read(reader, binary.BigEndian, &count); err != nil {
+			return err
+		}
+
+		if count == -1 {
+			if n, _ := reader.Read(make([]byte, 1)); n != 0 {
+				return errFakeCopy
+			}
+
+			return nil
+		}
+
+		row := make([][]byte, count)
+
+		for idx := range row {
+			var size int32
+			if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+				return err
+			}
+
+			if size == -1 {
+				continue
+			}
+
+			row[idx] = make([]byte, size)
+			if _, err := io.ReadFull(reader, row[idx]); err != nil {
+				return err
+			}
+		}
+
+		f.rows = append(f.rows, row)
+	}
+}
@@ -0,0 +1,303 @@
+package gogis
+
+import (
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// CurveTolerance is the meaning of the tolerance of CurveToLine, as the
+// tolerance_type of ST_CurveToLine.
+type CurveTolerance uint8
+
+const (
+	// CurveToleranceSegments is a number of segments per quarter circle.
+	CurveToleranceSegments CurveTolerance = iota
+
+	// CurveToleranceDeviation is the maximum distance between an arc and its segments.
+	CurveToleranceDeviation
+
+	// CurveToleranceAngle is the maximum angle of an arc covered by a segment, in radians.
+	CurveToleranceAngle
+)
+
+const (
+	defaultCurveSegments = 32
+
+	// curveEpsilon is the relative distance of a vertex to a circle, and the
+	// relative difference of angles, still on the same arc for LineToCurve.
+	curveEpsilon = 1e-8
+
+	// curveQuadrantEdges is the minimum number of segments per quarter circle
+	// of an arc found by LineToCurve.
+	curveQuadrantEdges = 2
+)
+
+// CurveToLineOptions are the parameters of CurveToLine.
+type CurveToLineOptions struct {
+	// Tolerance is the tolerance of the approximation (32 segments per quarter circle by default).
+	Tolerance float64
+
+	// ToleranceType is the meaning of the tolerance.
+	ToleranceType CurveTolerance
+}
+
+// WithSegmentsPerQuadrant approximates the arcs with a number of segments per quarter circle.
+func WithSegmentsPerQuadrant(segments int) func(*CurveToLineOptions) {
+	return func(options *CurveToLineOptions) {
+		options.Tolerance = float64(segments)
+		options.ToleranceType = CurveToleranceSegments
+	}
+}
+
+// WithMaxDeviation approximates the arcs with segments not farther than the
+// deviation from the arc.
+func WithMaxDeviation(deviation float64) func(*CurveToLineOptions) {
+	return func(options *CurveToLineOptions) {
+		options.Tolerance = deviation
+		options.ToleranceType = CurveToleranceDeviation
+	}
+}
+
+// WithMaxAngle approximates the arcs with segments covering at most the angle (in radians).
+func WithMaxAngle(angle float64) func(*CurveToLineOptions) {
+	return func(options *CurveToLineOptions) {
+		options.Tolerance = angle
+		options.ToleranceType = CurveToleranceAngle
+	}
+}
+
+// CurveToLine approximates the circular strings of a geometry with linestrings,
+// as ST_CurveToLine does; the other geometries are returned as they are.
+//
+//	line, err := gogis.CurveToLine(curve, gogis.WithMaxDeviation(0.01))
+//
+// The segments of an arc have the same angle. Z and M are interpolated along
+// each half of the arc, from the start to the middle point, then to the end;
+// an arc starting and ending at the same point is a full circle. A tolerance
+// that is not positive falls back to the default.
+func CurveToLine(geometry EWKBConverter, opts ...func(*CurveToLineOptions)) (Geometry, error) {
+	options := CurveToLineOptions{
+		Tolerance:     defaultCurveSegments,
+		ToleranceType: CurveToleranceSegments,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.Tolerance <= 0 {
+		options = CurveToLineOptions{Tolerance: defaultCurveSegments, ToleranceType: CurveToleranceSegments}
+	}
+
+	return modelOf(curveToLine(geometry.ToEWKB(), options))
+}
+
+// LineToCurve finds the arcs of the linestrings of a geometry, as ST_LineToCurve
+// does: a linestring with runs of vertices on a circle, at the same angle from
+// each other, becomes a circular string, the other geometries are returned as
+// they are. A run is an arc when it has at least 3 segments, and 2 per quarter
+// circle; the middle vertex of the run is the middle point of the arc. The
+// segments out of the arcs are written as straight arcs (their middle point is
+// on the segment), and a closed run as two arcs.
+func LineToCurve(geometry EWKBConverter) (Geometry, error) {
+	return modelOf(lineToCurve(geometry.ToEWKB()))
+}
+
+func curveToLine(geometry ewkb.Geometry, options CurveToLineOptions) ewkb.Geometry { //nolint: ireturn
+	switch geo := geometry.(type) {
+	case *ewkb.CircularString:
+		return &ewkb.LineString{SRID: geo.SRID, CoordinateSet: linearize(geo.CoordinateSet, options)}
+	case *ewkb.GeometryCollection:
+		output := &ewkb.GeometryCollection{SRID: geo.SRID, Collection: make([]ewkb.Geometry, len(geo.Collection))}
+
+		for idx, sub := range geo.Collection {
+			output.Collection[idx] = curveToLine(sub, options)
+		}
+
+		return output
+	}
+
+	return geometry
+}
+
+func lineToCurve(geometry ewkb.Geometry) ewkb.Geometry { //nolint: ireturn
+	switch geo := geometry.(type) {
+	case *ewkb.LineString:
+		if set, ok := unstroke(geo.CoordinateSet); ok {
+			return &ewkb.CircularString{SRID: geo.SRID, CoordinateSet: set}
+		}
+	case *ewkb.GeometryCollection:
+		output := &ewkb.GeometryCollection{SRID: geo.SRID, Collection: make([]ewkb.Geometry, len(geo.Collection))}
+
+		for idx, sub := range geo.Collection {
+			output.Collection[idx] = lineToCurve(sub)
+		}
+
+		return output
+	}
+
+	return geometry
+}
+
+// linearize approximates the arcs of a circular string.
+func linearize(set ewkb.CoordinateSet, options CurveToLineOptions) ewkb.CoordinateSet {
+	if len(set) == 0 {
+		return ewkb.CoordinateSet{}
+	}
+
+	output := ewkb.CoordinateSet{set[0]}
+
+	for idx := 2; idx < len(set); idx += 2 {
+		output = append(output, linearizeArc(set[idx-2], set[idx-1], set[idx], options)...)
+	}
+
+	return output
+}
+
+// linearizeArc approximates the arc from start to end through middle, without
+// its start.
+func linearizeArc(start ewkb.Coordinate, middle ewkb.Coordinate, end ewkb.Coordinate, options CurveToLineOptions) ewkb.CoordinateSet {
+	arc, ok := ewkb.ArcOf(xyOf(start).coord(), xyOf(middle).coord(), xyOf(end).coord())
+	if !ok {
+		return ewkb.CoordinateSet{middle, end}
+	}
+
+	center, radius, origin := xy{x: arc.Center.X, y: arc.Center.Y}, arc.Radius, arc.StartAngle
+	closed := xyOf(start) == xyOf(end)
+
+	// Counter clockwise angles, unless the sweep is clockwise.
+	direction, sweep := 1.0, arc.Sweep
+	if sweep < 0 {
+		direction, sweep = -1, -sweep
+	}
+
+	middleSweep := math.Mod(direction*(math.Atan2(xyOf(middle).y-center.y, xyOf(middle).x-center.x)-origin), 2*math.Pi) //nolint: gomnd
+
+	if middleSweep < 0 {
+		middleSweep += 2 * math.Pi
+	}
+
+	segments := arcSegments(sweep, radius, options)
+	if closed && segments < 3 { //nolint: gomnd
+		segments = 3
+	}
+
+	output := make(ewkb.CoordinateSet, 0, segments)
+
+	for idx := 1; idx < segments; idx++ {
+		angle := sweep * float64(idx) / float64(segments)
+		position := xy{
+			x: center.x + radius*math.Cos(origin+direction*angle),
+			y: center.y + radius*math.Sin(origin+direction*angle),
+		}
+
+		if angle <= middleSweep {
+			output = append(output, interpolateCoordinate(start, middle, angle/middleSweep, position))
+		} else {
+			output = append(output, interpolateCoordinate(middle, end, (angle-middleSweep)/(sweep-middleSweep), position))
+		}
+	}
+
+	return append(output, end)
+}
+
+// arcSegments is the number of segments approximating an arc.
+func arcSegments(sweep float64, radius float64, options CurveToLineOptions) int {
+	step := options.Tolerance
+
+	switch options.ToleranceType {
+	case CurveToleranceSegments:
+		step = math.Pi / 2 / math.Max(math.Floor(options.Tolerance), 1) //nolint: gomnd
+	case CurveToleranceDeviation:
+		step = math.Pi
+		if options.Tolerance < radius {
+			step = 2 * math.Acos(1-options.Tolerance/radius) //nolint: gomnd
+		}
+	case CurveToleranceAngle:
+	}
+
+	// The rounding errors of the sweep do not add a segment.
+	return int(math.Max(math.Ceil(sweep/step-curveEpsilon), 1))
+}
+
+// unstroke writes a linestring as a circular string, if it has arcs.
+func unstroke(set ewkb.CoordinateSet) (ewkb.CoordinateSet, bool) {
+	if len(set) < 2 { //nolint: gomnd
+		return nil, false
+	}
+
+	output := ewkb.CoordinateSet{set[0]}
+	found := false
+
+	for start := 0; start < len(set)-1; {
+		end, closed := arcEnd(set, start)
+
+		switch {
+		case end == start+1:
+			from, to := xyOf(set[start]), xyOf(set[end])
+			position := xy{x: (from.x + to.x) / 2, y: (from.y + to.y) / 2}                                //nolint: gomnd
+			output = append(output, interpolateCoordinate(set[start], set[end], 0.5, position), set[end]) //nolint: gomnd
+		case closed:
+			half := (start + end) / 2 //nolint: gomnd
+			output = append(output, set[(start+half)/2], set[half], set[(half+end)/2], set[end])
+			found = true
+		default:
+			output = append(output, set[(start+end)/2], set[end])
+			found = true
+		}
+
+		start = end
+	}
+
+	return output, found
+}
+
+// arcEnd is the last vertex of the arc starting at a vertex (the next vertex
+// when there is none), and whether the arc is a full circle.
+func arcEnd(set ewkb.CoordinateSet, start int) (int, bool) {
+	if start+3 >= len(set) {
+		return start + 1, false
+	}
+
+	first := xyOf(set[start])
+
+	centerCoord, ok := ewkb.CircleCenter(first.coord(), xyOf(set[start+1]).coord(), xyOf(set[start+2]).coord())
+	if !ok {
+		return start + 1, false
+	}
+
+	center := xy{x: centerCoord.X, y: centerCoord.Y}
+
+	radius := distance(center, first)
+	step := angleBetween(center, first, xyOf(set[start+1]))
+	sweep := 0.0
+	end := start
+
+	for end+1 < len(set) && math.Abs(sweep) < 2*math.Pi-math.Abs(step)/2 { //nolint: gomnd
+		next := xyOf(set[end+1])
+
+		if math.Abs(distance(center, next)-radius) > curveEpsilon*radius ||
+			math.Abs(angleBetween(center, xyOf(set[end]), next)-step) > curveEpsilon*math.Abs(step) {
+			break
+		}
+
+		sweep += step
+		end++
+	}
+
+	edges := end - start
+	if edges < 3 || float64(edges) < curveQuadrantEdges*math.Ceil(math.Abs(sweep)/(math.Pi/2)-curveEpsilon) { //nolint: gomnd
+		return start + 1, false
+	}
+
+	return end, xyOf(set[end]) == first
+}
+
+// angleBetween is the signed angle from a to b around the center, in ]-π, π].
+func angleBetween(center xy, a xy, b xy) float64 {
+	return math.Atan2(
+		(a.x-center.x)*(b.y-center.y)-(a.y-center.y)*(b.x-center.x),
+		(a.x-center.x)*(b.x-center.x)+(a.y-center.y)*(b.y-center.y),
+	)
+}
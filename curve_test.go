@@ -0,0 +1,196 @@
+package gogis_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurveToLine(t *testing.T) {
+	// Half a circle, of radius 1, centered on (1, 0).
+	arc := gogis.CircularString(line(0, 0, 1, 1, 2, 0))
+
+	for _, testCase := range []struct {
+		name     string
+		options  []func(*gogis.CurveToLineOptions)
+		segments int
+	}{
+		{
+			name:     "default",
+			segments: 64,
+		},
+		{
+			name:     "segments per quadrant",
+			options:  []func(*gogis.CurveToLineOptions){gogis.WithSegmentsPerQuadrant(2)},
+			segments: 4,
+		},
+		{
+			name:     "max deviation",
+			options:  []func(*gogis.CurveToLineOptions){gogis.WithMaxDeviation(1 - math.Cos(math.Pi/12))},
+			segments: 6,
+		},
+		{
+			name:     "max angle",
+			options:  []func(*gogis.CurveToLineOptions){gogis.WithMaxAngle(math.Pi / 3)},
+			segments: 3,
+		},
+		{
+			name:     "invalid tolerance",
+			options:  []func(*gogis.CurveToLineOptions){gogis.WithMaxAngle(0)},
+			segments: 64,
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := gogis.CurveToLine(arc, testCase.options...)
+			require.NoError(t, err)
+			require.Equal(t, ewkb.GeometryTypeLineString, result.Type)
+
+			output, ok := result.Geometry.(*gogis.LineString)
+			require.True(t, ok)
+			require.Len(t, *output, testCase.segments+1)
+			assert.Equal(t, arc[0], (*output)[0])
+			assert.Equal(t, arc[2], (*output)[testCase.segments])
+
+			for _, point := range *output {
+				assert.InDelta(t, 1, math.Hypot(point.Coordinate['x']-1, point.Coordinate['y']), 1e-12)
+				assert.GreaterOrEqual(t, point.Coordinate['y'], 0.0)
+			}
+		})
+	}
+
+	t.Run("z and m", func(t *testing.T) {
+		curve := gogis.CircularString{
+			{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0, 'z': 0, 'm': 100}},
+			{Coordinate: ewkb.Coordinate{'x': 1, 'y': -1, 'z': 10, 'm': 0}},
+			{Coordinate: ewkb.Coordinate{'x': 2, 'y': 0, 'z': 20, 'm': 100}},
+		}
+
+		result, err := gogis.CurveToLine(curve, gogis.WithSegmentsPerQuadrant(2))
+		require.NoError(t, err)
+
+		output, ok := result.Geometry.(*gogis.LineString)
+		require.True(t, ok)
+		require.Len(t, *output, 5)
+
+		for idx, point := range *output {
+			assert.InDelta(t, float64(idx)*5, point.Coordinate['z'], 1e-9)
+			assert.InDelta(t, math.Abs(float64(idx)-2)*50, point.Coordinate['m'], 1e-9)
+			assert.LessOrEqual(t, point.Coordinate['y'], 1e-12)
+		}
+
+		assert.InDelta(t, 1, (*output)[2].Coordinate['x'], 1e-12)
+		assert.InDelta(t, -1, (*output)[2].Coordinate['y'], 1e-12)
+	})
+
+	t.Run("full circle", func(t *testing.T) {
+		result, err := gogis.CurveToLine(gogis.CircularString(line(0, 0, 2, 0, 0, 0)), gogis.WithSegmentsPerQuadrant(4))
+		require.NoError(t, err)
+
+		output, ok := result.Geometry.(*gogis.LineString)
+		require.True(t, ok)
+		require.Len(t, *output, 17)
+		assert.Equal(t, (*output)[0], (*output)[16])
+		assert.InDelta(t, 16*2*math.Sin(math.Pi/16), output.Length(), 1e-12)
+	})
+
+	t.Run("straight arc", func(t *testing.T) {
+		result, err := gogis.CurveToLine(gogis.CircularString(line(0, 0, 1, 1, 2, 2, 3, 2, 4, 0)))
+		require.NoError(t, err)
+
+		output, ok := result.Geometry.(*gogis.LineString)
+		require.True(t, ok)
+		assert.Equal(t, line(0, 0, 1, 1, 2, 2), (*output)[:3])
+		assert.Equal(t, gogis.Point{Coordinate: ewkb.Coordinate{'x': 4, 'y': 0}}, (*output)[len(*output)-1])
+	})
+
+	t.Run("other geometries", func(t *testing.T) {
+		collection := gogis.GeometryCollection{Valid: true, Collection: []gogis.ModelConverter{
+			&arc,
+			&gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+		}}
+
+		result, err := gogis.CurveToLine(collection)
+		require.NoError(t, err)
+
+		output, ok := result.Geometry.(*gogis.GeometryCollection)
+		require.True(t, ok)
+		require.Len(t, output.Collection, 2)
+		assert.IsType(t, &gogis.LineString{}, output.Collection[0])
+		assert.Equal(t, &gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}}, output.Collection[1])
+
+		result, err = gogis.CurveToLine(line(0, 0, 1, 1))
+		require.NoError(t, err)
+		assert.Equal(t, line(0, 0, 1, 1), *result.Geometry.(*gogis.LineString)) //nolint: forcetypeassert
+	})
+}
+
+func TestLineToCurve(t *testing.T) {
+	stroke := func(curve gogis.CircularString) gogis.LineString {
+		result, err := gogis.CurveToLine(curve)
+		require.NoError(t, err)
+
+		output, ok := result.Geometry.(*gogis.LineString)
+		require.True(t, ok)
+
+		return *output
+	}
+
+	assertPoints := func(t *testing.T, expected gogis.LineString, result gogis.Geometry) {
+		t.Helper()
+
+		require.Equal(t, ewkb.GeometryTypeCircularString, result.Type)
+
+		output, ok := result.Geometry.(*gogis.CircularString)
+		require.True(t, ok)
+		require.Len(t, *output, len(expected))
+
+		for idx := range expected {
+			assert.InDelta(t, expected[idx].Coordinate['x'], (*output)[idx].Coordinate['x'], 1e-12)
+			assert.InDelta(t, expected[idx].Coordinate['y'], (*output)[idx].Coordinate['y'], 1e-12)
+		}
+	}
+
+	t.Run("arc", func(t *testing.T) {
+		result, err := gogis.LineToCurve(stroke(gogis.CircularString(line(0, 0, 1, 1, 2, 0))))
+		require.NoError(t, err)
+		assertPoints(t, line(0, 0, 1, 1, 2, 0), result)
+	})
+
+	t.Run("arc and segments", func(t *testing.T) {
+		input := append(line(-4, 0), stroke(gogis.CircularString(line(0, 0, 1, -1, 2, 0)))...)
+		input = append(input, line(2, 3)...)
+
+		result, err := gogis.LineToCurve(input)
+		require.NoError(t, err)
+		assertPoints(t, line(-4, 0, -2, 0, 0, 0, 1, -1, 2, 0, 2, 1.5, 2, 3), result)
+
+		// Back to the same line.
+		curve, ok := result.Geometry.(*gogis.CircularString)
+		require.True(t, ok)
+		assert.InDelta(t, input.Length(), stroke(*curve).Length(), 1e-9)
+	})
+
+	t.Run("full circle", func(t *testing.T) {
+		result, err := gogis.LineToCurve(stroke(gogis.CircularString(line(0, 0, 2, 0, 0, 0))))
+		require.NoError(t, err)
+		assertPoints(t, line(0, 0, 1, -1, 2, 0, 1, 1, 0, 0), result)
+	})
+
+	t.Run("no arc", func(t *testing.T) {
+		for _, input := range []gogis.LineString{
+			line(0, 0, 1, 1, 2, 0),
+			line(0, 0, 1, 0, 1, 1, 0, 1, 0, 0),
+			line(0, 0),
+		} {
+			result, err := gogis.LineToCurve(input)
+			require.NoError(t, err)
+			assert.Equal(t, input, *result.Geometry.(*gogis.LineString)) //nolint: forcetypeassert
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package gogis
+
+import (
+	"sync"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// nolint: gochecknoglobals
+var (
+	globalDecoding      decoding
+	globalDecodingMutex sync.RWMutex
+)
+
+// decoding is the configuration of the decoder of scanned geometries; nil
+// fields fall back to the global configuration.
+type decoding struct {
+	limits *ewkb.DecoderLimits
+	strict *bool
+}
+
+// SetDecoderLimits sets the resource limits used to decode all scanned geometries,
+// to scan untrusted data. It can be overridden with WithDecoderLimits.
+func SetDecoderLimits(limits ewkb.DecoderLimits) {
+	globalDecodingMutex.Lock()
+	defer globalDecodingMutex.Unlock()
+
+	globalDecoding.limits = &limits
+}
+
+// SetStrictDecoding enables or disables the strict decoding (see ewkb.WithStrict)
+// of all scanned geometries. It can be overridden with WithStrictDecoding.
+func SetStrictDecoding(strict bool) {
+	globalDecodingMutex.Lock()
+	defer globalDecodingMutex.Unlock()
+
+	globalDecoding.strict = &strict
+}
+
+// WithDecoderLimits sets the resource limits used to decode a Geometry, a
+// LazyGeometry or a GeometryCollection.
+func WithDecoderLimits(limits ewkb.DecoderLimits) func(interface{}) {
+	return withDecoding(func(config *decoding) {
+		config.limits = &limits
+	})
+}
+
+// WithStrictDecoding enables or disables the strict decoding (see ewkb.WithStrict)
+// of a Geometry, a LazyGeometry or a GeometryCollection.
+func WithStrictDecoding(strict bool) func(interface{}) {
+	return withDecoding(func(config *decoding) {
+		config.strict = &strict
+	})
+}
+
+func withDecoding(setter func(*decoding)) func(interface{}) {
+	return func(shape interface{}) {
+		switch out := shape.(type) {
+		case *Geometry:
+			setter(&out.decoding)
+		case *GeometryCollection:
+			setter(&out.decoding)
+		case *LazyGeometry:
+			setter(&out.decoding)
+		}
+	}
+}
+
+// options are the decoder options.
+func (d decoding) options() []func(*ewkb.Decoder) {
+	output := []func(*ewkb.Decoder){}
+
+	globalDecodingMutex.RLock()
+	global := globalDecoding
+	globalDecodingMutex.RUnlock()
+
+	limits := d.limits
+	if limits == nil {
+		limits = global.limits
+	}
+
+	if limits != nil {
+		output = append(output, ewkb.WithLimits(*limits))
+	}
+
+	strict := d.strict
+	if strict == nil {
+		strict = global.strict
+	}
+
+	if strict != nil && *strict {
+		output = append(output, ewkb.WithStrict())
+	}
+
+	return output
+}
+
+// unmarshal decodes a scanned value.
+func unmarshal(geometry ewkb.Unmarshaler, value interface{}, config decoding) error {
+	return ewkb.Unmarshal(geometry, value, config.options()...)
+}
@@ -0,0 +1,97 @@
+package gogis_test
+
+import (
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderLimits(t *testing.T) {
+	// LINESTRING(1 2,3 4,5 6).
+	lineString := "010200000003000000000000000000F03F0000000000000040000000000000084000000000000010400000000000001440" +
+		"0000000000001840"
+
+	t.Run("hostile size", func(t *testing.T) {
+		err := gogis.NewGeometry().Scan("0102000000FFFFFFFF")
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("geometry", func(t *testing.T) {
+		geometry := gogis.NewGeometry(gogis.WithDecoderLimits(ewkb.DecoderLimits{MaxVertices: 2}))
+		assert.ErrorIs(t, geometry.Scan(lineString), ewkb.ErrTooManyVertices)
+
+		require.NoError(t, gogis.NewGeometry().Scan(lineString))
+	})
+
+	t.Run("lazy geometry", func(t *testing.T) {
+		lazy := gogis.NewLazyGeometry(gogis.WithDecoderLimits(ewkb.DecoderLimits{MaxVertices: 2}))
+		require.NoError(t, lazy.Scan(lineString))
+
+		_, err := lazy.Geometry()
+		assert.ErrorIs(t, err, ewkb.ErrTooManyVertices)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		gogis.SetDecoderLimits(ewkb.DecoderLimits{MaxVertices: 2})
+		defer gogis.SetDecoderLimits(ewkb.DecoderLimits{})
+
+		assert.ErrorIs(t, (&gogis.LineString{}).Scan(lineString), ewkb.ErrTooManyVertices)
+		assert.ErrorIs(t, (&gogis.Null[*gogis.LineString]{}).Scan(lineString), ewkb.ErrTooManyVertices)
+		assert.ErrorIs(t, gogis.NewGeometry().Scan(lineString), ewkb.ErrTooManyVertices)
+
+		geometry := gogis.NewGeometry(gogis.WithDecoderLimits(ewkb.DecoderLimits{}))
+		require.NoError(t, geometry.Scan(lineString))
+	})
+}
+
+func TestStrictDecoding(t *testing.T) {
+	// POINT(1 2), followed by a byte.
+	trailing := pointOneTwo + "00"
+
+	t.Run("geometry", func(t *testing.T) {
+		require.NoError(t, gogis.NewGeometry().Scan(trailing))
+
+		geometry := gogis.NewGeometry(gogis.WithStrictDecoding(true))
+		assert.ErrorIs(t, geometry.Scan(trailing), ewkb.ErrTrailingData)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		gogis.SetStrictDecoding(true)
+		defer gogis.SetStrictDecoding(false)
+
+		assert.ErrorIs(t, (&gogis.Point{}).Scan(trailing), ewkb.ErrTrailingData)
+		assert.ErrorIs(t, gogis.NewGeometry().Scan(trailing), ewkb.ErrTrailingData)
+
+		require.NoError(t, gogis.NewGeometry(gogis.WithStrictDecoding(false)).Scan(trailing))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		defer gogis.SetStrictDecoding(false)
+		defer gogis.SetDecoderLimits(ewkb.DecoderLimits{})
+
+		var group sync.WaitGroup
+
+		for idx := 0; idx < 8; idx++ {
+			group.Add(2)
+
+			go func(strict bool) {
+				defer group.Done()
+
+				gogis.SetStrictDecoding(strict)
+				gogis.SetDecoderLimits(ewkb.DecoderLimits{MaxVertices: 10})
+			}(idx%2 == 0)
+
+			go func() {
+				defer group.Done()
+
+				_ = gogis.NewGeometry().Scan(trailing)
+			}()
+		}
+
+		group.Wait()
+	})
+}
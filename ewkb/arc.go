@@ -0,0 +1,94 @@
+package ewkb
+
+import (
+	"math"
+)
+
+// Arc is the circle of a circular arc, from a start point to an end point
+// through a middle point. Only X and Y are used.
+type Arc struct {
+	// Center is the center of the circle.
+	Center Coord
+
+	// Radius is the radius of the circle.
+	Radius float64
+
+	// StartAngle is the angle of the start point around the center, in radians.
+	StartAngle float64
+
+	// Sweep is the angle from the start point to the end point, through the
+	// middle point: positive counter clockwise, negative clockwise, 2π for a
+	// full circle.
+	Sweep float64
+}
+
+// ArcOf computes the circle of the arc from start to end through middle. An
+// arc ending at its start is a full circle, the middle point being opposite to
+// the start. It fails when the arc is a segment (collinear points).
+func ArcOf(start Coord, middle Coord, end Coord) (Arc, bool) {
+	angle := func(center Coord, point Coord) float64 {
+		return math.Atan2(point.Y-center.Y, point.X-center.X)
+	}
+
+	if start.X == end.X && start.Y == end.Y {
+		if start.X == middle.X && start.Y == middle.Y {
+			return Arc{}, false
+		}
+
+		center := Coord{X: (start.X + middle.X) / 2, Y: (start.Y + middle.Y) / 2} //nolint: gomnd
+
+		return Arc{
+			Center:     center,
+			Radius:     math.Hypot(start.X-center.X, start.Y-center.Y),
+			StartAngle: angle(center, start),
+			Sweep:      2 * math.Pi, //nolint: gomnd
+		}, true
+	}
+
+	center, ok := CircleCenter(start, middle, end)
+	if !ok {
+		return Arc{}, false
+	}
+
+	startAngle := angle(center, start)
+
+	// Counter clockwise, unless the middle point is not on the way.
+	sweep := normalizeAngle(angle(center, end) - startAngle)
+	if normalizeAngle(angle(center, middle)-startAngle) > sweep {
+		sweep -= 2 * math.Pi
+	}
+
+	return Arc{
+		Center:     center,
+		Radius:     math.Hypot(start.X-center.X, start.Y-center.Y),
+		StartAngle: startAngle,
+		Sweep:      sweep,
+	}, true
+}
+
+// CircleCenter computes the center of the circle through a, b and c; it fails
+// when they are collinear.
+func CircleCenter(a Coord, b Coord, c Coord) (Coord, bool) {
+	determinant := 2 * ((b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)) //nolint: gomnd
+	if determinant == 0 {
+		return Coord{}, false
+	}
+
+	abNorm := (b.X-a.X)*(b.X-a.X) + (b.Y-a.Y)*(b.Y-a.Y)
+	acNorm := (c.X-a.X)*(c.X-a.X) + (c.Y-a.Y)*(c.Y-a.Y)
+
+	return Coord{
+		X: a.X + ((c.Y-a.Y)*abNorm-(b.Y-a.Y)*acNorm)/determinant,
+		Y: a.Y + ((b.X-a.X)*acNorm-(c.X-a.X)*abNorm)/determinant,
+	}, true
+}
+
+// normalizeAngle converts an angle to [0, 2π).
+func normalizeAngle(angle float64) float64 {
+	output := math.Mod(angle, 2*math.Pi)
+	if output < 0 {
+		output += 2 * math.Pi
+	}
+
+	return output
+}
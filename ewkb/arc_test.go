@@ -0,0 +1,69 @@
+package ewkb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArcOf(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		points [3]ewkb.Coord
+		center ewkb.Coord
+		start  float64
+		sweep  float64
+	}{
+		{
+			name:   "counter clockwise",
+			points: [3]ewkb.Coord{{X: 0, Y: 0}, {X: 1, Y: -1}, {X: 2, Y: 0}},
+			center: ewkb.Coord{X: 1, Y: 0},
+			start:  math.Pi,
+			sweep:  math.Pi,
+		},
+		{
+			name:   "clockwise",
+			points: [3]ewkb.Coord{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 0}},
+			center: ewkb.Coord{X: 1, Y: 0},
+			start:  math.Pi,
+			sweep:  -math.Pi,
+		},
+		{
+			name:   "three quarters",
+			points: [3]ewkb.Coord{{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: -1}},
+			center: ewkb.Coord{},
+			start:  0,
+			sweep:  3 * math.Pi / 2,
+		},
+		{
+			name:   "full circle",
+			points: [3]ewkb.Coord{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}},
+			center: ewkb.Coord{X: 1, Y: 0},
+			start:  math.Pi,
+			sweep:  2 * math.Pi,
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			arc, ok := ewkb.ArcOf(testCase.points[0], testCase.points[1], testCase.points[2])
+			require.True(t, ok)
+			assert.InDelta(t, testCase.center.X, arc.Center.X, 1e-12)
+			assert.InDelta(t, testCase.center.Y, arc.Center.Y, 1e-12)
+			assert.InDelta(t, 1, arc.Radius, 1e-12)
+			assert.InDelta(t, testCase.start, arc.StartAngle, 1e-12)
+			assert.InDelta(t, testCase.sweep, arc.Sweep, 1e-12)
+		})
+	}
+
+	t.Run("segment", func(t *testing.T) {
+		_, ok := ewkb.ArcOf(ewkb.Coord{}, ewkb.Coord{X: 1, Y: 1}, ewkb.Coord{X: 2, Y: 2})
+		assert.False(t, ok)
+
+		_, ok = ewkb.ArcOf(ewkb.Coord{}, ewkb.Coord{}, ewkb.Coord{})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,332 @@
+package ewkb
+
+import (
+	"math"
+)
+
+// Box is the extent of a geometry: the minimum and maximum values of each
+// dimension. Z and M extents are set when the geometry has them (HasZ, HasM),
+// and are 0 otherwise.
+//
+//	box := ewkb.EnvelopeOf(&polygon)
+//	if !box.IsEmpty() && box.Intersects(filter) {
+//		...
+//	}
+//
+// The envelope of an empty geometry is an empty box (see EmptyBox).
+type Box struct {
+	XMin float64
+	YMin float64
+	ZMin float64
+	MMin float64
+	XMax float64
+	YMax float64
+	ZMax float64
+	MMax float64
+	HasZ bool
+	HasM bool
+}
+
+// EmptyBox creates an empty box: its X and Y minimums are +Inf and its maximums
+// -Inf, so that the union with any box is that box.
+func EmptyBox() Box {
+	return Box{
+		XMin: math.Inf(1),
+		YMin: math.Inf(1),
+		XMax: math.Inf(-1),
+		YMax: math.Inf(-1),
+	}
+}
+
+// EnvelopeOf computes the envelope of a geometry. Geometries without an Envelope
+// method have an empty envelope.
+func EnvelopeOf(geometry Marshaler) Box {
+	enveloper, ok := geometry.(interface{ Envelope() Box })
+	if !ok {
+		return EmptyBox()
+	}
+
+	return enveloper.Envelope()
+}
+
+// IsEmpty checks if the box is empty.
+func (b Box) IsEmpty() bool {
+	return !(b.XMin <= b.XMax && b.YMin <= b.YMax)
+}
+
+// Union is the smallest box containing both boxes.
+func (b Box) Union(other Box) Box {
+	switch {
+	case other.IsEmpty():
+		return b
+	case b.IsEmpty():
+		return other
+	}
+
+	output := Box{
+		XMin: math.Min(b.XMin, other.XMin),
+		YMin: math.Min(b.YMin, other.YMin),
+		XMax: math.Max(b.XMax, other.XMax),
+		YMax: math.Max(b.YMax, other.YMax),
+	}
+
+	output.ZMin, output.ZMax, output.HasZ = unionRange(b.ZMin, b.ZMax, b.HasZ, other.ZMin, other.ZMax, other.HasZ)
+	output.MMin, output.MMax, output.HasM = unionRange(b.MMin, b.MMax, b.HasM, other.MMin, other.MMax, other.HasM)
+
+	return output
+}
+
+// Intersection is the box shared by both boxes; it is empty when they do not
+// intersect. Z (or M) is kept when both boxes have it.
+func (b Box) Intersection(other Box) Box {
+	if !b.Intersects(other) {
+		return EmptyBox()
+	}
+
+	output := Box{
+		XMin: math.Max(b.XMin, other.XMin),
+		YMin: math.Max(b.YMin, other.YMin),
+		XMax: math.Min(b.XMax, other.XMax),
+		YMax: math.Min(b.YMax, other.YMax),
+		HasZ: b.HasZ && other.HasZ,
+		HasM: b.HasM && other.HasM,
+	}
+
+	if output.HasZ {
+		output.ZMin, output.ZMax = math.Max(b.ZMin, other.ZMin), math.Min(b.ZMax, other.ZMax)
+	}
+
+	if output.HasM {
+		output.MMin, output.MMax = math.Max(b.MMin, other.MMin), math.Min(b.MMax, other.MMax)
+	}
+
+	return output
+}
+
+// Expand grows the box by distance in every direction, as ST_Expand does: X and
+// Y, and Z when the box has it. A negative distance shrinks the box.
+func (b Box) Expand(distance float64) Box {
+	if b.IsEmpty() {
+		return b
+	}
+
+	b.XMin -= distance
+	b.YMin -= distance
+	b.XMax += distance
+	b.YMax += distance
+
+	if b.HasZ {
+		b.ZMin -= distance
+		b.ZMax += distance
+	}
+
+	return b
+}
+
+// Contains checks if the other box is inside the box. Z (or M) is checked when
+// both boxes have it. An empty box contains nothing, and is contained by nothing.
+func (b Box) Contains(other Box) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return b.XMin <= other.XMin && b.XMax >= other.XMax &&
+		b.YMin <= other.YMin && b.YMax >= other.YMax &&
+		(!b.HasZ || !other.HasZ || (b.ZMin <= other.ZMin && b.ZMax >= other.ZMax)) &&
+		(!b.HasM || !other.HasM || (b.MMin <= other.MMin && b.MMax >= other.MMax))
+}
+
+// ContainsCoord checks if the coordinate is inside the box (boundary included).
+func (b Box) ContainsCoord(coord Coord) bool {
+	return b.Contains(EmptyBox().extend(coord))
+}
+
+// Intersects checks if the boxes share at least a point. Z (or M) is checked when
+// both boxes have it.
+func (b Box) Intersects(other Box) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+
+	return b.XMin <= other.XMax && b.XMax >= other.XMin &&
+		b.YMin <= other.YMax && b.YMax >= other.YMin &&
+		(!b.HasZ || !other.HasZ || (b.ZMin <= other.ZMax && b.ZMax >= other.ZMin)) &&
+		(!b.HasM || !other.HasM || (b.MMin <= other.MMax && b.MMax >= other.MMin))
+}
+
+// extend grows the box to contain the coordinate. Empty coordinates are ignored.
+func (b Box) extend(coord Coord) Box {
+	if coord.IsEmpty() {
+		return b
+	}
+
+	b = b.extendXY(coord.X, coord.Y)
+
+	if coord.HasZ() {
+		b.ZMin, b.ZMax, b.HasZ = unionRange(b.ZMin, b.ZMax, b.HasZ, coord.Z, coord.Z, true)
+	}
+
+	if coord.HasM() {
+		b.MMin, b.MMax, b.HasM = unionRange(b.MMin, b.MMax, b.HasM, coord.M, coord.M, true)
+	}
+
+	return b
+}
+
+func (b Box) extendXY(x float64, y float64) Box {
+	b.XMin = math.Min(b.XMin, x)
+	b.YMin = math.Min(b.YMin, y)
+	b.XMax = math.Max(b.XMax, x)
+	b.YMax = math.Max(b.YMax, y)
+
+	return b
+}
+
+func unionRange(
+	min float64, max float64, has bool,
+	otherMin float64, otherMax float64, otherHas bool,
+) (float64, float64, bool) {
+	switch {
+	case has && otherHas:
+		return math.Min(min, otherMin), math.Max(max, otherMax), true
+	case has:
+		return min, max, true
+	case otherHas:
+		return otherMin, otherMax, true
+	}
+
+	return 0, 0, false
+}
+
+// Envelope computes the envelope of the coordinate.
+func (c Coordinate) Envelope() Box {
+	return EmptyBox().extend(c.Coord())
+}
+
+// Envelope computes the envelope of the coordinates.
+func (c CoordinateSet) Envelope() Box {
+	output := EmptyBox()
+
+	for _, coord := range c {
+		output = output.extend(coord.Coord())
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the coordinates.
+func (c CoordinateGroup) Envelope() Box {
+	output := EmptyBox()
+
+	for _, set := range c {
+		output = output.Union(set.Envelope())
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the coordinates.
+func (f FlatCoords) Envelope() Box {
+	output := EmptyBox()
+
+	for idx := 0; idx < f.Len(); idx++ {
+		output = output.extend(f.Coord(idx))
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the points.
+func (m MultiPoint) Envelope() Box {
+	output := EmptyBox()
+
+	for _, pnt := range m.Points {
+		output = output.Union(pnt.Envelope())
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the linestrings.
+func (m MultiLineString) Envelope() Box {
+	output := EmptyBox()
+
+	for _, line := range m.LineStrings {
+		output = output.Union(line.Envelope())
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the polygons.
+func (m MultiPolygon) Envelope() Box {
+	output := EmptyBox()
+
+	for _, polygon := range m.Polygons {
+		output = output.Union(polygon.Envelope())
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the geometries of the collection.
+func (g GeometryCollection) Envelope() Box {
+	output := EmptyBox()
+
+	for _, geometry := range g.Collection {
+		output = output.Union(EnvelopeOf(geometry))
+	}
+
+	return output
+}
+
+// Envelope computes the envelope of the arcs: unlike ST_Envelope on the
+// vertices, the arcs bulging beyond their vertices are included, as the box
+// of a curve in PostGIS.
+func (c CircularString) Envelope() Box {
+	output := c.CoordinateSet.Envelope()
+
+	for idx := 2; idx < len(c.CoordinateSet); idx += 2 {
+		start, middle, end := c.CoordinateSet[idx-2].Coord(), c.CoordinateSet[idx-1].Coord(), c.CoordinateSet[idx].Coord()
+
+		if start.IsEmpty() || middle.IsEmpty() || end.IsEmpty() {
+			continue
+		}
+
+		for _, extreme := range arcExtremes(start, middle, end) {
+			output = output.extendXY(extreme[0], extreme[1])
+		}
+	}
+
+	return output
+}
+
+// arcExtremes computes the points of the arc (start, middle, end) reaching
+// the minimum or the maximum X or Y of its circle.
+func arcExtremes(start Coord, middle Coord, end Coord) [][2]float64 {
+	arc, ok := ArcOf(start, middle, end)
+	if !ok {
+		return nil
+	}
+
+	// The same arc, counter clockwise.
+	startAngle, sweep := arc.StartAngle, arc.Sweep
+	if sweep < 0 {
+		startAngle, sweep = startAngle+sweep, -sweep
+	}
+
+	output := [][2]float64{}
+
+	for quarter, extreme := range [][2]float64{
+		{arc.Center.X + arc.Radius, arc.Center.Y},
+		{arc.Center.X, arc.Center.Y + arc.Radius},
+		{arc.Center.X - arc.Radius, arc.Center.Y},
+		{arc.Center.X, arc.Center.Y - arc.Radius},
+	} {
+		if normalizeAngle(float64(quarter)*math.Pi/2-startAngle) <= sweep { //nolint: gomnd
+			output = append(output, extreme)
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,122 @@
+package ewkb_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	xyz := ewkb.LayoutWith(false, true)
+
+	t.Run("polygon", func(t *testing.T) {
+		polygon := ewkb.Polygon{
+			CoordinateGroup: ewkb.CoordinateGroup{
+				{
+					{'x': 0, 'y': 0, 'z': 1},
+					{'x': 10, 'y': 0, 'z': 3},
+					{'x': 10, 'y': 20, 'z': 2},
+					{'x': 0, 'y': 0, 'z': 1},
+				},
+			},
+		}
+
+		assert.Equal(t, ewkb.Box{XMax: 10, YMax: 20, ZMin: 1, ZMax: 3, HasZ: true}, ewkb.EnvelopeOf(&polygon))
+	})
+
+	t.Run("flat polygon", func(t *testing.T) {
+		polygon := ewkb.FlatPolygon{FlatCoords: ewkb.NewFlatCoords(xyz, []float64{0, 0, 1, 10, 0, 3, 10, 20, 2, 0, 0, 1}), Ends: []int{4}}
+
+		assert.Equal(t, ewkb.Box{XMax: 10, YMax: 20, ZMin: 1, ZMax: 3, HasZ: true}, polygon.Envelope())
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		collection := ewkb.GeometryCollection{
+			Collection: []ewkb.Geometry{
+				&ewkb.Point{Coordinate: ewkb.Coordinate{'x': -5, 'y': 2}},
+				&ewkb.GeometryCollection{
+					Collection: []ewkb.Geometry{
+						&ewkb.MultiPoint{Points: []ewkb.Point{{Coordinate: ewkb.Coordinate{'x': 4, 'y': 8, 'm': 7}}}},
+					},
+				},
+				&ewkb.LineString{},
+			},
+		}
+
+		box := ewkb.EnvelopeOf(&collection)
+		assert.Equal(t, []float64{-5, 2, 4, 8}, []float64{box.XMin, box.YMin, box.XMax, box.YMax})
+		assert.False(t, box.HasZ)
+		assert.True(t, box.HasM)
+		assert.Equal(t, []float64{7, 7}, []float64{box.MMin, box.MMax})
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.True(t, ewkb.EnvelopeOf(&ewkb.LineString{}).IsEmpty())
+		assert.True(t, ewkb.EnvelopeOf(&ewkb.Point{Coordinate: ewkb.NewNullCoordinate(xyz)}).IsEmpty())
+		assert.True(t, ewkb.EmptyBox().IsEmpty())
+		assert.False(t, ewkb.Box{}.IsEmpty())
+	})
+
+	t.Run("circular string", func(t *testing.T) {
+		// CIRCULARSTRING(0 0,1 1,2 0): the arc reaches (1 1) only.
+		arc := ewkb.CircularString{CoordinateSet: ewkb.CoordinateSet{{'x': 0, 'y': 0}, {'x': 1, 'y': 1}, {'x': 2, 'y': 0}}}
+		box := arc.Envelope()
+		assert.Equal(t, []float64{0, 0, 2, 1}, []float64{box.XMin, box.YMin, box.XMax, box.YMax})
+
+		// CIRCULARSTRING(0 0,1.7071 0.7071,1 1): three quarters of the circle centered on (1 0).
+		arc = ewkb.CircularString{CoordinateSet: ewkb.CoordinateSet{{'x': 0, 'y': 0}, {'x': 1 + math.Sqrt2/2, 'y': math.Sqrt2 / 2}, {'x': 1, 'y': 1}}}
+		box = arc.Envelope()
+		assert.InDeltaSlice(t, []float64{0, -1, 2, 1}, []float64{box.XMin, box.YMin, box.XMax, box.YMax}, 1e-9)
+
+		// CIRCULARSTRING(0 0,2 0,0 0): full circle.
+		arc = ewkb.CircularString{CoordinateSet: ewkb.CoordinateSet{{'x': 0, 'y': 0}, {'x': 2, 'y': 0}, {'x': 0, 'y': 0}}}
+		box = arc.Envelope()
+		assert.InDeltaSlice(t, []float64{0, -1, 2, 1}, []float64{box.XMin, box.YMin, box.XMax, box.YMax}, 1e-9)
+	})
+}
+
+func TestBox(t *testing.T) {
+	square := ewkb.Box{XMin: 0, YMin: 0, XMax: 10, YMax: 10}
+	other := ewkb.Box{XMin: 5, YMin: -5, XMax: 15, YMax: 5}
+
+	t.Run("union", func(t *testing.T) {
+		assert.Equal(t, ewkb.Box{XMin: 0, YMin: -5, XMax: 15, YMax: 10}, square.Union(other))
+		assert.Equal(t, square, square.Union(ewkb.EmptyBox()))
+		assert.Equal(t, square, ewkb.EmptyBox().Union(square))
+
+		withZ := ewkb.Box{XMax: 1, YMax: 1, ZMin: 2, ZMax: 3, HasZ: true}
+		union := square.Union(withZ)
+		assert.True(t, union.HasZ)
+		assert.Equal(t, []float64{2, 3}, []float64{union.ZMin, union.ZMax})
+	})
+
+	t.Run("intersection", func(t *testing.T) {
+		assert.Equal(t, ewkb.Box{XMin: 5, YMin: 0, XMax: 10, YMax: 5}, square.Intersection(other))
+		assert.True(t, square.Intersection(ewkb.Box{XMin: 20, YMin: 20, XMax: 30, YMax: 30}).IsEmpty())
+		assert.Equal(t, ewkb.Box{XMin: 10, YMin: 10, XMax: 10, YMax: 10}, square.Intersection(ewkb.Box{XMin: 10, YMin: 10, XMax: 20, YMax: 20}))
+	})
+
+	t.Run("expand", func(t *testing.T) {
+		assert.Equal(t, ewkb.Box{XMin: -1, YMin: -1, XMax: 11, YMax: 11}, square.Expand(1))
+		assert.Equal(t, ewkb.Box{XMin: -1, YMin: -1, ZMin: 1, XMax: 11, YMax: 11, ZMax: 4, HasZ: true}, ewkb.Box{XMax: 10, YMax: 10, ZMin: 2, ZMax: 3, HasZ: true}.Expand(1))
+		assert.True(t, ewkb.EmptyBox().Expand(1).IsEmpty())
+	})
+
+	t.Run("contains", func(t *testing.T) {
+		assert.True(t, square.Contains(ewkb.Box{XMin: 1, YMin: 1, XMax: 10, YMax: 10}))
+		assert.False(t, square.Contains(other))
+		assert.False(t, square.Contains(ewkb.EmptyBox()))
+		assert.False(t, ewkb.Box{XMax: 10, YMax: 10, ZMax: 1, HasZ: true}.Contains(ewkb.Box{XMax: 1, YMax: 1, ZMax: 2, HasZ: true}))
+		assert.True(t, square.ContainsCoord(ewkb.Coord{X: 10, Y: 5}))
+		assert.False(t, square.ContainsCoord(ewkb.Coord{X: 11, Y: 5}))
+	})
+
+	t.Run("intersects", func(t *testing.T) {
+		assert.True(t, square.Intersects(other))
+		assert.True(t, square.Intersects(ewkb.Box{XMin: 10, YMin: 10, XMax: 20, YMax: 20}))
+		assert.False(t, square.Intersects(ewkb.Box{XMin: 11, YMin: 10, XMax: 20, YMax: 20}))
+		assert.False(t, square.Intersects(ewkb.EmptyBox()))
+	})
+}
@@ -33,7 +33,7 @@ func (c CircularString) Type() GeometryType {
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (c *CircularString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != c.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, c.Type())
+		return newDecodeError(ErrWrongGeometryType, c.Type(), record.Type)
 	}
 
 	c.SRID = record.SRID
@@ -44,7 +44,8 @@ func (c *CircularString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 
 	size := len(c.CoordinateSet)
 
-	if size%2 == 0 || size < 3 {
+	// CIRCULARSTRING EMPTY has no vertex.
+	if size != 0 && (size%2 == 0 || size < 3) {
 		return fmt.Errorf("%w: found %d vertices", ErrCircularStringWrongSize, size)
 	}
 
@@ -55,7 +56,8 @@ func (c *CircularString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 func (c CircularString) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
 	size := len(c.CoordinateSet)
 
-	if size%2 == 0 || size < 3 {
+	// CIRCULARSTRING EMPTY has no vertex.
+	if size != 0 && (size%2 == 0 || size < 3) {
 		return nil, fmt.Errorf("%w: found %d vertices", ErrCircularStringWrongSize, size)
 	}
 
@@ -16,11 +16,5 @@ func float64FromBytes(bytes []byte, byteOrder binary.ByteOrder) float64 {
 
 // Float64Bytes converts float64 to 8-bytes array.
 func float64Bytes(float float64, byteOrder binary.ByteOrder) []byte {
-	bits := math.Float64bits(float)
-
-	bytes := make([]byte, 8) //nolint: gomnd
-
-	byteOrder.PutUint64(bytes, bits)
-
-	return bytes
+	return appendFloat64(make([]byte, 0, size64bit), float, byteOrder)
 }
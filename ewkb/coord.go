@@ -0,0 +1,143 @@
+package ewkb
+
+import (
+	"math"
+)
+
+// Coord is a coordinate with named fields. Unlike Coordinate, it does not
+// allocate, and its layout is explicit (XY when zero).
+//
+//	coord := ewkb.Coord{X: -71.42, Y: 42.71}
+//	coordZ := ewkb.Coord{X: -71.42, Y: 42.71, Z: 4, Layout: ewkb.LayoutWith(false, true)}
+//
+// Z and M are meaningless when the layout does not have them.
+type Coord struct {
+	X      float64
+	Y      float64
+	Z      float64
+	M      float64
+	Layout Layout
+}
+
+// NewCoord creates a coordinate from values in the layout order (x, y, [z], [m]).
+// Missing values are NaN.
+func NewCoord(layout Layout, values ...float64) Coord {
+	output := Coord{Layout: layout}
+
+	for idx, name := range layout.Format() {
+		value := math.NaN()
+		if idx < len(values) {
+			value = values[idx]
+		}
+
+		output.set(byte(name), value)
+	}
+
+	return output
+}
+
+// EmptyCoord creates an empty coordinate (POINT EMPTY): all values are NaN.
+func EmptyCoord(layout Layout) Coord {
+	nan := math.NaN()
+
+	return Coord{
+		X:      nan,
+		Y:      nan,
+		Z:      nan,
+		M:      nan,
+		Layout: layout,
+	}
+}
+
+// HasZ checks if the coordinate has a Z value.
+func (c Coord) HasZ() bool {
+	return c.Layout.HasZ()
+}
+
+// HasM checks if the coordinate has a M value.
+func (c Coord) HasM() bool {
+	return c.Layout.HasM()
+}
+
+// Get gets a value by name ('x', 'y', 'z' or 'm'). The boolean is false when the
+// layout does not have the value.
+func (c Coord) Get(name byte) (float64, bool) {
+	switch name {
+	case 'x':
+		return c.X, true
+	case 'y':
+		return c.Y, true
+	case 'z':
+		return c.Z, c.HasZ()
+	case 'm':
+		return c.M, c.HasM()
+	}
+
+	return 0, false
+}
+
+// IsEmpty checks if coordinate is empty (POINT EMPTY): all its values are NaN.
+func (c Coord) IsEmpty() bool {
+	for _, name := range c.Layout.Format() {
+		if value, _ := c.Get(byte(name)); value == value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AppendValues appends the values in the layout order.
+func (c Coord) AppendValues(values []float64) []float64 {
+	values = append(values, c.X, c.Y)
+
+	if c.HasZ() {
+		values = append(values, c.Z)
+	}
+
+	if c.HasM() {
+		values = append(values, c.M)
+	}
+
+	return values
+}
+
+// Coordinate converts to the map representation.
+func (c Coord) Coordinate() Coordinate {
+	output := Coordinate{}
+
+	for _, name := range c.Layout.Format() {
+		output[byte(name)], _ = c.Get(byte(name))
+	}
+
+	return output
+}
+
+// Coord converts to the struct representation. An empty Coordinate gives an
+// empty XY Coord.
+func (c Coordinate) Coord() Coord {
+	if len(c) == 0 {
+		return EmptyCoord(layoutXY)
+	}
+
+	output := Coord{Layout: c.Layout()}
+
+	for name, value := range c {
+		output.set(name, value)
+	}
+
+	return output
+}
+
+func (c *Coord) set(name byte, value float64) {
+	switch name {
+	case 'x':
+		c.X = value
+	case 'y':
+		c.Y = value
+	case 'z':
+		c.Z = value
+	case 'm':
+		c.M = value
+	}
+}
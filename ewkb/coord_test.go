@@ -0,0 +1,45 @@
+package ewkb_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCoord(t *testing.T) {
+	coord := ewkb.NewCoord(ewkb.LayoutWith(true, false), 1, 2, 3)
+
+	assert.Equal(t, 1.0, coord.X)
+	assert.Equal(t, 2.0, coord.Y)
+	assert.Equal(t, 3.0, coord.M)
+	assert.True(t, coord.HasM())
+	assert.False(t, coord.HasZ())
+	assert.False(t, coord.IsEmpty())
+
+	value, ok := coord.Get('m')
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, value)
+
+	_, ok = coord.Get('z')
+	assert.False(t, ok)
+
+	assert.Equal(t, []float64{1, 2, 3}, coord.AppendValues(nil))
+}
+
+func TestEmptyCoord(t *testing.T) {
+	assert.True(t, ewkb.EmptyCoord(ewkb.LayoutWith(true, true)).IsEmpty())
+	assert.True(t, ewkb.NewCoord(ewkb.LayoutWith(false, true), 1).HasZ())
+	assert.False(t, ewkb.NewCoord(ewkb.LayoutWith(false, true), 1).IsEmpty())
+}
+
+func TestCoordConversion(t *testing.T) {
+	coordinate := ewkb.Coordinate{'x': 1, 'y': 2, 'z': 3, 'm': 4}
+	coord := ewkb.Coord{X: 1, Y: 2, Z: 3, M: 4, Layout: ewkb.LayoutWith(true, true)}
+
+	assert.Equal(t, coord, coordinate.Coord())
+	assert.Equal(t, coordinate, coord.Coordinate())
+
+	assert.Equal(t, ewkb.Coordinate{'x': 1, 'y': 2}, ewkb.Coord{X: 1, Y: 2, Z: 3}.Coordinate())
+	assert.True(t, ewkb.Coordinate{}.Coord().IsEmpty())
+}
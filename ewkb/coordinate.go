@@ -9,6 +9,8 @@ import (
 type Coordinate map[byte]float64
 
 // IsNull checks if coordinate is null.
+//
+// Deprecated: PostGIS has no NULL coordinate; NaN coordinates are POINT EMPTY. Use IsEmpty.
 func (c Coordinate) IsNull() bool {
 	if len(c) == 0 {
 		return true
@@ -23,6 +25,17 @@ func (c Coordinate) IsNull() bool {
 	return false
 }
 
+// IsEmpty checks if coordinate is empty (POINT EMPTY): no values, or only NaN values.
+func (c Coordinate) IsEmpty() bool {
+	for _, coord := range c {
+		if coord == coord {
+			return false
+		}
+	}
+
+	return true
+}
+
 // NewNullCoordinate creates a null coordinate system.
 func NewNullCoordinate(layout Layout) Coordinate {
 	output := Coordinate{}
@@ -43,7 +56,7 @@ func (c *Coordinate) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 
 	*c = Coordinate{}
 
-	if pnt.isNull() {
+	if pnt.isEmpty() {
 		*c = NewNullCoordinate(record.Layout)
 
 		return nil
@@ -60,6 +73,10 @@ func (c *Coordinate) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 func (c Coordinate) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
 	output := []byte{}
 
+	if len(c) == 0 {
+		c = NewNullCoordinate(layoutXY)
+	}
+
 	for _, name := range c.Layout().Format() {
 		bytes := float64Bytes(c[byte(name)], byteOrder)
 		output = append(output, bytes...)
@@ -81,21 +98,35 @@ func (c Coordinate) Layout() Layout {
 // CoordinateSet is a set of coordinates.
 type CoordinateSet []Coordinate
 
+// IsEmpty checks if the set has no coordinates.
+func (c CoordinateSet) IsEmpty() bool {
+	return len(c) == 0
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (c *CoordinateSet) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
-	size, err := record.ReadUint32()
+	size, err := record.readVertexCount()
 	if err != nil {
 		return err
 	}
 
-	*c = make(CoordinateSet, size)
-	for idx := range *c {
-		err := (&((*c)[idx])).UnmarshalEWBK(record)
-		if err != nil {
+	*c = make(CoordinateSet, 0, record.state.capacity(size))
+
+	record.state.enter("Vertex")
+
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
+
+		var element Coordinate
+		if err := element.UnmarshalEWBK(record); err != nil {
 			return err
 		}
+
+		*c = append(*c, element)
 	}
 
+	record.state.leave()
+
 	return nil
 }
 
@@ -123,21 +154,41 @@ func (c CoordinateSet) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
 // CoordinateGroup is a group of set of coordinates.
 type CoordinateGroup []CoordinateSet
 
+// IsEmpty checks if the group has no coordinates.
+func (c CoordinateGroup) IsEmpty() bool {
+	for _, set := range c {
+		if !set.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (c *CoordinateGroup) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minPartSize)
 	if err != nil {
 		return err
 	}
 
-	*c = make(CoordinateGroup, size)
-	for idx := range *c {
-		err := (&((*c)[idx])).UnmarshalEWBK(record)
-		if err != nil {
+	*c = make(CoordinateGroup, 0, record.state.capacity(size))
+
+	record.state.enter("Ring")
+
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
+
+		var element CoordinateSet
+		if err := element.UnmarshalEWBK(record); err != nil {
 			return err
 		}
+
+		*c = append(*c, element)
 	}
 
+	record.state.leave()
+
 	return nil
 }
 
@@ -43,3 +43,10 @@ func TestNewNullCoordinate(t *testing.T) {
 	assert.True(t, coord['z'] == coord['z'])
 	assert.True(t, coord['m'] != coord['m'])
 }
+
+func TestCoordinateIsEmpty(t *testing.T) {
+	assert.True(t, ewkb.Coordinate{}.IsEmpty())
+	assert.True(t, ewkb.NewNullCoordinate(ewkb.LayoutWith(true, true)).IsEmpty())
+	assert.False(t, ewkb.Coordinate{'x': math.NaN(), 'y': 42.0}.IsEmpty())
+	assert.False(t, ewkb.Coordinate{'x': 35.3, 'y': 42.0}.IsEmpty())
+}
@@ -10,13 +10,13 @@ type point []float64
 func (p *point) read(dataStream io.Reader, size uint32, byteOrder binary.ByteOrder) error {
 	out := make(point, size)
 
-	for idx := 0; idx < int(size); idx++ {
-		floatBytes := make([]byte, size64bit)
-		if _, err := io.ReadFull(dataStream, floatBytes); err != nil {
-			return err
-		}
+	floatBytes := make([]byte, int(size)*size64bit)
+	if _, err := io.ReadFull(dataStream, floatBytes); err != nil {
+		return err
+	}
 
-		out[idx] = float64FromBytes(floatBytes, byteOrder)
+	for idx := range out {
+		out[idx] = float64FromBytes(floatBytes[idx*size64bit:], byteOrder)
 	}
 
 	*p = out
@@ -24,12 +24,13 @@ func (p *point) read(dataStream io.Reader, size uint32, byteOrder binary.ByteOrd
 	return nil
 }
 
-func (p point) isNull() bool {
+// isEmpty checks if all the values are NaN, as PostGIS encodes POINT EMPTY.
+func (p point) isEmpty() bool {
 	for _, value := range p {
-		if value != value {
-			return true
+		if value == value {
+			return false
 		}
 	}
 
-	return false
+	return true
 }
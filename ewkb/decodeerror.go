@@ -0,0 +1,139 @@
+package ewkb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DecodeError is an error occurring while decoding a geometry. It wraps the cause
+// (ErrWrongGeometryType, io.ErrUnexpectedEOF, etc.), to use with errors.Is:
+//
+//	var decodeErr *ewkb.DecodeError
+//	if errors.As(err, &decodeErr) {
+//		log.Printf("invalid geometry at %s (offset %d)", decodeErr.Path, decodeErr.Offset)
+//	}
+type DecodeError struct {
+	// Offset is the offset in the binary input, where the error occurred.
+	Offset int
+
+	// Path is the position in the geometry, such as MultiPolygon[12].Ring[3].Vertex[440].
+	Path string
+
+	// Expected and Found are the expected and found values, when the error is
+	// about an unexpected value.
+	Expected string
+	Found    string
+
+	// Err is the cause of the error.
+	Err error
+
+	located bool
+}
+
+// newDecodeError creates a DecodeError about an unexpected value.
+func newDecodeError(err error, expected interface{}, found interface{}) *DecodeError {
+	return &DecodeError{
+		Expected: fmt.Sprint(expected),
+		Found:    fmt.Sprint(found),
+		Err:      err,
+	}
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	output := e.Err.Error()
+
+	if e.Expected != "" || e.Found != "" {
+		output += fmt.Sprintf(": found %s, expected %s", e.Found, e.Expected)
+	}
+
+	if e.located {
+		output += fmt.Sprintf(" at offset %d", e.Offset)
+	}
+
+	if e.Path != "" {
+		output += " in " + e.Path
+	}
+
+	return output
+}
+
+// Unwrap returns the cause of the error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// pathElement is an element of the path in the geometry: a name, and an index
+// when positive.
+type pathElement struct {
+	name  string
+	index int
+}
+
+// enter adds an element to the path.
+func (d *decodeState) enter(name string) {
+	if d == nil {
+		return
+	}
+
+	d.path = append(d.path, pathElement{name: name, index: -1})
+}
+
+// at sets the index of the last element of the path.
+func (d *decodeState) at(index int) {
+	if d == nil || len(d.path) == 0 {
+		return
+	}
+
+	d.path[len(d.path)-1].index = index
+}
+
+// leave removes the last element of the path.
+func (d *decodeState) leave() {
+	if d == nil || len(d.path) == 0 {
+		return
+	}
+
+	d.path = d.path[:len(d.path)-1]
+}
+
+// pathString formats the path.
+func (d *decodeState) pathString() string {
+	builder := strings.Builder{}
+
+	for _, element := range d.path {
+		if builder.Len() > 0 {
+			builder.WriteByte('.')
+		}
+
+		builder.WriteString(element.name)
+
+		if element.index >= 0 {
+			builder.WriteByte('[')
+			builder.WriteString(strconv.Itoa(element.index))
+			builder.WriteByte(']')
+		}
+	}
+
+	return builder.String()
+}
+
+// locate sets the offset and the path of the error.
+func (d *decodeState) locate(err error) error {
+	var decodeErr *DecodeError
+
+	if !errors.As(err, &decodeErr) {
+		decodeErr = &DecodeError{Err: err}
+		err = decodeErr
+	}
+
+	if !decodeErr.located {
+		decodeErr.Offset = d.reader.offset
+		decodeErr.Path = d.pathString()
+		decodeErr.located = true
+	}
+
+	return err
+}
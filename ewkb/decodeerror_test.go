@@ -0,0 +1,96 @@
+package ewkb_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeError(t *testing.T) {
+	multiPolygon, err := ewkb.Marshal(largeMultiPolygon(3, 10))
+	require.NoError(t, err)
+
+	// Header (9 bytes), 2 polygons (173 bytes each), polygon header, ring count
+	// and vertex count (13 bytes), 5 vertices (80 bytes) and a half vertex.
+	truncated := multiPolygon[:2*(9+2*173+13+80+8)]
+
+	t.Run("truncated", func(t *testing.T) {
+		for name, geometry := range map[string]ewkb.Unmarshaler{
+			"map":  &ewkb.MultiPolygon{},
+			"flat": &ewkb.FlatMultiPolygon{},
+		} {
+			geometry := geometry
+
+			t.Run(name, func(t *testing.T) {
+				err := ewkb.NewDecoder(unsizedReader{bytes.NewReader(truncated)}).Decode(geometry)
+				require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+				var decodeErr *ewkb.DecodeError
+				require.True(t, errors.As(err, &decodeErr))
+
+				assert.Equal(t, 456, decodeErr.Offset)
+				assert.Equal(t, "MultiPolygon[2].Ring[0].Vertex[5]", decodeErr.Path)
+				assert.EqualError(t, err, "unexpected EOF at offset 456 in MultiPolygon[2].Ring[0].Vertex[5]")
+			})
+		}
+	})
+
+	t.Run("truncated with known size", func(t *testing.T) {
+		err := ewkb.Unmarshal(&ewkb.MultiPolygon{}, truncated)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		var decodeErr *ewkb.DecodeError
+		require.True(t, errors.As(err, &decodeErr))
+
+		assert.Equal(t, 368, decodeErr.Offset)
+		assert.Equal(t, "MultiPolygon[2].Ring[0]", decodeErr.Path)
+		assert.Equal(t, "10 items of 16 bytes", decodeErr.Expected)
+		assert.Equal(t, "88 bytes remaining", decodeErr.Found)
+	})
+
+	t.Run("wrong geometry type", func(t *testing.T) {
+		// LINESTRING EMPTY.
+		err := ewkb.Unmarshal(&ewkb.Point{}, "010200000000000000")
+		require.ErrorIs(t, err, ewkb.ErrWrongGeometryType)
+
+		var decodeErr *ewkb.DecodeError
+		require.True(t, errors.As(err, &decodeErr))
+
+		assert.Equal(t, "Point", decodeErr.Expected)
+		assert.Equal(t, "LineString", decodeErr.Found)
+		assert.Equal(t, 5, decodeErr.Offset)
+		assert.EqualError(t, err, "wrong geometry type: found LineString, expected Point at offset 5 in LineString")
+	})
+
+	t.Run("nested in collection", func(t *testing.T) {
+		collection, err := ewkb.Marshal(ewkb.GeometryCollection{
+			Collection: []ewkb.Geometry{
+				&ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+				&ewkb.LineString{CoordinateSet: ewkb.CoordinateSet{{'x': 1, 'y': 2}, {'x': 3, 'y': 4}}},
+			},
+		})
+		require.NoError(t, err)
+
+		err = ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader(collection[:len(collection)-2])},
+		).Decode(ewkb.NewGeometryCollection())
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		var decodeErr *ewkb.DecodeError
+		require.True(t, errors.As(err, &decodeErr))
+
+		assert.Equal(t, "GeometryCollection[1].LineString.Vertex[1]", decodeErr.Path)
+	})
+
+	t.Run("without decoder", func(t *testing.T) {
+		err := (&ewkb.Point{}).UnmarshalEWBK(ewkb.ExtendedWellKnownBytes{
+			ExtendedWellKnownBytesHeader: ewkb.ExtendedWellKnownBytesHeader{Type: ewkb.GeometryTypeLineString},
+		})
+		assert.EqualError(t, err, "wrong geometry type: found LineString, expected Point")
+	})
+}
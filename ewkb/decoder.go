@@ -34,13 +34,16 @@ type ExtendedWellKnownBytes struct {
 	ExtendedWellKnownBytesHeader
 	DataStream io.Reader
 	IsNil      bool
+
+	state *decodeState
+	depth int
 }
 
 // ReadUint32 reads 32-bit unsigned integer from the stream.
 func (e ExtendedWellKnownBytes) ReadUint32() (uint32, error) {
 	data := make([]byte, size32bit)
 
-	_, err := e.DataStream.Read(data)
+	_, err := io.ReadFull(e.DataStream, data)
 
 	return e.ByteOrder.Uint32(data), err
 }
@@ -49,7 +52,7 @@ func (e ExtendedWellKnownBytes) ReadUint32() (uint32, error) {
 func (e ExtendedWellKnownBytes) ReadFloat64() (float64, error) {
 	data := make([]byte, size64bit)
 
-	_, err := e.DataStream.Read(data)
+	_, err := io.ReadFull(e.DataStream, data)
 
 	bits := e.ByteOrder.Uint64(data)
 
@@ -60,7 +63,7 @@ func (e ExtendedWellKnownBytes) ReadFloat64() (float64, error) {
 func DecodeHeader(reader io.Reader) (*ExtendedWellKnownBytes, error) {
 	firstByte := make([]byte, size8bit)
 
-	_, err := reader.Read(firstByte)
+	_, err := io.ReadFull(reader, firstByte)
 	if err == io.EOF {
 		return &ExtendedWellKnownBytes{IsNil: true}, nil
 	}
@@ -81,7 +84,7 @@ func DecodeHeader(reader io.Reader) (*ExtendedWellKnownBytes, error) {
 	}
 
 	controlByte := make([]byte, size32bit)
-	if _, err := reader.Read(controlByte); err != nil {
+	if _, err := io.ReadFull(reader, controlByte); err != nil {
 		return nil, err
 	}
 
@@ -109,13 +112,13 @@ func DecodeHeader(reader io.Reader) (*ExtendedWellKnownBytes, error) {
 }
 
 // Unmarshal converts EWKB array of bytes to Geometry.
-func Unmarshal(geoShape Unmarshaler, value interface{}) error {
+func Unmarshal(geoShape Unmarshaler, value interface{}, opts ...func(*Decoder)) error {
 	if value == nil {
 		return nil
 	}
 
 	if strData, ok := value.(string); ok {
-		return Unmarshal(geoShape, []byte(strData))
+		return Unmarshal(geoShape, []byte(strData), opts...)
 	}
 
 	dataByte, ok := value.([]byte)
@@ -123,27 +126,126 @@ func Unmarshal(geoShape Unmarshaler, value interface{}) error {
 		return ErrIncompatibleFormat
 	}
 
-	return NewDecoder(bytes.NewBuffer(dataByte)).Decode(geoShape)
+	return NewDecoder(bytes.NewBuffer(dataByte), opts...).Decode(geoShape)
 }
 
 // Decoder is a Extended Well Known Byte decoder.
 type Decoder struct {
 	reader io.Reader
+	limits DecoderLimits
+	strict bool
+
+	counting *countingReader
+	size     int
 }
 
 // NewDecoder creates a EWKB decoder.
-func NewDecoder(reader io.Reader) *Decoder {
-	return &Decoder{
-		reader: hex.NewDecoder(reader),
+func NewDecoder(reader io.Reader, opts ...func(*Decoder)) *Decoder {
+	output := &Decoder{
+		size: -1,
+	}
+
+	for _, opt := range opts {
+		opt(output)
 	}
+
+	if sized, ok := reader.(interface{ Len() int }); ok {
+		output.size = hex.DecodedLen(sized.Len())
+	}
+
+	output.counting = &countingReader{
+		reader:   hex.NewDecoder(reader),
+		maxBytes: output.limits.MaxBytes,
+	}
+	output.reader = output.counting
+
+	return output
 }
 
 // Decode decodes to a Geometry.
 func (d *Decoder) Decode(geoShape Unmarshaler) error {
+	state := newDecodeState(d.limits, d.counting, d.size)
+	state.strict = d.strict
+
 	record, err := DecodeHeader(d.reader)
 	if err != nil {
+		return state.locate(state.wrapEOF(err))
+	}
+
+	if record.IsNil && state.strict {
+		return state.locate(io.ErrUnexpectedEOF)
+	}
+
+	record.state = state
+
+	if !record.IsNil {
+		state.enter(record.Type.String())
+	}
+
+	if err := geoShape.UnmarshalEWBK(*record); err != nil {
+		return state.locate(state.wrapEOF(err))
+	}
+
+	if record.IsNil {
+		return nil
+	}
+
+	return state.checkTrailing()
+}
+
+// readVertexCount reads the size of a set of vertices, and checks it against
+// the limits before allocating.
+func (e ExtendedWellKnownBytes) readVertexCount() (uint32, error) {
+	size, err := e.ReadUint32()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, e.state.addVertices(size, e.Layout)
+}
+
+// readPartCount reads a number of parts, and checks it against the limits
+// before allocating.
+func (e ExtendedWellKnownBytes) readPartCount(minSize int) (uint32, error) {
+	size, err := e.ReadUint32()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, e.state.addParts(size, minSize)
+}
+
+// decodeNested decodes a geometry nested in a multi geometry.
+func (e ExtendedWellKnownBytes) decodeNested(geoShape Unmarshaler) error {
+	record, err := e.decodeNestedHeader()
+	if err != nil {
+		return err
+	}
+
+	if err := geoShape.UnmarshalEWBK(*record); err != nil {
 		return err
 	}
 
-	return geoShape.UnmarshalEWBK(*record)
+	return e.checkNestedLayout(record, geoShape)
+}
+
+// decodeNestedHeader decodes the header of a geometry nested in a collection.
+func (e ExtendedWellKnownBytes) decodeNestedHeader() (*ExtendedWellKnownBytes, error) {
+	if err := e.state.checkDepth(e.depth + 1); err != nil {
+		return nil, err
+	}
+
+	record, err := DecodeHeader(e.DataStream)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.IsNil {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	record.state = e.state
+	record.depth = e.depth + 1
+
+	return record, nil
 }
@@ -0,0 +1,128 @@
+package ewkb_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmpty(t *testing.T) {
+	// Binaries are the output of PostGIS (SELECT 'POINT EMPTY'::geometry).
+	fixtures := []struct {
+		geometry    ewkb.Geometry
+		strGeometry string
+		binary      string
+	}{
+		{
+			geometry:    &ewkb.Point{},
+			strGeometry: "POINT EMPTY",
+			binary:      "0101000000000000000000F87F000000000000F87F",
+		},
+		{
+			geometry:    &ewkb.Point{},
+			strGeometry: "POINT Z EMPTY, 4326",
+			binary:      "01010000A0E6100000000000000000F87F000000000000F87F000000000000F87F",
+		},
+		{
+			geometry:    &ewkb.LineString{},
+			strGeometry: "LINESTRING EMPTY",
+			binary:      "010200000000000000",
+		},
+		{
+			geometry:    &ewkb.LineString{},
+			strGeometry: "LINESTRING EMPTY, 4326",
+			binary:      "0102000020E610000000000000",
+		},
+		{
+			geometry:    &ewkb.Polygon{},
+			strGeometry: "POLYGON EMPTY",
+			binary:      "010300000000000000",
+		},
+		{
+			geometry:    &ewkb.MultiPoint{},
+			strGeometry: "MULTIPOINT EMPTY",
+			binary:      "010400000000000000",
+		},
+		{
+			geometry:    &ewkb.MultiPoint{},
+			strGeometry: "MULTIPOINT(EMPTY)",
+			binary:      "0104000000010000000101000000000000000000F87F000000000000F87F",
+		},
+		{
+			geometry:    &ewkb.MultiLineString{},
+			strGeometry: "MULTILINESTRING EMPTY",
+			binary:      "010500000000000000",
+		},
+		{
+			geometry:    &ewkb.MultiPolygon{},
+			strGeometry: "MULTIPOLYGON EMPTY",
+			binary:      "010600000000000000",
+		},
+		{
+			geometry:    ewkb.NewGeometryCollection(),
+			strGeometry: "GEOMETRYCOLLECTION EMPTY",
+			binary:      "010700000000000000",
+		},
+		{
+			geometry:    ewkb.NewGeometryCollection(),
+			strGeometry: "GEOMETRYCOLLECTION(POINT EMPTY)",
+			binary:      "0107000000010000000101000000000000000000F87F000000000000F87F",
+		},
+		{
+			geometry:    &ewkb.CircularString{},
+			strGeometry: "CIRCULARSTRING EMPTY",
+			binary:      "010800000000000000",
+		},
+		{
+			geometry:    &ewkb.Triangle{},
+			strGeometry: "TRIANGLE EMPTY",
+			binary:      "011100000000000000",
+		},
+	}
+
+	for idx, elt := range fixtures {
+		fixture := elt
+
+		t.Run(fmt.Sprintf("%d - %s", idx, fixture.strGeometry), func(t *testing.T) {
+			require.NoError(t, ewkb.Unmarshal(fixture.geometry, fixture.binary))
+			assert.True(t, ewkb.IsEmpty(fixture.geometry))
+
+			output, err := ewkb.Marshal(fixture.geometry)
+			require.NoError(t, err)
+
+			assert.Equal(t, strings.ToLower(fixture.binary), string(output))
+		})
+	}
+
+	t.Run("zero values", func(t *testing.T) {
+		for _, geometry := range []ewkb.Geometry{
+			&ewkb.Point{},
+			&ewkb.LineString{},
+			&ewkb.Polygon{},
+			&ewkb.MultiPoint{},
+			&ewkb.MultiLineString{},
+			&ewkb.MultiPolygon{},
+			&ewkb.GeometryCollection{},
+			&ewkb.CircularString{},
+			&ewkb.Triangle{},
+		} {
+			assert.True(t, ewkb.IsEmpty(geometry), "%T", geometry)
+		}
+
+		output, err := ewkb.Marshal(ewkb.Point{})
+		require.NoError(t, err)
+		assert.Equal(t, "0101000000000000000000f87f000000000000f87f", string(output))
+	})
+
+	t.Run("not empty", func(t *testing.T) {
+		point := ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}}
+
+		assert.False(t, ewkb.IsEmpty(point))
+		assert.False(t, ewkb.IsEmpty(ewkb.MultiPoint{Points: []ewkb.Point{{}, point}}))
+		assert.False(t, ewkb.IsEmpty(ewkb.Polygon{CoordinateGroup: ewkb.CoordinateGroup{{point.Coordinate}}}))
+	})
+}
@@ -81,7 +81,7 @@ func IsEWKB(data interface{}) bool {
 	}
 
 	if byteData, ok := data.([]byte); ok {
-		return byteData[0] == 0 || byteData[0] == 1
+		return len(byteData) > 0 && (byteData[0] == 0 || byteData[0] == 1)
 	}
 
 	return false
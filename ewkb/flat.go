@@ -0,0 +1,358 @@
+package ewkb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// flatChunkSize is the number of float64 read at once by the flat decoders.
+	flatChunkSize = 512
+
+	// canonicalNaN is the quiet NaN PostGIS writes for empty coordinates.
+	canonicalNaN uint64 = 0x7FF8000000000000
+)
+
+// FlatCoords is a sequence of coordinates stored in a single []float64: each
+// vertex is Stride() consecutive values, in the layout order (x, y, [z], [m]).
+//
+// Flat geometries (FlatPoint, FlatLineString, FlatPolygon, ...) are built on
+// FlatCoords; they decode and encode without allocating per vertex:
+//
+//	polygon := ewkb.FlatPolygon{}
+//	err := ewkb.Unmarshal(&polygon, value)
+//	...
+//	for idx := 0; idx < polygon.Len(); idx++ {
+//		coord := polygon.Coord(idx)
+//		// use coord.X, coord.Y
+//	}
+type FlatCoords struct {
+	layout Layout
+	Coords []float64
+}
+
+// NewFlatCoords creates flat coordinates with the given layout.
+func NewFlatCoords(layout Layout, coords []float64) FlatCoords {
+	return FlatCoords{
+		layout: layout,
+		Coords: coords,
+	}
+}
+
+// Layout implements the Marshaler interface.
+func (f FlatCoords) Layout() Layout {
+	return f.layout
+}
+
+// Stride is the number of values of a vertex.
+func (f FlatCoords) Stride() int {
+	return int(f.layout.Size())
+}
+
+// Len is the number of vertices.
+func (f FlatCoords) Len() int {
+	return len(f.Coords) / f.Stride()
+}
+
+// Coord gets the vertex at index idx.
+func (f FlatCoords) Coord(idx int) Coord {
+	stride := f.Stride()
+
+	return NewCoord(f.layout, f.Coords[idx*stride:(idx+1)*stride]...)
+}
+
+// Append appends vertices. The values are taken in the layout of f, whatever
+// the layout of the coordinates.
+func (f *FlatCoords) Append(coords ...Coord) {
+	for _, coord := range coords {
+		coord.Layout = f.layout
+		f.Coords = coord.AppendValues(f.Coords)
+	}
+}
+
+// slice is a view on the vertices from start to end (excluded).
+func (f FlatCoords) slice(start int, end int) FlatCoords {
+	stride := f.Stride()
+
+	return NewFlatCoords(f.layout, f.Coords[start*stride:end*stride])
+}
+
+// coordinateSet converts the vertices from start to end (excluded) to the map representation.
+func (f FlatCoords) coordinateSet(start int, end int) CoordinateSet {
+	output := make(CoordinateSet, end-start)
+
+	for idx := range output {
+		output[idx] = f.coordinate(start + idx)
+	}
+
+	return output
+}
+
+func (f FlatCoords) coordinate(idx int) Coordinate {
+	stride := f.Stride()
+	output := make(Coordinate, stride)
+
+	for pos, name := range f.layout.Format() {
+		output[byte(name)] = f.Coords[idx*stride+pos]
+	}
+
+	return output
+}
+
+// appendCoordinate appends the values of the coordinate in the layout order.
+// Missing values are NaN.
+func appendCoordinate(coords []float64, layout Layout, coordinate Coordinate) []float64 {
+	for _, name := range layout.Format() {
+		value, ok := coordinate[byte(name)]
+		if !ok {
+			value = math.NaN()
+		}
+
+		coords = append(coords, value)
+	}
+
+	return coords
+}
+
+// flatReader reads flat geometries, reusing its buffer.
+type flatReader struct {
+	record ExtendedWellKnownBytes
+	buffer [flatChunkSize * size64bit]byte
+
+	// nestedLayout is the layout of the current nested geometry.
+	nestedLayout Layout
+
+	// byteOrder is the byte order of the current nested geometry.
+	byteOrder binary.ByteOrder
+}
+
+func newFlatReader(record ExtendedWellKnownBytes, geometryType GeometryType) (*flatReader, error) {
+	if record.Type != geometryType {
+		return nil, newDecodeError(ErrWrongGeometryType, geometryType, record.Type)
+	}
+
+	return &flatReader{record: record, nestedLayout: record.Layout, byteOrder: record.ByteOrder}, nil
+}
+
+func (f *flatReader) readUint32() (uint32, error) {
+	data := f.buffer[:size32bit]
+
+	if _, err := io.ReadFull(f.record.DataStream, data); err != nil {
+		return 0, err
+	}
+
+	return f.byteOrder.Uint32(data), nil
+}
+
+// readPartCount reads a number of parts, and checks it against the limits.
+func (f *flatReader) readPartCount(minSize int) (uint32, error) {
+	size, err := f.readUint32()
+	if err != nil {
+		return 0, err
+	}
+
+	return size, f.record.state.addParts(size, minSize)
+}
+
+// readCoords appends count vertices to coords.
+func (f *flatReader) readCoords(coords []float64, count uint32) ([]float64, error) {
+	if count > 0 && f.nestedLayout != f.record.Layout {
+		return coords, fmt.Errorf(
+			"%w: nested geometry with layout %s in %s",
+			ErrIncompatibleFormat,
+			f.nestedLayout.Format(),
+			f.record.Layout.Format(),
+		)
+	}
+
+	if err := f.record.state.addVertices(count, f.record.Layout); err != nil {
+		return coords, err
+	}
+
+	start := len(coords)
+	remaining := int(count) * int(f.record.Layout.Size())
+
+	for remaining > 0 {
+		chunk := remaining
+		if chunk > flatChunkSize {
+			chunk = flatChunkSize
+		}
+
+		data := f.buffer[:chunk*size64bit]
+
+		if read, err := io.ReadFull(f.record.DataStream, data); err != nil {
+			// Vertices are not tracked one by one, the failing one is located from the read values.
+			f.record.state.enter("Vertex")
+			f.record.state.at((len(coords)-start)/int(f.record.Layout.Size()) + read/(size64bit*int(f.record.Layout.Size())))
+
+			return coords, err
+		}
+
+		for idx := 0; idx < chunk; idx++ {
+			coords = append(coords, math.Float64frombits(f.byteOrder.Uint64(data[idx*size64bit:])))
+		}
+
+		remaining -= chunk
+	}
+
+	return coords, nil
+}
+
+// readCoordSet appends a set of vertices (size + vertices) to coords.
+func (f *flatReader) readCoordSet(coords []float64) ([]float64, error) {
+	size, err := f.readUint32()
+	if err != nil {
+		return coords, err
+	}
+
+	return f.readCoords(coords, size)
+}
+
+// readCoordGroup appends a group of sets of vertices to coords, and the end
+// of each set to ends.
+func (f *flatReader) readCoordGroup(coords []float64, ends []int) ([]float64, []int, error) {
+	size, err := f.readPartCount(minPartSize)
+	if err != nil {
+		return coords, ends, err
+	}
+
+	stride := int(f.record.Layout.Size())
+
+	f.record.state.enter("Ring")
+
+	for idx := uint32(0); idx < size; idx++ {
+		f.record.state.at(int(idx))
+
+		coords, err = f.readCoordSet(coords)
+		if err != nil {
+			return coords, ends, err
+		}
+
+		ends = append(ends, len(coords)/stride)
+	}
+
+	f.record.state.leave()
+
+	return coords, ends, nil
+}
+
+// readHeader reads the header of a geometry nested in a multi geometry. The nested
+// geometry must have the same layout as its parent, unless it is empty.
+func (f *flatReader) readHeader(geometryType GeometryType) error {
+	data := f.buffer[:1+size32bit]
+
+	if _, err := io.ReadFull(f.record.DataStream, data); err != nil {
+		return err
+	}
+
+	switch data[0] {
+	case bigEndian:
+		f.byteOrder = binary.BigEndian
+	case littleEndian:
+		f.byteOrder = binary.LittleEndian
+	default:
+		return ErrWrongByteOrder
+	}
+
+	header := f.byteOrder.Uint32(data[1:])
+
+	if found := GeometryType(header &^ (ewkbZ | ewkbM | ewkbSRID)); found != geometryType {
+		return newDecodeError(ErrWrongGeometryType, geometryType, found)
+	}
+
+	f.nestedLayout = Layout((header & (ewkbZ | ewkbM)) >> 30) //nolint: gomnd
+
+	if header&ewkbSRID != 0 {
+		// Nested SRID is ignored.
+		if _, err := f.readUint32(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendUint32 writes in place, as a local array would escape through the
+// binary.ByteOrder interface.
+func appendUint32(output []byte, value uint32, byteOrder binary.ByteOrder) []byte {
+	output = append(output, 0, 0, 0, 0)
+
+	byteOrder.PutUint32(output[len(output)-size32bit:], value)
+
+	return output
+}
+
+func appendFloat64(output []byte, value float64, byteOrder binary.ByteOrder) []byte {
+	bits := math.Float64bits(value)
+
+	if value != value {
+		bits = canonicalNaN
+	}
+
+	output = append(output, 0, 0, 0, 0, 0, 0, 0, 0)
+
+	byteOrder.PutUint64(output[len(output)-size64bit:], bits)
+
+	return output
+}
+
+// appendHeader appends the header of a geometry nested in a multi geometry (without SRID).
+func appendHeader(output []byte, geometryType GeometryType, layout Layout, byteOrder binary.ByteOrder) []byte {
+	if byteOrder == binary.BigEndian {
+		output = append(output, bigEndian)
+	} else {
+		output = append(output, littleEndian)
+	}
+
+	return appendUint32(output, layout.Uint32()+uint32(geometryType), byteOrder)
+}
+
+func appendCoords(output []byte, coords []float64, byteOrder binary.ByteOrder) []byte {
+	for _, value := range coords {
+		output = appendFloat64(output, value, byteOrder)
+	}
+
+	return output
+}
+
+// appendCoordSet appends the size and the vertices.
+func appendCoordSet(output []byte, coords FlatCoords, byteOrder binary.ByteOrder) []byte {
+	output = appendUint32(output, uint32(coords.Len()), byteOrder)
+
+	return appendCoords(output, coords.Coords, byteOrder)
+}
+
+// appendCoordGroup appends the sets of vertices delimited by ends.
+func appendCoordGroup(output []byte, coords FlatCoords, ends []int, byteOrder binary.ByteOrder) []byte {
+	output = appendUint32(output, uint32(len(ends)), byteOrder)
+
+	start := 0
+
+	for _, end := range ends {
+		output = appendCoordSet(output, coords.slice(start, end), byteOrder)
+		start = end
+	}
+
+	return output
+}
+
+// checkEnds checks that ends are increasing and within the coordinates.
+func checkEnds(coords FlatCoords, ends []int) error {
+	start := 0
+
+	for _, end := range ends {
+		if end < start || end > coords.Len() {
+			return fmt.Errorf("%w: wrong end %d", ErrIncompatibleFormat, end)
+		}
+
+		start = end
+	}
+
+	if start != coords.Len() {
+		return fmt.Errorf("%w: %d vertices out of the ends", ErrIncompatibleFormat, coords.Len()-start)
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package ewkb_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlat(t *testing.T) {
+	srid := ewkb.SystemReferenceWGS84
+
+	ring := func(offset float64) ewkb.CoordinateSet {
+		return ewkb.CoordinateSet{
+			{'x': offset, 'y': offset, 'z': 1, 'm': 2},
+			{'x': offset + 1, 'y': offset, 'z': 1, 'm': 2},
+			{'x': offset + 1, 'y': offset + 1, 'z': 1, 'm': 2},
+			{'x': offset, 'y': offset, 'z': 1, 'm': 2},
+		}
+	}
+
+	fixtures := []struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{}
+
+	point := ewkb.Point{SRID: &srid, Coordinate: ewkb.Coordinate{'x': 1, 'y': 2, 'm': 3}}
+	flatPoint := &ewkb.FlatPoint{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "point",
+		geometry: &point,
+		flat:     flatPoint,
+		toFlat:   func() ewkb.Geometry { out := point.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatPoint.Point(); return &out },
+	})
+
+	line := ewkb.LineString{SRID: &srid, CoordinateSet: ring(0)}
+	flatLine := &ewkb.FlatLineString{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "linestring",
+		geometry: &line,
+		flat:     flatLine,
+		toFlat:   func() ewkb.Geometry { out := line.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatLine.LineString(); return &out },
+	})
+
+	polygon := ewkb.Polygon{SRID: &srid, CoordinateGroup: ewkb.CoordinateGroup{ring(0), ring(10)}}
+	flatPolygon := &ewkb.FlatPolygon{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "polygon",
+		geometry: &polygon,
+		flat:     flatPolygon,
+		toFlat:   func() ewkb.Geometry { out := polygon.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatPolygon.Polygon(); return &out },
+	})
+
+	multiPoint := ewkb.MultiPoint{Points: []ewkb.Point{{Coordinate: ring(0)[0]}, {Coordinate: ring(0)[1]}}}
+	flatMultiPoint := &ewkb.FlatMultiPoint{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "multipoint",
+		geometry: &multiPoint,
+		flat:     flatMultiPoint,
+		toFlat:   func() ewkb.Geometry { out := multiPoint.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatMultiPoint.MultiPoint(); return &out },
+	})
+
+	multiLine := ewkb.MultiLineString{LineStrings: []ewkb.LineString{{CoordinateSet: ring(0)}, {CoordinateSet: ring(5)}}}
+	flatMultiLine := &ewkb.FlatMultiLineString{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "multilinestring",
+		geometry: &multiLine,
+		flat:     flatMultiLine,
+		toFlat:   func() ewkb.Geometry { out := multiLine.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatMultiLine.MultiLineString(); return &out },
+	})
+
+	multiPolygon := ewkb.MultiPolygon{SRID: &srid, Polygons: []ewkb.Polygon{
+		{CoordinateGroup: ewkb.CoordinateGroup{ring(0), ring(10)}},
+		{CoordinateGroup: ewkb.CoordinateGroup{ring(20)}},
+	}}
+	flatMultiPolygon := &ewkb.FlatMultiPolygon{}
+	fixtures = append(fixtures, struct {
+		name     string
+		geometry ewkb.Geometry
+		flat     ewkb.Geometry
+		toFlat   func() ewkb.Geometry
+		fromFlat func() ewkb.Geometry
+	}{
+		name:     "multipolygon",
+		geometry: &multiPolygon,
+		flat:     flatMultiPolygon,
+		toFlat:   func() ewkb.Geometry { out := multiPolygon.Flat(); return &out },
+		fromFlat: func() ewkb.Geometry { out := flatMultiPolygon.MultiPolygon(); return &out },
+	})
+
+	for idx, elt := range fixtures {
+		fixture := elt
+
+		t.Run(fmt.Sprintf("%d - %s", idx, fixture.name), func(t *testing.T) {
+			binary, err := ewkb.Marshal(fixture.geometry)
+			require.NoError(t, err)
+
+			// Decode twice, to check that a previous decoding is replaced.
+			require.NoError(t, ewkb.Unmarshal(fixture.flat, binary))
+			require.NoError(t, ewkb.Unmarshal(fixture.flat, binary))
+
+			output, err := ewkb.Marshal(fixture.flat)
+			require.NoError(t, err)
+			assert.Equal(t, string(binary), string(output))
+
+			assert.Equal(t, fixture.toFlat(), fixture.flat)
+			assert.Equal(t, fixture.geometry, fixture.fromFlat())
+		})
+	}
+
+	t.Run("accessors", func(t *testing.T) {
+		assert.Equal(t, 4, flatPolygon.Stride())
+		assert.Equal(t, 8, flatPolygon.Len())
+		assert.Equal(t, []int{4, 8}, flatPolygon.Ends)
+		assert.Equal(t, ewkb.Coord{X: 11, Y: 11, Z: 1, M: 2, Layout: ewkb.LayoutWith(true, true)}, flatPolygon.Ring(1).Coord(2))
+
+		second := flatMultiPolygon.Polygon(1)
+		assert.Equal(t, []int{4}, second.Ends)
+		assert.Equal(t, 20.0, second.Coord(0).X)
+
+		assert.Equal(t, 5.0, flatMultiLine.LineString(1).Coord(0).X)
+	})
+
+	t.Run("append", func(t *testing.T) {
+		coords := ewkb.NewFlatCoords(ewkb.LayoutWith(false, true), nil)
+		coords.Append(ewkb.Coord{X: 1, Y: 2, Z: 3}, ewkb.Coord{X: 4, Y: 5, M: 6})
+
+		assert.Equal(t, []float64{1, 2, 3, 4, 5, 0}, coords.Coords)
+	})
+
+	t.Run("empty point", func(t *testing.T) {
+		empty := ewkb.FlatPoint{}
+
+		require.NoError(t, ewkb.Unmarshal(&empty, "0101000000000000000000F87F000000000000F87F"))
+		assert.True(t, empty.IsEmpty())
+		assert.True(t, math.IsNaN(empty.Coord(0).X))
+
+		output, err := ewkb.Marshal(ewkb.FlatPoint{})
+		require.NoError(t, err)
+		assert.Equal(t, "0101000000000000000000f87f000000000000f87f", string(output))
+	})
+
+	t.Run("wrong ends", func(t *testing.T) {
+		_, err := ewkb.Marshal(ewkb.FlatPolygon{
+			FlatCoords: ewkb.NewFlatCoords(ewkb.LayoutWith(false, false), []float64{1, 2, 3, 4}),
+			Ends:       []int{1},
+		})
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+	})
+
+	t.Run("empty nested polygon", func(t *testing.T) {
+		// MULTIPOLYGON Z(EMPTY, ((...))): the empty polygon has no Z.
+		multi := ewkb.MultiPolygon{Polygons: []ewkb.Polygon{
+			{},
+			{CoordinateGroup: ewkb.CoordinateGroup{{{'x': 1, 'y': 2, 'z': 3}}}},
+		}}
+
+		binary, err := ewkb.Marshal(multi)
+		require.NoError(t, err)
+
+		flat := ewkb.FlatMultiPolygon{}
+		require.NoError(t, ewkb.Unmarshal(&flat, binary))
+		assert.True(t, flat.Polygon(0).IsEmpty())
+		assert.Equal(t, ewkb.Coord{X: 1, Y: 2, Z: 3, Layout: ewkb.LayoutWith(false, true)}, flat.Polygon(1).Coord(0))
+	})
+
+	t.Run("mixed byte orders", func(t *testing.T) {
+		// Little endian MULTIPOINT with a big endian POINT(1 2) and a little endian POINT(3 4).
+		flat := ewkb.FlatMultiPoint{}
+		require.NoError(t, ewkb.Unmarshal(&flat, "010400000002000000"+
+			"00000000013FF00000000000004000000000000000"+
+			"010100000000000000000008400000000000001040"))
+		assert.Equal(t, []float64{1, 2, 3, 4}, flat.Coords)
+	})
+
+	t.Run("truncated input", func(t *testing.T) {
+		binary, err := ewkb.Marshal(multiPolygon)
+		require.NoError(t, err)
+
+		other, err := ewkb.Marshal(largeMultiPolygon(3, 10))
+		require.NoError(t, err)
+
+		flat := ewkb.FlatMultiPolygon{}
+		require.NoError(t, ewkb.Unmarshal(&flat, binary))
+		require.Error(t, ewkb.Unmarshal(&flat, other[:len(other)/4*2]))
+		assert.Equal(t, multiPolygon.Flat(), flat)
+
+		binary, err = ewkb.Marshal(multiLine)
+		require.NoError(t, err)
+
+		other, err = ewkb.Marshal(ewkb.MultiLineString{LineStrings: []ewkb.LineString{{CoordinateSet: ring(10)}, {CoordinateSet: ring(20)}}})
+		require.NoError(t, err)
+
+		lines := ewkb.FlatMultiLineString{}
+		require.NoError(t, ewkb.Unmarshal(&lines, binary))
+		require.Error(t, ewkb.Unmarshal(&lines, other[:len(other)/4*2]))
+		assert.Equal(t, multiLine.Flat(), lines)
+	})
+
+	t.Run("wrong nested layout", func(t *testing.T) {
+		// MULTIPOINT with a nested POINT Z.
+		err := ewkb.Unmarshal(&ewkb.FlatMultiPoint{}, "0104000000010000000101000080000000000000F03F00000000000000400000000000000840")
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+	})
+}
+
+func largeMultiPolygon(polygons int, vertices int) ewkb.MultiPolygon {
+	output := ewkb.MultiPolygon{Polygons: make([]ewkb.Polygon, polygons)}
+
+	for idx := range output.Polygons {
+		ring := make(ewkb.CoordinateSet, vertices)
+
+		for pos := range ring {
+			angle := 2 * math.Pi * float64(pos) / float64(vertices-1)
+			ring[pos] = ewkb.Coordinate{'x': float64(idx) + math.Cos(angle), 'y': math.Sin(angle)}
+		}
+
+		output.Polygons[idx] = ewkb.Polygon{CoordinateGroup: ewkb.CoordinateGroup{ring}}
+	}
+
+	return output
+}
+
+func BenchmarkMultiPolygonUnmarshal(b *testing.B) {
+	binary, err := ewkb.Marshal(largeMultiPolygon(100, 1000))
+	require.NoError(b, err)
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for idx := 0; idx < b.N; idx++ {
+			multi := ewkb.MultiPolygon{}
+			if err := ewkb.Unmarshal(&multi, binary); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("flat", func(b *testing.B) {
+		b.ReportAllocs()
+
+		multi := ewkb.FlatMultiPolygon{}
+
+		for idx := 0; idx < b.N; idx++ {
+			if err := ewkb.Unmarshal(&multi, binary); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMultiPolygonMarshal(b *testing.B) {
+	multi := largeMultiPolygon(100, 1000)
+	flat := multi.Flat()
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for idx := 0; idx < b.N; idx++ {
+			if _, err := ewkb.Marshal(multi); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("flat", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for idx := 0; idx < b.N; idx++ {
+			if _, err := ewkb.Marshal(flat); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
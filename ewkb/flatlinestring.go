@@ -0,0 +1,70 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatLineString is a LINESTRING in database, stored as FlatCoords (see LineString).
+type FlatLineString struct {
+	SRID *SystemReferenceID
+	FlatCoords
+}
+
+// Type implements the Geometry interface.
+func (l FlatLineString) Type() GeometryType {
+	return GeometryTypeLineString
+}
+
+// IsEmpty checks if the line has no vertex.
+func (l FlatLineString) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (l *FlatLineString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, l.Type())
+	if err != nil {
+		return err
+	}
+
+	coords, err := reader.readCoordSet(nil)
+	if err != nil {
+		return err
+	}
+
+	l.SRID = record.SRID
+	l.FlatCoords = NewFlatCoords(record.Layout, coords)
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (l FlatLineString) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	output := make([]byte, 0, size32bit+len(l.Coords)*size64bit)
+
+	return appendCoordSet(output, l.FlatCoords, byteOrder), nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (l FlatLineString) SystemReferenceID() *SystemReferenceID {
+	return l.SRID
+}
+
+// LineString converts to the map representation.
+func (l FlatLineString) LineString() LineString {
+	return LineString{SRID: l.SRID, CoordinateSet: l.coordinateSet(0, l.Len())}
+}
+
+// Flat converts to the flat representation.
+func (l LineString) Flat() FlatLineString {
+	output := FlatLineString{
+		SRID:       l.SRID,
+		FlatCoords: NewFlatCoords(l.Layout(), nil),
+	}
+
+	for _, coordinate := range l.CoordinateSet {
+		output.Coords = appendCoordinate(output.Coords, output.layout, coordinate)
+	}
+
+	return output
+}
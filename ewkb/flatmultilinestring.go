@@ -0,0 +1,130 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatMultiLineString is a MULTILINESTRING in database, stored as FlatCoords
+// (see MultiLineString).
+//
+// Ends are the end vertex index (excluded) of each line.
+type FlatMultiLineString struct {
+	SRID *SystemReferenceID
+	FlatCoords
+	Ends []int
+}
+
+// Type implements the Geometry interface.
+func (m FlatMultiLineString) Type() GeometryType {
+	return GeometryTypeMultiLineString
+}
+
+// IsEmpty checks if the MultiLineString has no vertex.
+func (m FlatMultiLineString) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// LineString gets the line at index idx.
+func (m FlatMultiLineString) LineString(idx int) FlatLineString {
+	start := 0
+	if idx > 0 {
+		start = m.Ends[idx-1]
+	}
+
+	return FlatLineString{FlatCoords: m.slice(start, m.Ends[idx])}
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (m *FlatMultiLineString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, m.Type())
+	if err != nil {
+		return err
+	}
+
+	size, err := reader.readPartCount(minNestedSize)
+	if err != nil {
+		return err
+	}
+
+	output := FlatMultiLineString{SRID: record.SRID, FlatCoords: NewFlatCoords(record.Layout, nil), Ends: []int{}}
+
+	for idx := uint32(0); idx < size; idx++ {
+		reader.record.state.at(int(idx))
+
+		if err := reader.readHeader(GeometryTypeLineString); err != nil {
+			return err
+		}
+
+		if output.Coords, err = reader.readCoordSet(output.Coords); err != nil {
+			return err
+		}
+
+		output.Ends = append(output.Ends, output.Len())
+	}
+
+	*m = output
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (m FlatMultiLineString) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	if err := checkEnds(m.FlatCoords, m.Ends); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, 0, size32bit+len(m.Ends)*(1+2*size32bit)+len(m.Coords)*size64bit)
+
+	output = appendUint32(output, uint32(len(m.Ends)), byteOrder)
+
+	start := 0
+
+	for _, end := range m.Ends {
+		output = appendHeader(output, GeometryTypeLineString, m.layout, byteOrder)
+		output = appendCoordSet(output, m.slice(start, end), byteOrder)
+		start = end
+	}
+
+	return output, nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (m FlatMultiLineString) SystemReferenceID() *SystemReferenceID {
+	return m.SRID
+}
+
+// MultiLineString converts to the map representation.
+func (m FlatMultiLineString) MultiLineString() MultiLineString {
+	output := MultiLineString{
+		SRID:        m.SRID,
+		LineStrings: make([]LineString, len(m.Ends)),
+	}
+
+	start := 0
+
+	for idx, end := range m.Ends {
+		output.LineStrings[idx].CoordinateSet = m.coordinateSet(start, end)
+		start = end
+	}
+
+	return output
+}
+
+// Flat converts to the flat representation.
+func (m MultiLineString) Flat() FlatMultiLineString {
+	output := FlatMultiLineString{
+		SRID:       m.SRID,
+		FlatCoords: NewFlatCoords(m.Layout(), nil),
+		Ends:       make([]int, 0, len(m.LineStrings)),
+	}
+
+	for _, line := range m.LineStrings {
+		for _, coordinate := range line.CoordinateSet {
+			output.Coords = appendCoordinate(output.Coords, output.layout, coordinate)
+		}
+
+		output.Ends = append(output.Ends, output.Len())
+	}
+
+	return output
+}
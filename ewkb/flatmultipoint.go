@@ -0,0 +1,107 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatMultiPoint is a MULTIPOINT in database, stored as FlatCoords (see MultiPoint).
+// Each vertex is a point; empty points are NaN vertices.
+type FlatMultiPoint struct {
+	SRID *SystemReferenceID
+	FlatCoords
+}
+
+// Type implements the Geometry interface.
+func (m FlatMultiPoint) Type() GeometryType {
+	return GeometryTypeMultiPoint
+}
+
+// IsEmpty checks if the MultiPoint has no point, or only empty points.
+func (m FlatMultiPoint) IsEmpty() bool {
+	for idx := 0; idx < m.Len(); idx++ {
+		if !m.Coord(idx).IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (m *FlatMultiPoint) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, m.Type())
+	if err != nil {
+		return err
+	}
+
+	size, err := reader.readPartCount(minNestedSize)
+	if err != nil {
+		return err
+	}
+
+	var coords []float64
+
+	for idx := uint32(0); idx < size; idx++ {
+		reader.record.state.at(int(idx))
+
+		if err := reader.readHeader(GeometryTypePoint); err != nil {
+			return err
+		}
+
+		if coords, err = reader.readCoords(coords, 1); err != nil {
+			return err
+		}
+	}
+
+	m.SRID = record.SRID
+	m.FlatCoords = NewFlatCoords(record.Layout, coords)
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (m FlatMultiPoint) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	output := make([]byte, 0, size32bit+m.Len()*(1+size32bit)+len(m.Coords)*size64bit)
+
+	output = appendUint32(output, uint32(m.Len()), byteOrder)
+
+	for idx := 0; idx < m.Len(); idx++ {
+		output = appendHeader(output, GeometryTypePoint, m.layout, byteOrder)
+		output = appendCoords(output, m.slice(idx, idx+1).Coords, byteOrder)
+	}
+
+	return output, nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (m FlatMultiPoint) SystemReferenceID() *SystemReferenceID {
+	return m.SRID
+}
+
+// MultiPoint converts to the map representation.
+func (m FlatMultiPoint) MultiPoint() MultiPoint {
+	output := MultiPoint{
+		SRID:   m.SRID,
+		Points: make([]Point, m.Len()),
+	}
+
+	for idx := range output.Points {
+		output.Points[idx].Coordinate = m.coordinate(idx)
+	}
+
+	return output
+}
+
+// Flat converts to the flat representation.
+func (m MultiPoint) Flat() FlatMultiPoint {
+	output := FlatMultiPoint{
+		SRID:       m.SRID,
+		FlatCoords: NewFlatCoords(m.Layout(), nil),
+	}
+
+	for _, pnt := range m.Points {
+		output.Coords = appendCoordinate(output.Coords, output.layout, pnt.Coordinate)
+	}
+
+	return output
+}
@@ -0,0 +1,155 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatMultiPolygon is a MULTIPOLYGON in database, stored as FlatCoords (see MultiPolygon).
+//
+// Endss are the ends of the rings of each polygon (see FlatPolygon). Ends are
+// vertex indexes in the whole FlatCoords.
+type FlatMultiPolygon struct {
+	SRID *SystemReferenceID
+	FlatCoords
+	Endss [][]int
+}
+
+// Type implements the Geometry interface.
+func (m FlatMultiPolygon) Type() GeometryType {
+	return GeometryTypeMultiPolygon
+}
+
+// IsEmpty checks if the MultiPolygon has no vertex.
+func (m FlatMultiPolygon) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Polygon gets the polygon at index idx. Its ends are relative to its own vertices.
+func (m FlatMultiPolygon) Polygon(idx int) FlatPolygon {
+	start := m.polygonStart(idx)
+	ends := make([]int, len(m.Endss[idx]))
+
+	for pos, end := range m.Endss[idx] {
+		ends[pos] = end - start
+	}
+
+	end := start
+	if len(ends) > 0 {
+		end = m.Endss[idx][len(ends)-1]
+	}
+
+	return FlatPolygon{
+		FlatCoords: m.slice(start, end),
+		Ends:       ends,
+	}
+}
+
+// polygonStart is the first vertex index of the polygon at index idx.
+func (m FlatMultiPolygon) polygonStart(idx int) int {
+	for pos := idx - 1; pos >= 0 && pos < len(m.Endss); pos-- {
+		if ends := m.Endss[pos]; len(ends) > 0 {
+			return ends[len(ends)-1]
+		}
+	}
+
+	return 0
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (m *FlatMultiPolygon) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, m.Type())
+	if err != nil {
+		return err
+	}
+
+	size, err := reader.readPartCount(minNestedSize)
+	if err != nil {
+		return err
+	}
+
+	output := FlatMultiPolygon{SRID: record.SRID, FlatCoords: NewFlatCoords(record.Layout, nil), Endss: [][]int{}}
+
+	for idx := uint32(0); idx < size; idx++ {
+		reader.record.state.at(int(idx))
+
+		if err := reader.readHeader(GeometryTypePolygon); err != nil {
+			return err
+		}
+
+		var ends []int
+
+		if output.Coords, ends, err = reader.readCoordGroup(output.Coords, []int{}); err != nil {
+			return err
+		}
+
+		output.Endss = append(output.Endss, ends)
+	}
+
+	*m = output
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (m FlatMultiPolygon) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	ends := []int{}
+	for _, polygonEnds := range m.Endss {
+		ends = append(ends, polygonEnds...)
+	}
+
+	if err := checkEnds(m.FlatCoords, ends); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, 0, size32bit+len(m.Endss)*(1+2*size32bit)+len(ends)*size32bit+len(m.Coords)*size64bit)
+
+	output = appendUint32(output, uint32(len(m.Endss)), byteOrder)
+
+	for idx := range m.Endss {
+		polygon := m.Polygon(idx)
+
+		output = appendHeader(output, GeometryTypePolygon, m.layout, byteOrder)
+		output = appendCoordGroup(output, polygon.FlatCoords, polygon.Ends, byteOrder)
+	}
+
+	return output, nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (m FlatMultiPolygon) SystemReferenceID() *SystemReferenceID {
+	return m.SRID
+}
+
+// MultiPolygon converts to the map representation.
+func (m FlatMultiPolygon) MultiPolygon() MultiPolygon {
+	output := MultiPolygon{
+		SRID:     m.SRID,
+		Polygons: make([]Polygon, len(m.Endss)),
+	}
+
+	for idx := range output.Polygons {
+		output.Polygons[idx] = m.Polygon(idx).Polygon()
+	}
+
+	return output
+}
+
+// Flat converts to the flat representation.
+func (m MultiPolygon) Flat() FlatMultiPolygon {
+	output := FlatMultiPolygon{
+		SRID:       m.SRID,
+		FlatCoords: NewFlatCoords(m.Layout(), nil),
+		Endss:      make([][]int, len(m.Polygons)),
+	}
+
+	for idx, polygon := range m.Polygons {
+		flat := FlatPolygon{FlatCoords: output.FlatCoords, Ends: make([]int, 0, len(polygon.CoordinateGroup))}
+
+		flat.appendGroup(polygon.CoordinateGroup)
+
+		output.FlatCoords = flat.FlatCoords
+		output.Endss[idx] = flat.Ends
+	}
+
+	return output
+}
@@ -0,0 +1,72 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatPoint is a POINT in database, stored as FlatCoords (see Point).
+type FlatPoint struct {
+	SRID *SystemReferenceID
+	FlatCoords
+}
+
+// Type implements the Geometry interface.
+func (p FlatPoint) Type() GeometryType {
+	return GeometryTypePoint
+}
+
+// IsEmpty checks if the point is POINT EMPTY.
+func (p FlatPoint) IsEmpty() bool {
+	return p.Len() == 0 || p.Coord(0).IsEmpty()
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (p *FlatPoint) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, p.Type())
+	if err != nil {
+		return err
+	}
+
+	coords, err := reader.readCoords(nil, 1)
+	if err != nil {
+		return err
+	}
+
+	p.SRID = record.SRID
+	p.FlatCoords = NewFlatCoords(record.Layout, coords)
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (p FlatPoint) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	if p.Len() == 0 {
+		return appendCoords(nil, EmptyCoord(p.layout).AppendValues(nil), byteOrder), nil
+	}
+
+	return appendCoords(make([]byte, 0, p.Stride()*size64bit), p.Coords[:p.Stride()], byteOrder), nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (p FlatPoint) SystemReferenceID() *SystemReferenceID {
+	return p.SRID
+}
+
+// Point converts to the map representation.
+func (p FlatPoint) Point() Point {
+	if p.Len() == 0 {
+		return Point{SRID: p.SRID, Coordinate: NewNullCoordinate(p.layout)}
+	}
+
+	return Point{SRID: p.SRID, Coordinate: p.coordinate(0)}
+}
+
+// Flat converts to the flat representation.
+func (p Point) Flat() FlatPoint {
+	layout := p.Layout()
+
+	return FlatPoint{
+		SRID:       p.SRID,
+		FlatCoords: NewFlatCoords(layout, appendCoordinate(nil, layout, p.Coordinate)),
+	}
+}
@@ -0,0 +1,110 @@
+package ewkb
+
+import (
+	"encoding/binary"
+)
+
+// FlatPolygon is a POLYGON in database, stored as FlatCoords (see Polygon).
+//
+// Ends are the end vertex index (excluded) of each ring: the first ring is
+// [0, Ends[0]), the second one [Ends[0], Ends[1]), and so on.
+type FlatPolygon struct {
+	SRID *SystemReferenceID
+	FlatCoords
+	Ends []int
+}
+
+// Type implements the Geometry interface.
+func (p FlatPolygon) Type() GeometryType {
+	return GeometryTypePolygon
+}
+
+// IsEmpty checks if the polygon has no vertex.
+func (p FlatPolygon) IsEmpty() bool {
+	return p.Len() == 0
+}
+
+// Ring gets the vertices of the ring at index idx.
+func (p FlatPolygon) Ring(idx int) FlatCoords {
+	start := 0
+	if idx > 0 {
+		start = p.Ends[idx-1]
+	}
+
+	return p.slice(start, p.Ends[idx])
+}
+
+// UnmarshalEWBK implements the Unmarshaler interface.
+func (p *FlatPolygon) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
+	reader, err := newFlatReader(record, p.Type())
+	if err != nil {
+		return err
+	}
+
+	coords, ends, err := reader.readCoordGroup(nil, []int{})
+	if err != nil {
+		return err
+	}
+
+	p.SRID = record.SRID
+	p.FlatCoords = NewFlatCoords(record.Layout, coords)
+	p.Ends = ends
+
+	return nil
+}
+
+// MarshalEWBK implements the Marshaler interface.
+func (p FlatPolygon) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	if err := checkEnds(p.FlatCoords, p.Ends); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, 0, size32bit*(1+len(p.Ends))+len(p.Coords)*size64bit)
+
+	return appendCoordGroup(output, p.FlatCoords, p.Ends, byteOrder), nil
+}
+
+// SystemReferenceID implements the Marshaler interface.
+func (p FlatPolygon) SystemReferenceID() *SystemReferenceID {
+	return p.SRID
+}
+
+// Polygon converts to the map representation.
+func (p FlatPolygon) Polygon() Polygon {
+	output := Polygon{
+		SRID:            p.SRID,
+		CoordinateGroup: make(CoordinateGroup, len(p.Ends)),
+	}
+
+	start := 0
+
+	for idx, end := range p.Ends {
+		output.CoordinateGroup[idx] = p.coordinateSet(start, end)
+		start = end
+	}
+
+	return output
+}
+
+// Flat converts to the flat representation.
+func (p Polygon) Flat() FlatPolygon {
+	output := FlatPolygon{
+		SRID:       p.SRID,
+		FlatCoords: NewFlatCoords(p.Layout(), nil),
+		Ends:       make([]int, 0, len(p.CoordinateGroup)),
+	}
+
+	output.appendGroup(p.CoordinateGroup)
+
+	return output
+}
+
+func (p *FlatPolygon) appendGroup(group CoordinateGroup) {
+	for _, set := range group {
+		for _, coordinate := range set {
+			p.Coords = appendCoordinate(p.Coords, p.layout, coordinate)
+		}
+
+		p.Ends = append(p.Ends, p.Len())
+	}
+}
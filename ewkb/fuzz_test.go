@@ -0,0 +1,78 @@
+package ewkb_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/landru29/gogis/internal/fuzzseeds"
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzGeometries are the decoded geometries.
+func fuzzGeometries() map[string]func() ewkb.Geometry {
+	return map[string]func() ewkb.Geometry{
+		"point":                func() ewkb.Geometry { return &ewkb.Point{} },
+		"linestring":           func() ewkb.Geometry { return &ewkb.LineString{} },
+		"polygon":              func() ewkb.Geometry { return &ewkb.Polygon{} },
+		"multipoint":           func() ewkb.Geometry { return &ewkb.MultiPoint{} },
+		"multilinestring":      func() ewkb.Geometry { return &ewkb.MultiLineString{} },
+		"multipolygon":         func() ewkb.Geometry { return &ewkb.MultiPolygon{} },
+		"triangle":             func() ewkb.Geometry { return &ewkb.Triangle{} },
+		"circularstring":       func() ewkb.Geometry { return &ewkb.CircularString{} },
+		"collection":           func() ewkb.Geometry { return ewkb.NewGeometryCollection() },
+		"flat point":           func() ewkb.Geometry { return &ewkb.FlatPoint{} },
+		"flat linestring":      func() ewkb.Geometry { return &ewkb.FlatLineString{} },
+		"flat polygon":         func() ewkb.Geometry { return &ewkb.FlatPolygon{} },
+		"flat multipoint":      func() ewkb.Geometry { return &ewkb.FlatMultiPoint{} },
+		"flat multilinestring": func() ewkb.Geometry { return &ewkb.FlatMultiLineString{} },
+		"flat multipolygon":    func() ewkb.Geometry { return &ewkb.FlatMultiPolygon{} },
+	}
+}
+
+func FuzzDecodeHeader(f *testing.F) {
+	fuzzseeds.Add(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = ewkb.DecodeHeader(bytes.NewReader(data))
+		_, _ = ewkb.Peek(hex.EncodeToString(data))
+		_ = ewkb.IsEWKB(data)
+	})
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	fuzzseeds.Add(f)
+
+	limits := ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 64})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value := hex.EncodeToString(data)
+
+		for name, newGeometry := range fuzzGeometries() {
+			geometry := newGeometry()
+
+			if err := ewkb.Unmarshal(geometry, value, limits); err != nil {
+				continue
+			}
+
+			encoded, err := ewkb.Marshal(geometry)
+			require.NoError(t, err, name)
+
+			decoded := newGeometry()
+			require.NoError(t, ewkb.Unmarshal(decoded, encoded, limits), name)
+
+			reencoded, err := ewkb.Marshal(decoded)
+			require.NoError(t, err, name)
+			require.Equal(t, string(encoded), string(reencoded), name)
+		}
+	})
+}
+
+func FuzzWalk(f *testing.F) {
+	fuzzseeds.Add(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_ = ewkb.Walk(bytes.NewReader(data), ewkb.VisitorFuncs{})
+	})
+}
@@ -1,5 +1,7 @@
 package ewkb
 
+import "fmt"
+
 // GeometryType is the type of the geometry (bits 0-61 of the bytes 1-4 of the header).
 type GeometryType uint8
 
@@ -50,8 +52,53 @@ const (
 	GeometryTypeTriangle GeometryType = 17
 )
 
+// String implements the fmt.Stringer interface.
+func (g GeometryType) String() string {
+	switch g {
+	case GeometryTypePoint:
+		return "Point"
+	case GeometryTypeLineString:
+		return "LineString"
+	case GeometryTypePolygon:
+		return "Polygon"
+	case GeometryTypeMultiPoint:
+		return "MultiPoint"
+	case GeometryTypeMultiLineString:
+		return "MultiLineString"
+	case GeometryTypeMultiPolygon:
+		return "MultiPolygon"
+	case GeometryTypeGeometryCollection:
+		return "GeometryCollection"
+	case GeometryTypeCircularString:
+		return "CircularString"
+	case GeometryTypeCompound:
+		return "CompoundCurve"
+	case GeometryTypeCurvePoly:
+		return "CurvePolygon"
+	case GeometryTypeMultiCurve:
+		return "MultiCurve"
+	case GeometryTypeMultiSurface:
+		return "MultiSurface"
+	case GeometryTypePolyhedralSurface:
+		return "PolyhedralSurface"
+	case GeometryTypeTin:
+		return "Tin"
+	case GeometryTypeTriangle:
+		return "Triangle"
+	default:
+		return fmt.Sprintf("GeometryType(%d)", uint8(g))
+	}
+}
+
 // Geometry is a geometrical shape.
 type Geometry interface {
 	Unmarshaler
 	Marshaler
 }
+
+// IsEmpty checks if the geometry is EMPTY. Geometries without an IsEmpty method are never empty.
+func IsEmpty(geometry Marshaler) bool {
+	emptier, ok := geometry.(interface{ IsEmpty() bool })
+
+	return ok && emptier.IsEmpty()
+}
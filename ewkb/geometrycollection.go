@@ -3,7 +3,6 @@ package ewkb
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 	"reflect"
 )
 
@@ -31,6 +30,11 @@ func (g GeometryCollection) pick(geoType GeometryType) (Geometry, error) { //nol
 
 			out, _ := newGeo.Interface().(Geometry)
 
+			if collection, ok := out.(*GeometryCollection); ok {
+				// Nested collections decode the same geometries.
+				collection.wellKnownGeometry = g.wellKnownGeometry
+			}
+
 			return out, nil
 		}
 	}
@@ -67,27 +71,42 @@ func (g GeometryCollection) Type() GeometryType {
 	return GeometryTypeGeometryCollection
 }
 
+// IsEmpty checks if the collection has no element, or only empty elements.
+func (g GeometryCollection) IsEmpty() bool {
+	for _, geo := range g.Collection {
+		if !IsEmpty(geo) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (g *GeometryCollection) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != g.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, g.Type())
+		return newDecodeError(ErrWrongGeometryType, g.Type(), record.Type)
 	}
 
 	g.SRID = record.SRID
 
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minNestedSize)
 	if err != nil {
 		return err
 	}
 
-	g.Collection = make([]Geometry, size)
+	g.Collection = make([]Geometry, 0, record.state.capacity(size))
 
-	for idx := range g.Collection {
-		dataSet, err := DecodeHeader(record.DataStream)
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
+
+		dataSet, err := record.decodeNestedHeader()
 		if err != nil {
 			return err
 		}
 
+		record.state.enter(dataSet.Type.String())
+
 		geometry, err := g.pick(dataSet.Type)
 		if err != nil {
 			return err
@@ -99,7 +118,13 @@ func (g *GeometryCollection) UnmarshalEWBK(record ExtendedWellKnownBytes) error
 			return err
 		}
 
-		g.Collection[idx] = geometry
+		if err := record.checkNestedLayout(dataSet, geometry); err != nil {
+			return err
+		}
+
+		record.state.leave()
+
+		g.Collection = append(g.Collection, geometry)
 	}
 
 	return nil
@@ -134,6 +159,12 @@ func (g GeometryCollection) SystemReferenceID() *SystemReferenceID {
 
 // Layout implements the Marshaler interface.
 func (g GeometryCollection) Layout() Layout {
+	for _, geo := range g.Collection {
+		if !IsEmpty(geo) {
+			return geo.Layout()
+		}
+	}
+
 	for _, geo := range g.Collection {
 		return geo.Layout()
 	}
@@ -45,6 +45,16 @@ func (l Layout) Size() uint32 {
 	return uint32(len(l.Format()))
 }
 
+// HasZ checks if the layout has a Z coordinate.
+func (l Layout) HasZ() bool {
+	return l&layoutXYZ != 0
+}
+
+// HasM checks if the layout has a M coordinate.
+func (l Layout) HasM() bool {
+	return l&layoutXYM != 0
+}
+
 // Uint32 convert layout in uint32.
 func (l Layout) Uint32() uint32 {
 	return uint32(l) << 30 //nolint: gomnd
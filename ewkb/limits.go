@@ -0,0 +1,172 @@
+package ewkb
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	// ErrTooManyVertices occurs when a geometry has more vertices than DecoderLimits.MaxVertices.
+	ErrTooManyVertices = Error("too many vertices")
+
+	// ErrTooManyParts occurs when a geometry has more parts than DecoderLimits.MaxParts.
+	ErrTooManyParts = Error("too many parts")
+
+	// ErrTooDeep occurs when geometries are nested deeper than DecoderLimits.MaxDepth.
+	ErrTooDeep = Error("geometry nested too deep")
+
+	// ErrTooLarge occurs when a geometry is larger than DecoderLimits.MaxBytes.
+	ErrTooLarge = Error("geometry too large")
+
+	// minPartSize is the smallest binary size of a part (the size of an empty set).
+	minPartSize = size32bit
+
+	// minNestedSize is the smallest binary size of a nested geometry (header and size).
+	minNestedSize = 1 + 2*size32bit
+
+	// maxPrealloc is the maximum number of items preallocated from an unchecked size.
+	maxPrealloc = 1024
+)
+
+// DecoderLimits are the resource limits of a decoder, to decode untrusted
+// geometries. Zero values mean no limit.
+//
+// Whatever the limits, when the size of the input is known (Unmarshal, or a
+// reader with a Len method such as bytes.Buffer), sizes read in the geometry are
+// checked against the remaining input before allocating.
+type DecoderLimits struct {
+	// MaxVertices is the maximum number of vertices of the whole geometry.
+	MaxVertices int
+
+	// MaxParts is the maximum number of parts (rings, lines, polygons, nested
+	// geometries) of the whole geometry.
+	MaxParts int
+
+	// MaxDepth is the maximum nesting depth; the root geometry has a depth of 0,
+	// the geometries of a collection have a depth of 1.
+	MaxDepth int
+
+	// MaxBytes is the maximum binary size read by the decoder.
+	MaxBytes int
+}
+
+// WithLimits sets the resource limits of the decoder.
+func WithLimits(limits DecoderLimits) func(*Decoder) {
+	return func(decoder *Decoder) {
+		decoder.limits = limits
+	}
+}
+
+// decodeState tracks the resources used while decoding a geometry.
+type decodeState struct {
+	limits   DecoderLimits
+	reader   *countingReader
+	size     int
+	strict   bool
+	vertices int
+	parts    int
+	path     []pathElement
+}
+
+func newDecodeState(limits DecoderLimits, reader *countingReader, size int) *decodeState {
+	return &decodeState{
+		limits: limits,
+		reader: reader,
+		size:   size,
+	}
+}
+
+// checkRemaining checks that count items fit in the remaining input.
+func (d *decodeState) checkRemaining(count uint32, itemSize int) error {
+	needed := int64(count) * int64(itemSize)
+
+	if d.limits.MaxBytes > 0 && needed > int64(d.limits.MaxBytes-d.reader.offset) {
+		return newDecodeError(
+			ErrTooLarge,
+			fmt.Sprintf("at most %d bytes", d.limits.MaxBytes),
+			fmt.Sprintf("%d items of %d bytes", count, itemSize),
+		)
+	}
+
+	if d.size >= 0 && needed > int64(d.size-d.reader.offset) {
+		return newDecodeError(
+			io.ErrUnexpectedEOF,
+			fmt.Sprintf("%d items of %d bytes", count, itemSize),
+			fmt.Sprintf("%d bytes remaining", d.size-d.reader.offset),
+		)
+	}
+
+	return nil
+}
+
+// addVertices checks and counts vertices before allocating them.
+func (d *decodeState) addVertices(count uint32, layout Layout) error {
+	if d == nil {
+		return nil
+	}
+
+	d.vertices += int(count)
+
+	if d.limits.MaxVertices > 0 && d.vertices > d.limits.MaxVertices {
+		return newDecodeError(ErrTooManyVertices, fmt.Sprintf("at most %d", d.limits.MaxVertices), d.vertices)
+	}
+
+	return d.checkRemaining(count, int(layout.Size())*size64bit)
+}
+
+// addParts checks and counts parts before allocating them.
+func (d *decodeState) addParts(count uint32, minSize int) error {
+	if d == nil {
+		return nil
+	}
+
+	d.parts += int(count)
+
+	if d.limits.MaxParts > 0 && d.parts > d.limits.MaxParts {
+		return newDecodeError(ErrTooManyParts, fmt.Sprintf("at most %d", d.limits.MaxParts), d.parts)
+	}
+
+	return d.checkRemaining(count, minSize)
+}
+
+// checkDepth checks the depth of a nested geometry.
+func (d *decodeState) checkDepth(depth int) error {
+	if d == nil || d.limits.MaxDepth <= 0 || depth <= d.limits.MaxDepth {
+		return nil
+	}
+
+	return newDecodeError(ErrTooDeep, fmt.Sprintf("at most %d", d.limits.MaxDepth), depth)
+}
+
+// countingReader counts the bytes read, and stops at the maximum size.
+type countingReader struct {
+	reader   io.Reader
+	offset   int
+	maxBytes int
+}
+
+func (c *countingReader) Read(data []byte) (int, error) {
+	if c.maxBytes > 0 && c.offset+len(data) > c.maxBytes {
+		if c.offset >= c.maxBytes {
+			return 0, fmt.Errorf("%w: max %d bytes", ErrTooLarge, c.maxBytes)
+		}
+
+		data = data[:c.maxBytes-c.offset]
+	}
+
+	count, err := c.reader.Read(data)
+	c.offset += count
+
+	return count, err
+}
+
+// capacity returns the number of items to preallocate for count items: when the
+// size of the input is unknown, count was not checked, and only a part of it is
+// preallocated.
+func (d *decodeState) capacity(count uint32) int {
+	if count <= maxPrealloc || (d != nil && (d.size >= 0 || d.limits.MaxBytes > 0)) {
+		return int(count)
+	}
+
+	return maxPrealloc
+}
@@ -0,0 +1,124 @@
+package ewkb_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsizedReader hides the Len method of the reader.
+type unsizedReader struct {
+	io.Reader
+}
+
+func TestDecoderLimits(t *testing.T) {
+	t.Run("hostile sizes", func(t *testing.T) {
+		for name, fixture := range map[string]struct {
+			geometry ewkb.Unmarshaler
+			data     string
+		}{
+			"linestring":        {geometry: &ewkb.LineString{}, data: "0102000000FFFFFFFF"},
+			"polygon":           {geometry: &ewkb.Polygon{}, data: "0103000000FFFFFFFF"},
+			"multipoint":        {geometry: &ewkb.MultiPoint{}, data: "0104000000FFFFFFFF"},
+			"multilinestring":   {geometry: &ewkb.MultiLineString{}, data: "0105000000FFFFFFFF"},
+			"multipolygon":      {geometry: &ewkb.MultiPolygon{}, data: "0106000000FFFFFFFF"},
+			"collection":        {geometry: ewkb.NewGeometryCollection(), data: "0107000000FFFFFFFF"},
+			"triangle":          {geometry: &ewkb.Triangle{}, data: "0111000000FFFFFFFF"},
+			"ring":              {geometry: &ewkb.Polygon{}, data: "010300000001000000FFFFFFFF"},
+			"flat linestring":   {geometry: &ewkb.FlatLineString{}, data: "0102000000FFFFFFFF"},
+			"flat multipolygon": {geometry: &ewkb.FlatMultiPolygon{}, data: "0106000000FFFFFFFF"},
+		} {
+			fixture := fixture
+
+			t.Run(name, func(t *testing.T) {
+				err := ewkb.Unmarshal(fixture.geometry, fixture.data)
+				assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+			})
+		}
+	})
+
+	multiPolygon, err := ewkb.Marshal(largeMultiPolygon(3, 10))
+	require.NoError(t, err)
+
+	t.Run("no limits", func(t *testing.T) {
+		require.NoError(t, ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon))
+		require.NoError(t, ewkb.NewDecoder(unsizedReader{bytes.NewReader(multiPolygon)}).Decode(&ewkb.MultiPolygon{}))
+	})
+
+	t.Run("max vertices", func(t *testing.T) {
+		limits := ewkb.WithLimits(ewkb.DecoderLimits{MaxVertices: 29})
+
+		err := ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon, limits)
+		assert.ErrorIs(t, err, ewkb.ErrTooManyVertices)
+
+		err = ewkb.Unmarshal(&ewkb.FlatMultiPolygon{}, multiPolygon, limits)
+		assert.ErrorIs(t, err, ewkb.ErrTooManyVertices)
+
+		err = ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon, ewkb.WithLimits(ewkb.DecoderLimits{MaxVertices: 30}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("max parts", func(t *testing.T) {
+		// 3 polygons and 3 rings.
+		limits := ewkb.WithLimits(ewkb.DecoderLimits{MaxParts: 5})
+
+		err := ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon, limits)
+		assert.ErrorIs(t, err, ewkb.ErrTooManyParts)
+
+		err = ewkb.Unmarshal(&ewkb.FlatMultiPolygon{}, multiPolygon, limits)
+		assert.ErrorIs(t, err, ewkb.ErrTooManyParts)
+
+		err = ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon, ewkb.WithLimits(ewkb.DecoderLimits{MaxParts: 6}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		// GEOMETRYCOLLECTION(GEOMETRYCOLLECTION(POINT(1 2))).
+		nested, err := ewkb.Marshal(ewkb.GeometryCollection{
+			Collection: []ewkb.Geometry{
+				&ewkb.GeometryCollection{
+					Collection: []ewkb.Geometry{
+						&ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		err = ewkb.Unmarshal(ewkb.NewGeometryCollection(), nested, ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 1}))
+		assert.ErrorIs(t, err, ewkb.ErrTooDeep)
+
+		err = ewkb.Unmarshal(ewkb.NewGeometryCollection(), nested, ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 2}))
+		assert.NoError(t, err)
+
+		err = ewkb.Unmarshal(&ewkb.MultiPolygon{}, multiPolygon, ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 1}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		size := len(multiPolygon) / 2
+
+		err := ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader(multiPolygon)},
+			ewkb.WithLimits(ewkb.DecoderLimits{MaxBytes: size - 1}),
+		).Decode(&ewkb.MultiPolygon{})
+		assert.ErrorIs(t, err, ewkb.ErrTooLarge)
+
+		err = ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader(multiPolygon)},
+			ewkb.WithLimits(ewkb.DecoderLimits{MaxBytes: size}),
+		).Decode(&ewkb.MultiPolygon{})
+		assert.NoError(t, err)
+
+		// Hostile size in a stream of unknown size.
+		err = ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader([]byte("0102000000FFFFFFFF"))},
+			ewkb.WithLimits(ewkb.DecoderLimits{MaxBytes: 1024}),
+		).Decode(&ewkb.LineString{})
+		assert.ErrorIs(t, err, ewkb.ErrTooLarge)
+	})
+}
@@ -2,7 +2,6 @@ package ewkb
 
 import (
 	"encoding/binary"
-	"fmt"
 )
 
 // LineString is a set of lines.
@@ -28,7 +27,7 @@ func (l LineString) Type() GeometryType {
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (l *LineString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != l.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, l.Type())
+		return newDecodeError(ErrWrongGeometryType, l.Type(), record.Type)
 	}
 
 	l.SRID = record.SRID
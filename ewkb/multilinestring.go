@@ -3,7 +3,6 @@ package ewkb
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 )
 
 // MultiLineString is a MULTILINESTRING in database.
@@ -20,28 +19,41 @@ func (m MultiLineString) Type() GeometryType {
 	return GeometryTypeMultiLineString
 }
 
+// IsEmpty checks if the MultiLineString has no element, or only empty elements.
+func (m MultiLineString) IsEmpty() bool {
+	for _, line := range m.LineStrings {
+		if !line.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (m *MultiLineString) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != m.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, m.Type())
+		return newDecodeError(ErrWrongGeometryType, m.Type(), record.Type)
 	}
 
 	m.SRID = record.SRID
 
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minNestedSize)
 	if err != nil {
 		return err
 	}
 
-	m.LineStrings = make([]LineString, size)
+	m.LineStrings = make([]LineString, 0, record.state.capacity(size))
+
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
 
-	for idx := range m.LineStrings {
 		lineStr := &LineString{}
-		if err := (&Decoder{reader: record.DataStream}).Decode(lineStr); err != nil {
+		if err := record.decodeNested(lineStr); err != nil {
 			return err
 		}
 
-		m.LineStrings[idx] = *lineStr
+		m.LineStrings = append(m.LineStrings, *lineStr)
 	}
 
 	return nil
@@ -76,8 +88,11 @@ func (m MultiLineString) SystemReferenceID() *SystemReferenceID {
 
 // Layout implements the Marshaler interface.
 func (m MultiLineString) Layout() Layout {
-	if len(m.LineStrings) > 0 {
-		return m.LineStrings[0].Layout()
+	// Empty lines have no layout.
+	for _, line := range m.LineStrings {
+		if !line.IsEmpty() {
+			return line.Layout()
+		}
 	}
 
 	return layoutXY
@@ -3,7 +3,6 @@ package ewkb
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 )
 
 // MultiPoint is a MULTIPOINT in database.
@@ -19,28 +18,41 @@ func (m MultiPoint) Type() GeometryType {
 	return GeometryTypeMultiPoint
 }
 
+// IsEmpty checks if the MultiPoint has no element, or only empty elements.
+func (m MultiPoint) IsEmpty() bool {
+	for _, pnt := range m.Points {
+		if !pnt.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (m *MultiPoint) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != m.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, m.Type())
+		return newDecodeError(ErrWrongGeometryType, m.Type(), record.Type)
 	}
 
 	m.SRID = record.SRID
 
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minNestedSize)
 	if err != nil {
 		return err
 	}
 
-	m.Points = make([]Point, size)
+	m.Points = make([]Point, 0, record.state.capacity(size))
+
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
 
-	for idx := range m.Points {
 		pnt := &Point{}
-		if err := (&Decoder{reader: record.DataStream}).Decode(pnt); err != nil {
+		if err := record.decodeNested(pnt); err != nil {
 			return err
 		}
 
-		m.Points[idx] = *pnt
+		m.Points = append(m.Points, *pnt)
 	}
 
 	return nil
@@ -75,8 +87,11 @@ func (m MultiPoint) SystemReferenceID() *SystemReferenceID {
 
 // Layout implements the Marshaler interface.
 func (m MultiPoint) Layout() Layout {
-	if len(m.Points) > 0 {
-		return m.Points[0].Layout()
+	// Points without coordinates have no layout.
+	for _, pnt := range m.Points {
+		if len(pnt.Coordinate) > 0 {
+			return pnt.Layout()
+		}
 	}
 
 	return layoutXY
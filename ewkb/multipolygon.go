@@ -3,7 +3,6 @@ package ewkb
 import (
 	"bytes"
 	"encoding/binary"
-	"fmt"
 )
 
 // MultiPolygon is a MULTILINESTRING in database.
@@ -20,28 +19,41 @@ func (m MultiPolygon) Type() GeometryType {
 	return GeometryTypeMultiPolygon
 }
 
+// IsEmpty checks if the MultiPolygon has no element, or only empty elements.
+func (m MultiPolygon) IsEmpty() bool {
+	for _, poly := range m.Polygons {
+		if !poly.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (m *MultiPolygon) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != m.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, m.Type())
+		return newDecodeError(ErrWrongGeometryType, m.Type(), record.Type)
 	}
 
 	m.SRID = record.SRID
 
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minNestedSize)
 	if err != nil {
 		return err
 	}
 
-	m.Polygons = make([]Polygon, size)
+	m.Polygons = make([]Polygon, 0, record.state.capacity(size))
+
+	for idx := uint32(0); idx < size; idx++ {
+		record.state.at(int(idx))
 
-	for idx := range m.Polygons {
 		polygon := &Polygon{}
-		if err := (&Decoder{reader: record.DataStream}).Decode(polygon); err != nil {
+		if err := record.decodeNested(polygon); err != nil {
 			return err
 		}
 
-		m.Polygons[idx] = *polygon
+		m.Polygons = append(m.Polygons, *polygon)
 	}
 
 	return nil
@@ -76,8 +88,11 @@ func (m MultiPolygon) SystemReferenceID() *SystemReferenceID {
 
 // Layout implements the Marshaler interface.
 func (m MultiPolygon) Layout() Layout {
-	if len(m.Polygons) > 0 {
-		return m.Polygons[0].Layout()
+	// Empty polygons have no layout.
+	for _, poly := range m.Polygons {
+		if !poly.IsEmpty() {
+			return poly.Layout()
+		}
 	}
 
 	return layoutXY
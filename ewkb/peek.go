@@ -0,0 +1,46 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+)
+
+// Summary is the header of an EWKB geometry, with its size.
+type Summary struct {
+	ExtendedWellKnownBytesHeader
+
+	// Size is the size of the binary EWKB, in bytes.
+	Size int
+}
+
+// Peek reads the header of a hexadecimal EWKB (string or []byte), as scanned from
+// database, without decoding the coordinates.
+func Peek(value interface{}) (*Summary, error) {
+	if strData, ok := value.(string); ok {
+		return Peek([]byte(strData))
+	}
+
+	dataByte, ok := value.([]byte)
+	if !ok {
+		return nil, ErrIncompatibleFormat
+	}
+
+	if len(dataByte)%2 != 0 {
+		return nil, fmt.Errorf("%w: odd hexadecimal length", ErrIncompatibleFormat)
+	}
+
+	record, err := DecodeHeader(hex.NewDecoder(bytes.NewReader(dataByte)))
+	if err != nil {
+		return nil, err
+	}
+
+	if record.IsNil {
+		return nil, fmt.Errorf("%w: no geometry", ErrIncompatibleFormat)
+	}
+
+	return &Summary{
+		ExtendedWellKnownBytesHeader: record.ExtendedWellKnownBytesHeader,
+		Size:                         hex.DecodedLen(len(dataByte)),
+	}, nil
+}
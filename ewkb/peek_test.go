@@ -0,0 +1,55 @@
+package ewkb_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeek(t *testing.T) {
+	srid := ewkb.SystemReferenceWGS84
+
+	t.Run("with SRID", func(t *testing.T) {
+		// SELECT 'SRID=4326;POINT ZM(-71.060316 48.432044 10 30)'::geometry.
+		summary, err := ewkb.Peek("01010000E0E61000003CDBA337DCC351C06D37C1374D37484000000000000024400000000000003E40")
+		require.NoError(t, err)
+
+		assert.Equal(t, &ewkb.Summary{
+			ExtendedWellKnownBytesHeader: ewkb.ExtendedWellKnownBytesHeader{
+				SRID:      &srid,
+				ByteOrder: binary.LittleEndian,
+				Type:      ewkb.GeometryTypePoint,
+				Layout:    ewkb.LayoutWith(true, true),
+			},
+			Size: 41,
+		}, summary)
+	})
+
+	t.Run("big endian", func(t *testing.T) {
+		// LINESTRING EMPTY in big endian.
+		summary, err := ewkb.Peek([]byte("000000000200000000"))
+		require.NoError(t, err)
+
+		assert.Equal(t, binary.BigEndian, summary.ByteOrder)
+		assert.Equal(t, ewkb.GeometryTypeLineString, summary.Type)
+		assert.Nil(t, summary.SRID)
+		assert.Equal(t, 9, summary.Size)
+	})
+
+	t.Run("wrong data", func(t *testing.T) {
+		_, err := ewkb.Peek("")
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+
+		_, err = ewkb.Peek("010")
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+
+		_, err = ewkb.Peek(42)
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+
+		_, err = ewkb.Peek("0201000000")
+		assert.ErrorIs(t, err, ewkb.ErrWrongByteOrder)
+	})
+}
@@ -2,7 +2,6 @@ package ewkb
 
 import (
 	"encoding/binary"
-	"fmt"
 )
 
 // Point is a POINT in database.
@@ -21,11 +20,15 @@ func (p Point) Type() GeometryType {
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (p *Point) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != p.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, p.Type())
+		return newDecodeError(ErrWrongGeometryType, p.Type(), record.Type)
 	}
 
 	p.SRID = record.SRID
 
+	if err := record.state.addVertices(1, record.Layout); err != nil {
+		return err
+	}
+
 	if err := (&(p.Coordinate)).UnmarshalEWBK(record); err != nil {
 		return err
 	}
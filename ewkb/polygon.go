@@ -2,7 +2,6 @@ package ewkb
 
 import (
 	"encoding/binary"
-	"fmt"
 )
 
 // Polygon is a POLYGON in database.
@@ -23,7 +22,7 @@ func (p Polygon) Type() GeometryType {
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (p *Polygon) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != p.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, p.Type())
+		return newDecodeError(ErrWrongGeometryType, p.Type(), record.Type)
 	}
 
 	p.SRID = record.SRID
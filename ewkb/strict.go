@@ -0,0 +1,67 @@
+package ewkb
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	// ErrTrailingData occurs in strict mode when data remains after the geometry.
+	ErrTrailingData = Error("trailing data")
+
+	// ErrMixedLayout occurs in strict mode when a nested geometry does not have
+	// the layout of its parent.
+	ErrMixedLayout = Error("mixed layouts")
+)
+
+// WithStrict enables the strict mode of the decoder: the input must contain
+// exactly one geometry, nested geometries must have the layout of their parent
+// (unless empty), and empty or truncated inputs return io.ErrUnexpectedEOF with
+// the offset.
+func WithStrict() func(*Decoder) {
+	return func(decoder *Decoder) {
+		decoder.strict = true
+	}
+}
+
+// wrapEOF reports end of file errors as io.ErrUnexpectedEOF in strict mode.
+func (d *decodeState) wrapEOF(err error) error {
+	if !d.strict || !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return io.ErrUnexpectedEOF
+}
+
+// checkTrailing checks in strict mode that the input is exhausted.
+func (d *decodeState) checkTrailing() error {
+	if !d.strict {
+		return nil
+	}
+
+	// The maximum size does not apply, the input is only checked.
+	_, err := io.ReadFull(d.reader.reader, make([]byte, size8bit))
+
+	switch {
+	case err == nil:
+		return d.locate(ErrTrailingData)
+	case err == io.EOF:
+		return nil
+	default:
+		return d.locate(d.wrapEOF(err))
+	}
+}
+
+// checkNestedLayout checks in strict mode that a nested geometry has the layout
+// of its parent.
+func (e ExtendedWellKnownBytes) checkNestedLayout(nested *ExtendedWellKnownBytes, geometry interface{}) error {
+	if e.state == nil || !e.state.strict || nested.Layout == e.Layout {
+		return nil
+	}
+
+	if marshaler, ok := geometry.(Marshaler); ok && IsEmpty(marshaler) {
+		return nil
+	}
+
+	return newDecodeError(ErrMixedLayout, e.Layout.Format(), nested.Layout.Format())
+}
@@ -0,0 +1,104 @@
+package ewkb_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	const (
+		// POINT(1 2).
+		point = "0101000000000000000000F03F0000000000000040"
+
+		// LINESTRING with 2 vertices, and only 3 values.
+		truncatedLineString = "010200000002000000000000000000F03F00000000000000400000000000000840"
+
+		// MULTIPOINT containing a POINT Z.
+		mixedMultiPoint = "0104000000010000000101000080000000000000F03F00000000000000400000000000000840"
+
+		// MULTIPOINT Z containing a POINT EMPTY.
+		emptyMultiPoint = "0104000080020000000101000080000000000000F03F00000000000000400000000000000840" +
+			"0101000000000000000000F87F000000000000F87F"
+	)
+
+	strict := ewkb.WithStrict()
+
+	t.Run("valid", func(t *testing.T) {
+		require.NoError(t, ewkb.Unmarshal(&ewkb.Point{}, point, strict))
+		require.NoError(t, ewkb.Unmarshal(&ewkb.MultiPoint{}, emptyMultiPoint, strict))
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		require.NoError(t, ewkb.Unmarshal(&ewkb.Point{}, point+"00"))
+
+		err := ewkb.Unmarshal(&ewkb.Point{}, point+"00", strict)
+		assert.ErrorIs(t, err, ewkb.ErrTrailingData)
+		assert.Contains(t, err.Error(), "offset 21")
+
+		err = ewkb.Unmarshal(&ewkb.Point{}, point+"0", strict)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		err = ewkb.Unmarshal(&ewkb.Point{}, point+point, strict)
+		assert.ErrorIs(t, err, ewkb.ErrTrailingData)
+	})
+
+	t.Run("trailing data after max bytes", func(t *testing.T) {
+		err := ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader([]byte(point + "00"))},
+			strict,
+			ewkb.WithLimits(ewkb.DecoderLimits{MaxBytes: 21}),
+		).Decode(&ewkb.Point{})
+		assert.ErrorIs(t, err, ewkb.ErrTrailingData)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		err := ewkb.Unmarshal(&ewkb.LineString{}, truncatedLineString, strict)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.EqualError(t, err, "unexpected EOF: found 24 bytes remaining, expected 2 items of 16 bytes at offset 9 in LineString")
+
+		err = ewkb.NewDecoder(
+			unsizedReader{bytes.NewReader([]byte(truncatedLineString))},
+			strict,
+		).Decode(&ewkb.LineString{})
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.EqualError(t, err, "unexpected EOF at offset 33 in LineString.Vertex[1]")
+
+		err = ewkb.Unmarshal(&ewkb.MultiPoint{}, "010400000002000000"+point, strict)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		err := ewkb.Unmarshal(&ewkb.Point{}, "", strict)
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		decodeErr := &ewkb.DecodeError{}
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, 0, decodeErr.Offset)
+
+		err = ewkb.NewDecoder(unsizedReader{bytes.NewReader(nil)}, strict).Decode(&ewkb.Point{})
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("mixed layouts", func(t *testing.T) {
+		require.NoError(t, ewkb.Unmarshal(&ewkb.MultiPoint{}, mixedMultiPoint))
+
+		err := ewkb.Unmarshal(&ewkb.MultiPoint{}, mixedMultiPoint, strict)
+		assert.ErrorIs(t, err, ewkb.ErrMixedLayout)
+
+		collection, err := ewkb.Marshal(ewkb.GeometryCollection{
+			Collection: []ewkb.Geometry{
+				&ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+				&ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2, 'z': 3}},
+			},
+		})
+		require.NoError(t, err)
+
+		err = ewkb.Unmarshal(ewkb.NewGeometryCollection(), collection, strict)
+		assert.ErrorIs(t, err, ewkb.ErrMixedLayout)
+	})
+}
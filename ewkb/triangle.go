@@ -2,7 +2,6 @@ package ewkb
 
 import (
 	"encoding/binary"
-	"fmt"
 )
 
 const (
@@ -29,16 +28,23 @@ func (t Triangle) Type() GeometryType {
 // UnmarshalEWBK implements the Unmarshaler interface.
 func (t *Triangle) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 	if record.Type != t.Type() {
-		return fmt.Errorf("%w: found %d, expected %d", ErrWrongGeometryType, record.Type, t.Type())
+		return newDecodeError(ErrWrongGeometryType, t.Type(), record.Type)
 	}
 
 	t.SRID = record.SRID
 
-	size, err := record.ReadUint32()
+	size, err := record.readPartCount(minPartSize)
 	if err != nil {
 		return err
 	}
 
+	if size == 0 {
+		// TRIANGLE EMPTY.
+		t.CoordinateSet = CoordinateSet{}
+
+		return nil
+	}
+
 	if size != triangleCoordinateGroupSize {
 		return ErrTriangleWrongSize
 	}
@@ -58,6 +64,11 @@ func (t *Triangle) UnmarshalEWBK(record ExtendedWellKnownBytes) error {
 func (t Triangle) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
 	output := []byte{}
 
+	if t.IsEmpty() {
+		// TRIANGLE EMPTY has no ring.
+		return make([]byte, size32bit), nil
+	}
+
 	if len(t.CoordinateSet) != triangleVerticesCount {
 		return nil, ErrTriangleWrongSize
 	}
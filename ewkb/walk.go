@@ -0,0 +1,338 @@
+package ewkb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Visitor receives the events of Walk.
+//
+// Geometries are reported between BeginGeometry and EndGeometry; nested
+// geometries (of multi geometries and collections) are reported inside their
+// parent. Coordinate is called for each vertex; z and m are NaN when the layout
+// does not have them. POINT EMPTY has no Coordinate event.
+//
+// Walk stops at the first error returned by the visitor.
+type Visitor interface {
+	BeginGeometry(geometryType GeometryType, layout Layout, srid *SystemReferenceID) error
+	Coordinate(x float64, y float64, z float64, m float64) error
+	EndGeometry(geometryType GeometryType) error
+}
+
+// RingVisitor is an optional extension of Visitor, to get the boundaries of the
+// sets of vertices (rings of polygons and triangles, vertices of lines).
+type RingVisitor interface {
+	Visitor
+	BeginRing(size uint32) error
+	EndRing() error
+}
+
+// VisitorFuncs is a Visitor built with functions. Nil functions are ignored.
+//
+//	count := 0
+//	err := ewkb.WalkHex(data, ewkb.VisitorFuncs{
+//		CoordinateFunc: func(x, y, z, m float64) error {
+//			count++
+//
+//			return nil
+//		},
+//	})
+type VisitorFuncs struct {
+	BeginGeometryFunc func(geometryType GeometryType, layout Layout, srid *SystemReferenceID) error
+	CoordinateFunc    func(x float64, y float64, z float64, m float64) error
+	EndGeometryFunc   func(geometryType GeometryType) error
+}
+
+// BeginGeometry implements the Visitor interface.
+func (v VisitorFuncs) BeginGeometry(geometryType GeometryType, layout Layout, srid *SystemReferenceID) error {
+	if v.BeginGeometryFunc == nil {
+		return nil
+	}
+
+	return v.BeginGeometryFunc(geometryType, layout, srid)
+}
+
+// Coordinate implements the Visitor interface.
+func (v VisitorFuncs) Coordinate(x float64, y float64, z float64, m float64) error {
+	if v.CoordinateFunc == nil {
+		return nil
+	}
+
+	return v.CoordinateFunc(x, y, z, m)
+}
+
+// EndGeometry implements the Visitor interface.
+func (v VisitorFuncs) EndGeometry(geometryType GeometryType) error {
+	if v.EndGeometryFunc == nil {
+		return nil
+	}
+
+	return v.EndGeometryFunc(geometryType)
+}
+
+// Walk reads a binary EWKB geometry from the reader, and reports it to the visitor
+// without building any geometry. It does not allocate per vertex.
+//
+// All the geometry types are supported, including curves and surfaces, which are
+// reported as collections of their nested geometries.
+//
+// Of the decoder options, the nesting depth of WithLimits (DecoderLimits.MaxDepth)
+// applies: a deeper geometry fails with a DecodeError (ErrTooDeep).
+func Walk(reader io.Reader, visitor Visitor, opts ...func(*Decoder)) error {
+	options := Decoder{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	walk := walker{
+		reader:   reader,
+		visitor:  visitor,
+		maxDepth: options.limits.MaxDepth,
+	}
+
+	walk.ringVisitor, _ = visitor.(RingVisitor)
+
+	return walk.geometry(0)
+}
+
+// WalkHex walks a hexadecimal EWKB (string or []byte), as scanned from database.
+func WalkHex(value interface{}, visitor Visitor, opts ...func(*Decoder)) error {
+	if strData, ok := value.(string); ok {
+		return WalkHex([]byte(strData), visitor, opts...)
+	}
+
+	dataByte, ok := value.([]byte)
+	if !ok {
+		return ErrIncompatibleFormat
+	}
+
+	return Walk(hex.NewDecoder(bytes.NewReader(dataByte)), visitor, opts...)
+}
+
+type walker struct {
+	reader      io.Reader
+	visitor     Visitor
+	ringVisitor RingVisitor
+	maxDepth    int
+	buffer      [flatChunkSize * size64bit]byte
+
+	// offset is the number of bytes read.
+	offset int
+}
+
+func (w *walker) read(size int) ([]byte, error) {
+	data := w.buffer[:size]
+
+	if _, err := io.ReadFull(w.reader, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	w.offset += size
+
+	return data, nil
+}
+
+func (w *walker) readUint32(byteOrder binary.ByteOrder) (uint32, error) {
+	data, err := w.read(size32bit)
+	if err != nil {
+		return 0, err
+	}
+
+	return byteOrder.Uint32(data), nil
+}
+
+// geometry walks a geometry at a nesting depth, from its header.
+func (w *walker) geometry(depth int) error {
+	if w.maxDepth > 0 && depth > w.maxDepth {
+		err := newDecodeError(ErrTooDeep, fmt.Sprintf("at most %d", w.maxDepth), depth)
+		err.Offset, err.located = w.offset, true
+
+		return err
+	}
+
+	data, err := w.read(1 + size32bit)
+	if err != nil {
+		if depth == 0 && errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("%w: no geometry", ErrIncompatibleFormat)
+		}
+
+		return err
+	}
+
+	var byteOrder binary.ByteOrder
+
+	switch data[0] {
+	case bigEndian:
+		byteOrder = binary.BigEndian
+	case littleEndian:
+		byteOrder = binary.LittleEndian
+	default:
+		return ErrWrongByteOrder
+	}
+
+	header := byteOrder.Uint32(data[1:])
+	layout := Layout((header & (ewkbZ | ewkbM)) >> 30) //nolint: gomnd
+	geometryType := GeometryType(header &^ (ewkbZ | ewkbM | ewkbSRID))
+
+	var srid *SystemReferenceID
+
+	if header&ewkbSRID != 0 {
+		value, err := w.readUint32(byteOrder)
+		if err != nil {
+			return err
+		}
+
+		srid = (*SystemReferenceID)(&value)
+	}
+
+	if err := w.visitor.BeginGeometry(geometryType, layout, srid); err != nil {
+		return err
+	}
+
+	if err := w.body(geometryType, layout, byteOrder, depth); err != nil {
+		return err
+	}
+
+	return w.visitor.EndGeometry(geometryType)
+}
+
+func (w *walker) body(geometryType GeometryType, layout Layout, byteOrder binary.ByteOrder, depth int) error {
+	switch geometryType { //nolint: exhaustive
+	case GeometryTypePoint:
+		return w.coordinates(1, layout, byteOrder)
+	case GeometryTypeLineString, GeometryTypeCircularString:
+		return w.set(layout, byteOrder)
+	case GeometryTypePolygon, GeometryTypeTriangle:
+		size, err := w.readUint32(byteOrder)
+		if err != nil {
+			return err
+		}
+
+		for idx := uint32(0); idx < size; idx++ {
+			if err := w.set(layout, byteOrder); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case GeometryTypeMultiPoint,
+		GeometryTypeMultiLineString,
+		GeometryTypeMultiPolygon,
+		GeometryTypeGeometryCollection,
+		GeometryTypeCompound,
+		GeometryTypeCurvePoly,
+		GeometryTypeMultiCurve,
+		GeometryTypeMultiSurface,
+		GeometryTypePolyhedralSurface,
+		GeometryTypeTin:
+		size, err := w.readUint32(byteOrder)
+		if err != nil {
+			return err
+		}
+
+		for idx := uint32(0); idx < size; idx++ {
+			if err := w.geometry(depth + 1); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: unsupported type %d", ErrWrongGeometryType, geometryType)
+}
+
+// set walks a set of vertices (size + vertices).
+func (w *walker) set(layout Layout, byteOrder binary.ByteOrder) error {
+	size, err := w.readUint32(byteOrder)
+	if err != nil {
+		return err
+	}
+
+	if w.ringVisitor != nil {
+		if err := w.ringVisitor.BeginRing(size); err != nil {
+			return err
+		}
+	}
+
+	if err := w.coordinates(size, layout, byteOrder); err != nil {
+		return err
+	}
+
+	if w.ringVisitor != nil {
+		return w.ringVisitor.EndRing()
+	}
+
+	return nil
+}
+
+// coordinates reads count vertices by chunks, and reports them.
+func (w *walker) coordinates(count uint32, layout Layout, byteOrder binary.ByteOrder) error {
+	stride := int(layout.Size())
+	perChunk := flatChunkSize / stride
+	remaining := int(count)
+
+	for remaining > 0 {
+		chunk := remaining
+		if chunk > perChunk {
+			chunk = perChunk
+		}
+
+		data, err := w.read(chunk * stride * size64bit)
+		if err != nil {
+			return err
+		}
+
+		for vertex := 0; vertex < chunk; vertex++ {
+			if err := w.coordinate(data[vertex*stride*size64bit:], layout, byteOrder); err != nil {
+				return err
+			}
+		}
+
+		remaining -= chunk
+	}
+
+	return nil
+}
+
+func (w *walker) coordinate(data []byte, layout Layout, byteOrder binary.ByteOrder) error {
+	var values [4]float64
+
+	empty := true
+
+	for idx := 0; idx < int(layout.Size()); idx++ {
+		values[idx] = math.Float64frombits(byteOrder.Uint64(data[idx*size64bit:]))
+
+		if values[idx] == values[idx] {
+			empty = false
+		}
+	}
+
+	if empty {
+		// POINT EMPTY.
+		return nil
+	}
+
+	nan := math.NaN()
+
+	switch layout {
+	case layoutXYM:
+		return w.visitor.Coordinate(values[0], values[1], nan, values[2])
+	case layoutXYZ:
+		return w.visitor.Coordinate(values[0], values[1], values[2], nan)
+	case layoutXYZM:
+		return w.visitor.Coordinate(values[0], values[1], values[2], values[3])
+	}
+
+	return w.visitor.Coordinate(values[0], values[1], nan, nan)
+}
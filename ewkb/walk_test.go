@@ -0,0 +1,244 @@
+package ewkb_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type eventVisitor struct {
+	events []string
+}
+
+func (e *eventVisitor) BeginGeometry(geometryType ewkb.GeometryType, layout ewkb.Layout, srid *ewkb.SystemReferenceID) error {
+	event := fmt.Sprintf("begin %d %s", geometryType, layout.Format())
+
+	if srid != nil {
+		event += fmt.Sprintf(" %d", *srid)
+	}
+
+	e.events = append(e.events, event)
+
+	return nil
+}
+
+func (e *eventVisitor) Coordinate(x float64, y float64, z float64, m float64) error {
+	e.events = append(e.events, fmt.Sprintf("coord %g %g %g %g", x, y, z, m))
+
+	return nil
+}
+
+func (e *eventVisitor) EndGeometry(geometryType ewkb.GeometryType) error {
+	e.events = append(e.events, fmt.Sprintf("end %d", geometryType))
+
+	return nil
+}
+
+func (e *eventVisitor) BeginRing(size uint32) error {
+	e.events = append(e.events, fmt.Sprintf("ring %d", size))
+
+	return nil
+}
+
+func (e *eventVisitor) EndRing() error {
+	e.events = append(e.events, "end ring")
+
+	return nil
+}
+
+func TestWalk(t *testing.T) {
+	srid := ewkb.SystemReferenceWGS84
+
+	t.Run("collection", func(t *testing.T) {
+		collection := ewkb.NewGeometryCollection()
+		collection.SRID = &srid
+		collection.Collection = []ewkb.Geometry{
+			&ewkb.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2, 'z': 3}},
+			&ewkb.MultiLineString{LineStrings: []ewkb.LineString{
+				{CoordinateSet: ewkb.CoordinateSet{{'x': 4, 'y': 5, 'z': 6}, {'x': 7, 'y': 8, 'z': 9}}},
+			}},
+			&ewkb.Point{Coordinate: ewkb.NewNullCoordinate(ewkb.LayoutWith(false, true))},
+		}
+
+		data, err := ewkb.Marshal(collection)
+		require.NoError(t, err)
+
+		visitor := &eventVisitor{}
+
+		require.NoError(t, ewkb.WalkHex(data, visitor))
+		assert.Equal(t, []string{
+			"begin 7 xyz 4326",
+			"begin 1 xyz",
+			"coord 1 2 3 NaN",
+			"end 1",
+			"begin 5 xyz",
+			"begin 2 xyz",
+			"ring 2",
+			"coord 4 5 6 NaN",
+			"coord 7 8 9 NaN",
+			"end ring",
+			"end 2",
+			"end 5",
+			"begin 1 xyz",
+			"end 1",
+			"end 7",
+		}, visitor.events)
+	})
+
+	t.Run("polygon XYM", func(t *testing.T) {
+		// SELECT 'POLYGON M((0 0 1,1 0 2,1 1 3,0 0 1))'::geometry.
+		data := "0103000040010000000400000000000000000000000000000000000000000000000000F03F" +
+			"000000000000F03F00000000000000000000000000000040000000000000F03F000000000000F03F" +
+			"000000000000084000000000000000000000000000000000000000000000F03F"
+
+		visitor := &eventVisitor{}
+
+		require.NoError(t, ewkb.WalkHex(data, visitor))
+		assert.Equal(t, []string{
+			"begin 3 xym",
+			"ring 4",
+			"coord 0 0 NaN 1",
+			"coord 1 0 NaN 2",
+			"coord 1 1 NaN 3",
+			"coord 0 0 NaN 1",
+			"end ring",
+			"end 3",
+		}, visitor.events)
+	})
+
+	t.Run("bounding box", func(t *testing.T) {
+		data, err := ewkb.Marshal(largeMultiPolygon(10, 100))
+		require.NoError(t, err)
+
+		count := 0
+		xMin, xMax := math.Inf(1), math.Inf(-1)
+
+		require.NoError(t, ewkb.WalkHex(data, ewkb.VisitorFuncs{
+			CoordinateFunc: func(x, y, z, m float64) error {
+				count++
+				xMin = math.Min(xMin, x)
+				xMax = math.Max(xMax, x)
+
+				return nil
+			},
+		}))
+
+		assert.Equal(t, 1000, count)
+		assert.InDelta(t, -1, xMin, 1e-3)
+		assert.InDelta(t, 10, xMax, 1e-3)
+	})
+
+	t.Run("visitor error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		count := 0
+
+		data, err := ewkb.Marshal(largeMultiPolygon(10, 100))
+		require.NoError(t, err)
+
+		err = ewkb.WalkHex(data, ewkb.VisitorFuncs{
+			CoordinateFunc: func(x, y, z, m float64) error {
+				count++
+				if count == 10 {
+					return errStop
+				}
+
+				return nil
+			},
+		})
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 10, count)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		err := ewkb.WalkHex("0102000000020000000000000000000000", ewkb.VisitorFuncs{})
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+		err = ewkb.WalkHex("", ewkb.VisitorFuncs{})
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+
+		err = ewkb.WalkHex(42, ewkb.VisitorFuncs{})
+		assert.ErrorIs(t, err, ewkb.ErrIncompatibleFormat)
+	})
+
+	t.Run("max depth", func(t *testing.T) {
+		// 100 nested GEOMETRYCOLLECTION, the last one empty.
+		data := strings.Repeat("010700000001000000", 99) + "010700000000000000"
+
+		require.NoError(t, ewkb.WalkHex(data, ewkb.VisitorFuncs{}))
+		require.NoError(t, ewkb.WalkHex(data, ewkb.VisitorFuncs{}, ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 99})))
+
+		err := ewkb.WalkHex(data, ewkb.VisitorFuncs{}, ewkb.WithLimits(ewkb.DecoderLimits{MaxDepth: 10}))
+		assert.ErrorIs(t, err, ewkb.ErrTooDeep)
+
+		var decodeErr *ewkb.DecodeError
+
+		require.ErrorAs(t, err, &decodeErr)
+		assert.Equal(t, 11*9, decodeErr.Offset)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		err := ewkb.WalkHex("012A00000000000000", ewkb.VisitorFuncs{})
+		assert.ErrorIs(t, err, ewkb.ErrWrongGeometryType)
+	})
+
+	t.Run("allocations", func(t *testing.T) {
+		allocations := func(polygons int) float64 {
+			data, err := ewkb.Marshal(largeMultiPolygon(polygons, 1000))
+			require.NoError(t, err)
+
+			binary, err := hex.DecodeString(string(data))
+			require.NoError(t, err)
+
+			reader := bytes.NewReader(binary)
+			visitor := ewkb.VisitorFuncs{}
+
+			return testing.AllocsPerRun(10, func() { //nolint: gomnd
+				reader.Reset(binary)
+
+				if err := ewkb.Walk(reader, visitor); err != nil {
+					panic(err)
+				}
+			})
+		}
+
+		assert.Equal(t, allocations(1), allocations(100))
+	})
+}
+
+func BenchmarkWalk(b *testing.B) {
+	data, err := ewkb.Marshal(largeMultiPolygon(100, 1000))
+	require.NoError(b, err)
+
+	binary, err := hex.DecodeString(strings.ToLower(string(data)))
+	require.NoError(b, err)
+
+	reader := bytes.NewReader(binary)
+	count := 0
+	visitor := ewkb.VisitorFuncs{
+		CoordinateFunc: func(x, y, z, m float64) error {
+			count++
+
+			return nil
+		},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for idx := 0; idx < b.N; idx++ {
+		reader.Reset(binary)
+
+		if err := ewkb.Walk(reader, visitor); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package gogis_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/landru29/gogis/internal/fuzzseeds"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzGeometryScan(f *testing.F) {
+	fuzzseeds.Add(f)
+
+	limits := gogis.WithDecoderLimits(ewkb.DecoderLimits{MaxDepth: 64})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		geometry := gogis.NewGeometry(limits)
+		if err := geometry.Scan(hex.EncodeToString(data)); err != nil {
+			return
+		}
+
+		// A scanned geometry is encoded again, and decoded to the same value.
+		value, err := geometry.Value()
+		require.NoError(t, err)
+		require.NotNil(t, value)
+
+		decoded := gogis.NewGeometry(limits)
+		require.NoError(t, decoded.Scan(value))
+
+		revalue, err := decoded.Value()
+		require.NoError(t, err)
+		require.Equal(t, value, revalue)
+	})
+}
@@ -0,0 +1,114 @@
+package geodesic
+
+import (
+	"math"
+)
+
+// PolygonArea computes the area and the perimeter of the geodesic polygon
+// defined by its vertices (Karney). The area is positive when the vertices are
+// counter clockwise, negative otherwise. The polygon is implicitly closed: the
+// last vertex may repeat the first one, or not.
+func (e *Ellipsoid) PolygonArea(latitudes []float64, longitudes []float64) (float64, float64) {
+	count := len(latitudes)
+	if len(longitudes) < count {
+		count = len(longitudes)
+	}
+
+	if count > 1 && latitudes[0] == latitudes[count-1] && angNormalize(longitudes[0]) == angNormalize(longitudes[count-1]) {
+		count--
+	}
+
+	if count < 2 { //nolint: gomnd
+		return 0, 0
+	}
+
+	var area, perimeter accumulator
+
+	crossings := 0
+
+	for idx := 0; idx < count; idx++ {
+		next := (idx + 1) % count
+		lon1, lon2 := angNormalize(longitudes[idx]), angNormalize(longitudes[next])
+
+		solution := e.inverse(latitudes[idx], lon1, latitudes[next], lon2, true)
+
+		perimeter.add(solution.s12)
+		area.add(solution.area)
+		crossings += transit(lon1, lon2)
+	}
+
+	return e.reduceArea(area, crossings), perimeter.sum()
+}
+
+// reduceArea reduces the accumulated area to (-area0/2, area0/2], counter
+// clockwise being positive.
+func (e *Ellipsoid) reduceArea(area accumulator, crossings int) float64 {
+	area0 := e.Area()
+
+	area.remainder(area0)
+
+	if crossings&1 != 0 {
+		if area.s < 0 {
+			area.add(area0 / 2) //nolint: gomnd
+		} else {
+			area.add(-area0 / 2) //nolint: gomnd
+		}
+	}
+
+	// The area is clockwise: counter clockwise is positive.
+	area.s, area.t = -area.s, -area.t
+
+	if area.s > area0/2 {
+		area.add(-area0)
+	} else if area.s <= -area0/2 {
+		area.add(area0)
+	}
+
+	return 0 + area.s
+}
+
+// transit is 1 or -1 when the edge crosses the prime meridian eastward or
+// westward, 0 otherwise.
+func transit(lon1 float64, lon2 float64) int {
+	lon12, _ := angDiff(lon1, lon2)
+	lon1 = angNormalize(lon1)
+	lon2 = angNormalize(lon2)
+
+	switch {
+	case lon12 > 0 && ((lon1 < 0 && lon2 >= 0) || (lon1 > 0 && lon2 == 0)):
+		return 1
+	case lon12 < 0 && lon1 >= 0 && lon2 < 0:
+		return -1
+	}
+
+	return 0
+}
+
+// accumulator is a sum in double-double precision: s + t.
+type accumulator struct {
+	s float64
+	t float64
+}
+
+func (a *accumulator) add(y float64) {
+	z, u := sumx(y, a.t)
+
+	a.s, a.t = sumx(z, a.s)
+
+	if a.s == 0 {
+		a.s = u
+	} else {
+		a.t += u
+	}
+}
+
+func (a accumulator) sum() float64 {
+	a.add(0)
+
+	return a.s
+}
+
+func (a *accumulator) remainder(y float64) {
+	a.s = math.Remainder(a.s, y)
+	a.add(0)
+}
@@ -0,0 +1,14 @@
+package geodesic
+
+// Error is a geodesic error.
+type Error string
+
+const (
+	// ErrNotConverged occurs when the Vincenty iteration does not converge
+	// (nearly antipodal points).
+	ErrNotConverged = Error("vincenty formula failed to converge")
+)
+
+func (e Error) Error() string {
+	return string(e)
+}
@@ -0,0 +1,124 @@
+// Package geodesic computes distances and areas on an ellipsoid, as PostGIS
+// does on geography.
+//
+// Positions are latitudes and longitudes in degrees; distances are in meters
+// and areas in square meters (in the unit of the equatorial radius).
+//
+//	inverse := geodesic.WGS84.Inverse(40.6, -73.8, 51.6, -0.5)
+//	// inverse.Distance = 5551759.400319 (JFK to LHR)
+//
+//	area, perimeter := geodesic.WGS84.PolygonArea(latitudes, longitudes)
+//
+// Inverse and PolygonArea implement the algorithms of C. F. F. Karney
+// ("Algorithms for geodesics", 2013), as GeographicLib does, which PostGIS uses
+// for ST_Distance(geography) and ST_Area(geography): they are accurate to a few
+// nanometers on the Earth. Vincenty is the classical iterative formula, and
+// Haversine the spherical approximation (ST_Distance(geography, false)).
+package geodesic
+
+import (
+	"math"
+)
+
+// Ellipsoid is an ellipsoid of revolution, with the constants of the geodesic
+// series.
+type Ellipsoid struct {
+	a     float64 // Equatorial radius.
+	f     float64 // Flattening.
+	f1    float64
+	e2    float64
+	ep2   float64
+	n     float64
+	b     float64
+	c2    float64
+	etol2 float64
+	a3x   [nA3]float64
+	c3x   [nC3x]float64
+	c4x   [nC4x]float64
+}
+
+// WGS84 is the ellipsoid of the World Geodetic System 1984 (SRID 4326).
+var WGS84 = NewEllipsoid(6378137, 1/298.257223563) //nolint: gochecknoglobals, gomnd
+
+// NewEllipsoid creates an ellipsoid from its equatorial radius and its
+// flattening. A flattening of 0 is a sphere.
+func NewEllipsoid(radius float64, flattening float64) *Ellipsoid {
+	output := Ellipsoid{
+		a:  radius,
+		f:  flattening,
+		f1: 1 - flattening,
+	}
+
+	output.e2 = flattening * (2 - flattening) //nolint: gomnd
+	output.ep2 = output.e2 / (output.f1 * output.f1)
+	output.n = flattening / (2 - flattening) //nolint: gomnd
+	output.b = radius * output.f1
+
+	authalic := 1.0
+
+	switch {
+	case output.e2 > 0:
+		authalic = math.Atanh(math.Sqrt(output.e2)) / math.Sqrt(output.e2)
+	case output.e2 < 0:
+		authalic = math.Atan(math.Sqrt(-output.e2)) / math.Sqrt(-output.e2)
+	}
+
+	output.c2 = (radius*radius + output.b*output.b*authalic) / 2                                               //nolint: gomnd
+	output.etol2 = 0.1 * tol2 / math.Sqrt(math.Max(0.001, math.Abs(flattening))*math.Min(1, 1-flattening/2)/2) //nolint: gomnd
+
+	output.a3coeff()
+	output.c3coeff()
+	output.c4coeff()
+
+	return &output
+}
+
+// Radius is the equatorial radius.
+func (e *Ellipsoid) Radius() float64 {
+	return e.a
+}
+
+// Flattening is the flattening.
+func (e *Ellipsoid) Flattening() float64 {
+	return e.f
+}
+
+// MeanRadius is the mean radius (2a + b) / 3, used by the spherical
+// approximations, as PostGIS does.
+func (e *Ellipsoid) MeanRadius() float64 {
+	return (2*e.a + e.b) / 3 //nolint: gomnd
+}
+
+// Area is the total area of the ellipsoid.
+func (e *Ellipsoid) Area() float64 {
+	return 4 * math.Pi * e.c2 //nolint: gomnd
+}
+
+// Inverse is the solution of the inverse geodesic problem: the shortest path
+// between two positions.
+type Inverse struct {
+	// Distance is the length of the geodesic.
+	Distance float64
+
+	// Azimuth1 is the azimuth at the first position, in degrees clockwise from the north.
+	Azimuth1 float64
+
+	// Azimuth2 is the azimuth at the second position, in degrees clockwise from the north.
+	Azimuth2 float64
+}
+
+// Inverse solves the inverse geodesic problem between two positions (Karney).
+func (e *Ellipsoid) Inverse(lat1 float64, lon1 float64, lat2 float64, lon2 float64) Inverse {
+	solution := e.inverse(lat1, lon1, lat2, lon2, false)
+
+	return Inverse{
+		Distance: solution.s12,
+		Azimuth1: atan2dx(solution.salp1, solution.calp1),
+		Azimuth2: atan2dx(solution.salp2, solution.calp2),
+	}
+}
+
+// Distance is the length of the geodesic between two positions (Karney).
+func (e *Ellipsoid) Distance(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	return e.inverse(lat1, lon1, lat2, lon2, false).s12
+}
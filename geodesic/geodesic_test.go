@@ -0,0 +1,122 @@
+package geodesic_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/landru29/gogis/geodesic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInverse(t *testing.T) {
+	t.Run("JFK to LHR", func(t *testing.T) {
+		inverse := geodesic.WGS84.Inverse(40.6, -73.8, 51.6, -0.5)
+
+		assert.InDelta(t, 5551759.400319, inverse.Distance, 1e-6)
+		assert.InDelta(t, 51.198882845579, inverse.Azimuth1, 1e-9)
+		assert.InDelta(t, 107.821776735514, inverse.Azimuth2, 1e-9)
+	})
+
+	t.Run("same position", func(t *testing.T) {
+		assert.Equal(t, 0.0, geodesic.WGS84.Distance(12, 34, 12, 34))
+	})
+
+	t.Run("equator", func(t *testing.T) {
+		// A quarter of the equator.
+		assert.InDelta(t, math.Pi*geodesic.WGS84.Radius()/2, geodesic.WGS84.Distance(0, 0, 0, 90), 1e-6)
+	})
+
+	t.Run("meridian", func(t *testing.T) {
+		// The quarter meridian of WGS84.
+		assert.InDelta(t, 10001965.729, geodesic.WGS84.Distance(0, 0, 90, 0), 1e-3)
+	})
+
+	t.Run("nearly antipodal", func(t *testing.T) {
+		assert.InDelta(t, 19936288.579, geodesic.WGS84.Distance(0, 0, 0.5, 179.5), 1e-3)
+	})
+
+	t.Run("sphere", func(t *testing.T) {
+		sphere := geodesic.NewEllipsoid(1, 0)
+
+		assert.InDelta(t, math.Pi/2, sphere.Distance(0, 0, 90, 0), 1e-15)
+		assert.InDelta(t, math.Pi/3, sphere.Distance(0, 0, 0, 60), 1e-15)
+	})
+}
+
+func TestVincenty(t *testing.T) {
+	t.Run("agrees with Karney", func(t *testing.T) {
+		random := rand.New(rand.NewSource(42)) //nolint: gosec
+
+		for idx := 0; idx < 1000; idx++ {
+			lat1, lon1 := random.Float64()*180-90, random.Float64()*360-180
+			lat2, lon2 := random.Float64()*180-90, random.Float64()*360-180
+
+			distance, err := geodesic.WGS84.Vincenty(lat1, lon1, lat2, lon2)
+			if err != nil {
+				continue
+			}
+
+			assert.InDelta(t, geodesic.WGS84.Distance(lat1, lon1, lat2, lon2), distance, 1e-3)
+		}
+	})
+
+	t.Run("antipodal", func(t *testing.T) {
+		_, err := geodesic.WGS84.Vincenty(0, 0, 0.5, 179.7)
+		require.ErrorIs(t, err, geodesic.ErrNotConverged)
+	})
+}
+
+func TestHaversine(t *testing.T) {
+	distance := geodesic.WGS84.Distance(40.6, -73.8, 51.6, -0.5)
+
+	assert.InDelta(t, distance, geodesic.WGS84.Haversine(40.6, -73.8, 51.6, -0.5), distance*0.005)
+	assert.Equal(t, 0.0, geodesic.WGS84.Haversine(12, 34, 12, 34))
+}
+
+func TestPolygonArea(t *testing.T) {
+	t.Run("octant", func(t *testing.T) {
+		area, perimeter := geodesic.WGS84.PolygonArea([]float64{0, 0, 90}, []float64{0, 90, 0})
+
+		assert.InDelta(t, geodesic.WGS84.Area()/8, area, 1e-2)
+		assert.InDelta(t, 30022685.630, perimeter, 1e-3)
+	})
+
+	t.Run("closed ring", func(t *testing.T) {
+		area, perimeter := geodesic.WGS84.PolygonArea([]float64{0, 0, 90, 0}, []float64{0, 90, 0, 0})
+
+		assert.InDelta(t, geodesic.WGS84.Area()/8, area, 1e-2)
+		assert.InDelta(t, 30022685.630, perimeter, 1e-3)
+	})
+
+	t.Run("clockwise", func(t *testing.T) {
+		area, _ := geodesic.WGS84.PolygonArea([]float64{90, 0, 0}, []float64{0, 90, 0})
+
+		assert.InDelta(t, -geodesic.WGS84.Area()/8, area, 1e-2)
+	})
+
+	t.Run("antarctica", func(t *testing.T) {
+		// The example of GeographicLib: a polygon encircling the south pole.
+		latitudes := []float64{
+			-63.1, -72.9, -71.9, -74.9, -74.3, -77.5, -77.4, -71.7, -65.9,
+			-65.7, -66.6, -66.9, -69.8, -70.0, -71.0, -77.3, -77.9, -74.7,
+		}
+		longitudes := []float64{
+			-58, -74, -102, -102, -131, -163, 163, 172, 140,
+			113, 88, 59, 25, -4, -14, -33, -46, -61,
+		}
+
+		area, perimeter := geodesic.WGS84.PolygonArea(latitudes, longitudes)
+
+		assert.InDelta(t, 13662703680020.1, area, 1)
+		assert.InDelta(t, 16831067.893, perimeter, 1e-3)
+	})
+
+	t.Run("degenerate", func(t *testing.T) {
+		area, perimeter := geodesic.WGS84.PolygonArea(nil, nil)
+
+		assert.Equal(t, 0.0, area)
+		assert.Equal(t, 0.0, perimeter)
+	})
+}
@@ -0,0 +1,17 @@
+package geodesic
+
+import (
+	"math"
+)
+
+// Haversine computes the great circle distance between two positions on the
+// sphere of the mean radius of the ellipsoid, as ST_Distance(geography, false)
+// does. It is faster than Distance, with an error up to 0.5 %.
+func (e *Ellipsoid) Haversine(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64 {
+	sinLat := math.Sin((lat2 - lat1) * degree / 2) //nolint: gomnd
+	sinLon := math.Sin((lon2 - lon1) * degree / 2) //nolint: gomnd
+
+	h := sinLat*sinLat + math.Cos(lat1*degree)*math.Cos(lat2*degree)*sinLon*sinLon
+
+	return 2 * e.MeanRadius() * math.Asin(math.Min(1, math.Sqrt(h))) //nolint: gomnd
+}
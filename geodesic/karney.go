@@ -0,0 +1,949 @@
+// This file is a port of geodesic.c from GeographicLib, distributed under the
+// following notice:
+//
+// Copyright (c) Charles Karney (2012-2022) <karney@alum.mit.edu>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package geodesic
+
+import (
+	"math"
+)
+
+// Port of the inverse problem of GeographicLib (geodesic.c, C. F. F. Karney,
+// see the notice above), with series of order 6.
+
+const (
+	geodesicOrder = 6
+	nA1           = geodesicOrder
+	nC1           = geodesicOrder
+	nA2           = geodesicOrder
+	nC2           = geodesicOrder
+	nA3           = geodesicOrder
+	nC3           = geodesicOrder
+	nC3x          = (nC3 * (nC3 - 1)) / 2
+	nC4           = geodesicOrder
+	nC4x          = (nC4 * (nC4 + 1)) / 2
+	nC            = geodesicOrder + 1
+
+	maxit1 = 20
+	maxit2 = maxit1 + 53 + 10 //nolint: gomnd
+
+	degree = math.Pi / 180
+)
+
+// nolint: gochecknoglobals
+var (
+	tiny    = math.Sqrt(math.SmallestNonzeroFloat64 * (1 << 52))
+	tol0    = math.Nextafter(1, 2) - 1
+	tol1    = 200 * tol0
+	tol2    = math.Sqrt(tol0)
+	tolb    = tol0
+	xthresh = 1000 * tol2
+)
+
+// inverseSolution is the solution of the inverse problem: the distance, the
+// azimuths (sine and cosine), and the area between the geodesic and the equator.
+type inverseSolution struct {
+	s12   float64
+	salp1 float64
+	calp1 float64
+	salp2 float64
+	calp2 float64
+	area  float64
+}
+
+// nolint: funlen, gocognit, gocyclo, cyclop, maintidx
+func (e *Ellipsoid) inverse(lat1 float64, lon1 float64, lat2 float64, lon2 float64, withArea bool) inverseSolution {
+	var (
+		salp1, calp1, salp2, calp2 float64
+		s12x, m12x, sig12          float64
+		omg12, comg12              float64
+		somg12                     = 2.0 // 2 marks that it must be computed.
+		coeffs                     [nC]float64
+	)
+
+	// Longitude difference in [-180, 180], positive.
+	lon12, lon12s := angDiff(lon1, lon2)
+
+	lonsign := 1.0
+	if lon12 < 0 {
+		lonsign = -1
+	}
+
+	lon12 = lonsign * angRound(lon12)
+	lon12s = angRound((180 - lon12) - lonsign*lon12s) //nolint: gomnd
+	lam12 := lon12 * degree
+
+	var slam12, clam12 float64
+	if lon12 > 90 { //nolint: gomnd
+		slam12, clam12 = sincosdx(lon12s)
+		clam12 = -clam12
+	} else {
+		slam12, clam12 = sincosdx(lon12)
+	}
+
+	// Very close to the equator: on the equator.
+	lat1 = angRound(latFix(lat1))
+	lat2 = angRound(latFix(lat2))
+
+	// The point with the highest absolute latitude is the first one.
+	swapp := 1.0
+	if math.Abs(lat1) < math.Abs(lat2) {
+		swapp = -1
+		lonsign *= -1
+		lat1, lat2 = lat2, lat1
+	}
+
+	// Make lat1 <= 0.
+	latsign := -1.0
+	if lat1 < 0 {
+		latsign = 1
+	}
+
+	lat1 *= latsign
+	lat2 *= latsign
+
+	// Now 0 <= lon12 <= 180, -90 <= lat1 <= 0, and lat1 <= lat2 <= -lat1.
+	sbet1, cbet1 := sincosdx(lat1)
+	sbet1 *= e.f1
+	sbet1, cbet1 = norm2(sbet1, cbet1)
+	cbet1 = math.Max(tiny, cbet1)
+
+	sbet2, cbet2 := sincosdx(lat2)
+	sbet2 *= e.f1
+	sbet2, cbet2 = norm2(sbet2, cbet2)
+	cbet2 = math.Max(tiny, cbet2)
+
+	if cbet1 < -sbet1 {
+		if cbet2 == cbet1 {
+			sbet2 = math.Copysign(sbet1, sbet2)
+		}
+	} else if math.Abs(sbet2) == -sbet1 {
+		cbet2 = cbet1
+	}
+
+	dn1 := math.Sqrt(1 + e.ep2*sbet1*sbet1)
+	dn2 := math.Sqrt(1 + e.ep2*sbet2*sbet2)
+
+	meridian := lat1 == -90 || slam12 == 0
+
+	if meridian {
+		// The end points are on a meridian.
+		calp1, salp1 = clam12, slam12
+		calp2, salp2 = 1, 0
+
+		ssig1, csig1 := sbet1, calp1*cbet1
+		ssig2, csig2 := sbet2, calp2*cbet2
+
+		sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+		s12x, m12x, _ = e.lengths(e.n, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+
+		if sig12 < 1 || m12x >= 0 {
+			if sig12 < 3*tiny || (sig12 < tol0 && (s12x < 0 || m12x < 0)) {
+				sig12, m12x, s12x = 0, 0, 0
+			}
+
+			m12x *= e.b
+			s12x *= e.b
+		} else {
+			// Prolate, and too close to antipodal.
+			meridian = false
+		}
+	}
+
+	switch {
+	case meridian:
+	case sbet1 == 0 && (e.f <= 0 || lon12s >= e.f*180): //nolint: gomnd
+		// Along the equator.
+		calp1, calp2, salp1, salp2 = 0, 0, 1, 1
+		s12x = e.a * lam12
+		sig12 = lam12 / e.f1
+		omg12 = sig12
+	default:
+		var dnm float64
+
+		sig12, salp1, calp1, salp2, calp2, dnm = e.inverseStart(sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12)
+
+		if sig12 >= 0 {
+			// Short line.
+			s12x = sig12 * e.b * dnm
+			omg12 = lam12 / (e.f1 * dnm)
+
+			break
+		}
+
+		// Newton's method on lambda12(alp1) - lam12 = 0, the root being
+		// bracketed by (alp1a, alp1b).
+		var ssig1, csig1, ssig2, csig2, eps, domg12 float64
+
+		salp1a, calp1a, salp1b, calp1b := tiny, 1.0, tiny, -1.0
+		tripn, tripb := false, false
+
+		for numit := 0; ; numit++ {
+			var value, derivative float64
+
+			value, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, derivative = e.lambda12(
+				sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam12, clam12, numit < maxit1,
+			)
+
+			tolerance := 1.0
+			if tripn {
+				tolerance = 8 //nolint: gomnd
+			}
+
+			if tripb || !(math.Abs(value) >= tolerance*tol0) || numit == maxit2 {
+				break
+			}
+
+			if value > 0 && (numit > maxit1 || calp1/salp1 > calp1b/salp1b) {
+				salp1b, calp1b = salp1, calp1
+			} else if value < 0 && (numit > maxit1 || calp1/salp1 < calp1a/salp1a) {
+				salp1a, calp1a = salp1, calp1
+			}
+
+			if numit < maxit1 && derivative > 0 {
+				dalp1 := -value / derivative
+
+				if math.Abs(dalp1) < math.Pi {
+					sdalp1, cdalp1 := math.Sincos(dalp1)
+
+					if nsalp1 := salp1*cdalp1 + calp1*sdalp1; nsalp1 > 0 {
+						calp1 = calp1*cdalp1 - salp1*sdalp1
+						salp1, calp1 = norm2(nsalp1, calp1)
+						tripn = math.Abs(value) <= 16*tol0 //nolint: gomnd
+
+						continue
+					}
+				}
+			}
+
+			// Bisection.
+			salp1, calp1 = norm2((salp1a+salp1b)/2, (calp1a+calp1b)/2) //nolint: gomnd
+			tripn = false
+			tripb = math.Abs(salp1a-salp1)+(calp1a-calp1) < tolb || math.Abs(salp1-salp1b)+(calp1-calp1b) < tolb
+		}
+
+		s12x, m12x, _ = e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+		s12x *= e.b
+
+		// omg12 = lam12 - domg12.
+		sdomg12, cdomg12 := math.Sincos(domg12)
+		somg12 = slam12*cdomg12 - clam12*sdomg12
+		comg12 = clam12*cdomg12 + slam12*sdomg12
+	}
+
+	output := inverseSolution{s12: 0 + s12x}
+
+	if withArea {
+		output.area = e.inverseArea(
+			meridian, sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2, omg12, somg12, comg12, coeffs[:],
+		) * swapp * lonsign * latsign
+		output.area += 0
+	}
+
+	// Azimuths, undoing the canonical transformation.
+	if swapp < 0 {
+		salp1, salp2 = salp2, salp1
+		calp1, calp2 = calp2, calp1
+	}
+
+	output.salp1, output.calp1 = salp1*swapp*lonsign, calp1*swapp*latsign
+	output.salp2, output.calp2 = salp2*swapp*lonsign, calp2*swapp*latsign
+
+	return output
+}
+
+// inverseArea computes the area between the geodesic and the equator.
+//
+// nolint: gomnd
+func (e *Ellipsoid) inverseArea(
+	meridian bool,
+	sbet1, cbet1, sbet2, cbet2, salp1, calp1, salp2, calp2, omg12, somg12, comg12 float64,
+	coeffs []float64,
+) float64 {
+	area := 0.0
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	if calp0 != 0 && salp0 != 0 {
+		ssig1, csig1 := norm2(sbet1, calp1*cbet1)
+		ssig2, csig2 := norm2(sbet2, calp2*cbet2)
+		k2 := calp0 * calp0 * e.ep2
+		eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+		a4 := e.a * e.a * calp0 * salp0 * e.e2
+
+		e.c4f(eps, coeffs)
+		area = a4 * (sinCosSeries(false, ssig2, csig2, coeffs, nC4) - sinCosSeries(false, ssig1, csig1, coeffs, nC4))
+	}
+
+	if !meridian && somg12 == 2 {
+		somg12, comg12 = math.Sincos(omg12)
+	}
+
+	var alp12 float64
+
+	if !meridian && comg12 > -0.7071 && sbet2-sbet1 < 1.75 {
+		// tan(Gamma/2) = tan(omg12/2) * (tan(bet1/2)+tan(bet2/2))/(1+tan(bet1/2)*tan(bet2/2)).
+		domg12, dbet1, dbet2 := 1+comg12, 1+cbet1, 1+cbet2
+		alp12 = 2 * math.Atan2(somg12*(sbet1*dbet2+sbet2*dbet1), domg12*(sbet1*sbet2+dbet1*dbet2))
+	} else {
+		salp12 := salp2*calp1 - calp2*salp1
+		calp12 := calp2*calp1 + salp2*salp1
+
+		if salp12 == 0 && calp12 < 0 {
+			salp12 = tiny * calp1
+			calp12 = -1
+		}
+
+		alp12 = math.Atan2(salp12, calp12)
+	}
+
+	return area + e.c2*alp12
+}
+
+// lengths computes the distance s12b and the reduced length m12b (both missing
+// a factor b), and m0.
+func (e *Ellipsoid) lengths(
+	eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2 float64,
+) (float64, float64, float64) {
+	var c1a, c2a [nC]float64
+
+	a1 := a1m1f(eps)
+	c1f(eps, c1a[:])
+	a2 := a2m1f(eps)
+	c2f(eps, c2a[:])
+
+	m0x := a1 - a2
+	a1++
+	a2++
+
+	b1 := sinCosSeries(true, ssig2, csig2, c1a[:], nC1) - sinCosSeries(true, ssig1, csig1, c1a[:], nC1)
+	s12b := a1 * (sig12 + b1)
+
+	b2 := sinCosSeries(true, ssig2, csig2, c2a[:], nC2) - sinCosSeries(true, ssig1, csig1, c2a[:], nC2)
+	j12 := m0x*sig12 + (a1*b1 - a2*b2)
+
+	m12b := dn2*(csig1*ssig2) - dn1*(ssig1*csig2) - csig1*csig2*j12
+
+	return s12b, m12b, m0x
+}
+
+// inverseStart computes a starting point for Newton's method. It returns
+// sig12 >= 0 when the line is short enough to be solved directly.
+//
+// nolint: funlen, gomnd
+func (e *Ellipsoid) inverseStart(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, lam12, slam12, clam12 float64,
+) (sig12, salp1, calp1, salp2, calp2, dnm float64) {
+	sig12 = -1
+	sbet12 := sbet2*cbet1 - cbet2*sbet1
+	cbet12 := cbet2*cbet1 + sbet2*sbet1
+	sbet12a := sbet2*cbet1 + cbet2*sbet1
+	shortline := cbet12 >= 0 && sbet12 < 0.5 && cbet2*lam12 < 0.5
+
+	var somg12, comg12 float64
+
+	if shortline {
+		sbetm2 := (sbet1 + sbet2) * (sbet1 + sbet2)
+		sbetm2 /= sbetm2 + (cbet1+cbet2)*(cbet1+cbet2)
+		dnm = math.Sqrt(1 + e.ep2*sbetm2)
+		somg12, comg12 = math.Sincos(lam12 / (e.f1 * dnm))
+	} else {
+		somg12, comg12 = slam12, clam12
+	}
+
+	salp1 = cbet2 * somg12
+
+	if comg12 >= 0 {
+		calp1 = sbet12 + cbet2*sbet1*somg12*somg12/(1+comg12)
+	} else {
+		calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+	}
+
+	ssig12 := math.Hypot(salp1, calp1)
+	csig12 := sbet1*sbet2 + cbet1*cbet2*comg12
+
+	switch {
+	case shortline && ssig12 < e.etol2:
+		// Really short line.
+		salp2 = cbet1 * somg12
+
+		if comg12 >= 0 {
+			calp2 = sbet12 - cbet1*sbet2*(somg12*somg12/(1+comg12))
+		} else {
+			calp2 = sbet12 - cbet1*sbet2*(1-comg12)
+		}
+
+		salp2, calp2 = norm2(salp2, calp2)
+		sig12 = math.Atan2(ssig12, csig12)
+	case math.Abs(e.n) > 0.1 || csig12 >= 0 || ssig12 >= 6*math.Abs(e.n)*math.Pi*cbet1*cbet1:
+		// The zeroth order spherical approximation is good enough.
+	default:
+		// Nearly antipodal: scale to the astroid problem.
+		var x, y, lamscale, betscale float64
+
+		lam12x := math.Atan2(-slam12, -clam12)
+
+		if e.f >= 0 {
+			k2 := sbet1 * sbet1 * e.ep2
+			eps := k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+			lamscale = e.f * cbet1 * e.a3f(eps) * math.Pi
+			betscale = lamscale * cbet1
+			x = lam12x / lamscale
+			y = sbet12a / betscale
+		} else {
+			cbet12a := cbet2*cbet1 - sbet2*sbet1
+			bet12a := math.Atan2(sbet12a, cbet12a)
+
+			_, m12b, m0 := e.lengths(e.n, math.Pi+bet12a, sbet1, -cbet1, dn1, sbet2, cbet2, dn2)
+			x = -1 + m12b/(cbet1*cbet2*m0*math.Pi)
+
+			if x < -0.01 {
+				betscale = sbet12a / x
+			} else {
+				betscale = -e.f * cbet1 * cbet1 * math.Pi
+			}
+
+			lamscale = betscale / cbet1
+			y = lam12x / lamscale
+		}
+
+		if y > -tol1 && x > -1-xthresh {
+			if e.f >= 0 {
+				salp1 = math.Min(1, -x)
+				calp1 = -math.Sqrt(1 - salp1*salp1)
+			} else {
+				lower := -1.0
+				if x > -tol1 {
+					lower = 0
+				}
+
+				calp1 = math.Max(lower, x)
+				salp1 = math.Sqrt(1 - calp1*calp1)
+			}
+		} else {
+			k := astroid(x, y)
+
+			var omg12a float64
+			if e.f >= 0 {
+				omg12a = lamscale * (-x * k / (1 + k))
+			} else {
+				omg12a = lamscale * (-y * (1 + k) / k)
+			}
+
+			somg12, comg12 = math.Sincos(omg12a)
+			comg12 = -comg12
+			salp1 = cbet2 * somg12
+			calp1 = sbet12a - cbet2*sbet1*somg12*somg12/(1-comg12)
+		}
+	}
+
+	if !(salp1 <= 0) {
+		salp1, calp1 = norm2(salp1, calp1)
+	} else {
+		salp1, calp1 = 1, 0
+	}
+
+	return sig12, salp1, calp1, salp2, calp2, dnm
+}
+
+// lambda12 computes the longitude difference of the geodesic starting with the
+// azimuth alp1, and its derivative.
+//
+// nolint: gomnd
+func (e *Ellipsoid) lambda12(
+	sbet1, cbet1, dn1, sbet2, cbet2, dn2, salp1, calp1, slam120, clam120 float64,
+	diffp bool,
+) (lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12 float64) {
+	var coeffs [nC]float64
+
+	if sbet1 == 0 && calp1 == 0 {
+		// Break the degeneracy of the equatorial line.
+		calp1 = -tiny
+	}
+
+	salp0 := salp1 * cbet1
+	calp0 := math.Hypot(calp1, salp1*sbet1)
+
+	ssig1 = sbet1
+	somg1 := salp0 * sbet1
+	csig1 = calp1 * cbet1
+	comg1 := csig1
+	ssig1, csig1 = norm2(ssig1, csig1)
+
+	// Symmetries when abs(bet2) = -bet1.
+	if cbet2 != cbet1 {
+		salp2 = salp0 / cbet2
+	} else {
+		salp2 = salp1
+	}
+
+	if cbet2 != cbet1 || math.Abs(sbet2) != -sbet1 {
+		if cbet1 < -sbet1 {
+			calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+(cbet2-cbet1)*(cbet1+cbet2)) / cbet2
+		} else {
+			calp2 = math.Sqrt(calp1*cbet1*calp1*cbet1+(sbet1-sbet2)*(sbet1+sbet2)) / cbet2
+		}
+	} else {
+		calp2 = math.Abs(calp1)
+	}
+
+	ssig2 = sbet2
+	somg2 := salp0 * sbet2
+	csig2 = calp2 * cbet2
+	comg2 := csig2
+	ssig2, csig2 = norm2(ssig2, csig2)
+
+	sig12 = math.Atan2(math.Max(0, csig1*ssig2-ssig1*csig2), csig1*csig2+ssig1*ssig2)
+
+	somg12 := math.Max(0, comg1*somg2-somg1*comg2)
+	comg12 := comg1*comg2 + somg1*somg2
+	eta := math.Atan2(somg12*clam120-comg12*slam120, comg12*clam120+somg12*slam120)
+
+	k2 := calp0 * calp0 * e.ep2
+	eps = k2 / (2*(1+math.Sqrt(1+k2)) + k2)
+
+	e.c3f(eps, coeffs[:])
+	b312 := sinCosSeries(true, ssig2, csig2, coeffs[:], nC3-1) - sinCosSeries(true, ssig1, csig1, coeffs[:], nC3-1)
+	domg12 = -e.f * e.a3f(eps) * salp0 * (sig12 + b312)
+	lam12 = eta + domg12
+
+	if diffp {
+		if calp2 == 0 {
+			dlam12 = -2 * e.f1 * dn1 / sbet1
+		} else {
+			_, dlam12, _ = e.lengths(eps, sig12, ssig1, csig1, dn1, ssig2, csig2, dn2)
+			dlam12 *= e.f1 / (calp2 * cbet2)
+		}
+	}
+
+	return lam12, salp2, calp2, sig12, ssig1, csig1, ssig2, csig2, eps, domg12, dlam12
+}
+
+// astroid solves k^4+2*k^3-(x^2+y^2-1)*k^2-2*y^2*k-y^2 = 0 for the positive root k.
+//
+// nolint: gomnd
+func astroid(x float64, y float64) float64 {
+	p, q := x*x, y*y
+	r := (p + q - 1) / 6
+
+	if q == 0 && r <= 0 {
+		return 0
+	}
+
+	s := p * q / 4
+	r2 := r * r
+	r3 := r * r2
+	disc := s * (s + 2*r3)
+	u := r
+
+	if disc >= 0 {
+		t3 := s + r3
+
+		if t3 < 0 {
+			t3 -= math.Sqrt(disc)
+		} else {
+			t3 += math.Sqrt(disc)
+		}
+
+		t := math.Cbrt(t3)
+
+		u += t
+		if t != 0 {
+			u += r2 / t
+		}
+	} else {
+		ang := math.Atan2(math.Sqrt(-disc), -(s + r3))
+		u += 2 * r * math.Cos(ang/3)
+	}
+
+	v := math.Sqrt(u*u + q)
+
+	var uv float64
+	if u < 0 {
+		uv = q / (v - u)
+	} else {
+		uv = u + v
+	}
+
+	w := (uv - q) / (2 * v)
+
+	return uv / (math.Sqrt(uv+w*w) + w)
+}
+
+// Series coefficients.
+
+// nolint: gomnd
+func a1m1f(eps float64) float64 {
+	coeff := []float64{1, 4, 64, 0, 256}
+	m := nA1 / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+
+	return (t + eps) / (1 - eps)
+}
+
+// nolint: gomnd
+func c1f(eps float64, c []float64) {
+	coeff := []float64{
+		-1, 6, -16, 32,
+		-9, 64, -128, 2048,
+		9, -16, 768,
+		3, -5, 512,
+		-7, 1280,
+		-7, 2048,
+	}
+
+	seriesInEps2(eps, coeff, nC1, c)
+}
+
+// nolint: gomnd
+func a2m1f(eps float64) float64 {
+	coeff := []float64{-11, -28, -192, 0, 256}
+	m := nA2 / 2
+	t := polyval(m, coeff, eps*eps) / coeff[m+1]
+
+	return (t - eps) / (1 + eps)
+}
+
+// nolint: gomnd
+func c2f(eps float64, c []float64) {
+	coeff := []float64{
+		1, 2, 16, 32,
+		35, 64, 384, 2048,
+		15, 80, 768,
+		7, 35, 512,
+		63, 1280,
+		77, 2048,
+	}
+
+	seriesInEps2(eps, coeff, nC2, c)
+}
+
+// seriesInEps2 evaluates the coefficients c[l] = eps^l * polynomial(eps^2), for l in [1, size].
+func seriesInEps2(eps float64, coeff []float64, size int, c []float64) {
+	eps2 := eps * eps
+	d := eps
+	offset := 0
+
+	for l := 1; l <= size; l++ {
+		m := (size - l) / 2 //nolint: gomnd
+		c[l] = d * polyval(m, coeff[offset:], eps2) / coeff[offset+m+1]
+		offset += m + 2
+		d *= eps
+	}
+}
+
+// nolint: gomnd
+func (e *Ellipsoid) a3coeff() {
+	coeff := []float64{
+		-3, 128,
+		-2, -3, 64,
+		-1, -3, -1, 16,
+		3, -1, -2, 8,
+		1, -1, 2,
+		1, 1,
+	}
+
+	offset, k := 0, 0
+
+	for j := nA3 - 1; j >= 0; j-- {
+		m := nA3 - j - 1
+		if j < m {
+			m = j
+		}
+
+		e.a3x[k] = polyval(m, coeff[offset:], e.n) / coeff[offset+m+1]
+		k++
+		offset += m + 2
+	}
+}
+
+// nolint: gomnd
+func (e *Ellipsoid) c3coeff() {
+	coeff := []float64{
+		3, 128,
+		2, 5, 128,
+		-1, 3, 3, 64,
+		-1, 0, 1, 8,
+		-1, 1, 4,
+		5, 256,
+		1, 3, 128,
+		-3, -2, 3, 64,
+		1, -3, 2, 32,
+		7, 512,
+		-10, 9, 384,
+		5, -9, 5, 192,
+		7, 512,
+		-14, 7, 512,
+		21, 2560,
+	}
+
+	offset, k := 0, 0
+
+	for l := 1; l < nC3; l++ {
+		for j := nC3 - 1; j >= l; j-- {
+			m := nC3 - j - 1
+			if j < m {
+				m = j
+			}
+
+			e.c3x[k] = polyval(m, coeff[offset:], e.n) / coeff[offset+m+1]
+			k++
+			offset += m + 2
+		}
+	}
+}
+
+// nolint: gomnd
+func (e *Ellipsoid) c4coeff() {
+	coeff := []float64{
+		97, 15015,
+		1088, 156, 45045,
+		-224, -4784, 1573, 45045,
+		-10656, 14144, -4576, -858, 45045,
+		64, 624, -4576, 6864, -3003, 15015,
+		100, 208, 572, 3432, -12012, 30030, 45045,
+		1, 9009,
+		-2944, 468, 135135,
+		5792, 1040, -1287, 135135,
+		5952, -11648, 9152, -2574, 135135,
+		-64, -624, 4576, -6864, 3003, 135135,
+		8, 10725,
+		1856, -936, 225225,
+		-8448, 4992, -1144, 225225,
+		-1440, 4160, -4576, 1716, 225225,
+		-136, 63063,
+		1024, -208, 105105,
+		3584, -3328, 1144, 315315,
+		-128, 135135,
+		-2560, 832, 405405,
+		128, 99099,
+	}
+
+	offset, k := 0, 0
+
+	for l := 0; l < nC4; l++ {
+		for j := nC4 - 1; j >= l; j-- {
+			m := nC4 - j - 1
+
+			e.c4x[k] = polyval(m, coeff[offset:], e.n) / coeff[offset+m+1]
+			k++
+			offset += m + 2
+		}
+	}
+}
+
+func (e *Ellipsoid) a3f(eps float64) float64 {
+	return polyval(nA3-1, e.a3x[:], eps)
+}
+
+func (e *Ellipsoid) c3f(eps float64, c []float64) {
+	mult := 1.0
+	offset := 0
+
+	for l := 1; l < nC3; l++ {
+		m := nC3 - l - 1
+		mult *= eps
+		c[l] = mult * polyval(m, e.c3x[offset:], eps)
+		offset += m + 1
+	}
+}
+
+func (e *Ellipsoid) c4f(eps float64, c []float64) {
+	mult := 1.0
+	offset := 0
+
+	for l := 0; l < nC4; l++ {
+		m := nC4 - l - 1
+		c[l] = mult * polyval(m, e.c4x[offset:], eps)
+		offset += m + 1
+		mult *= eps
+	}
+}
+
+// Mathematical helpers.
+
+// polyval evaluates the polynomial of order n with the coefficients p (highest first).
+func polyval(n int, p []float64, x float64) float64 {
+	if n < 0 {
+		return 0
+	}
+
+	y := p[0]
+
+	for idx := 1; idx <= n; idx++ {
+		y = y*x + p[idx]
+	}
+
+	return y
+}
+
+// sinCosSeries evaluates sum(c[i] * sin(2*i*x), i, 1, n) when sinp, or
+// sum(c[i] * cos((2*i+1)*x), i, 0, n-1) otherwise (Clenshaw summation).
+func sinCosSeries(sinp bool, sinx float64, cosx float64, c []float64, n int) float64 {
+	idx := n
+	if sinp {
+		idx++
+	}
+
+	ar := 2 * (cosx - sinx) * (cosx + sinx) //nolint: gomnd
+	y0, y1 := 0.0, 0.0
+
+	if n&1 != 0 {
+		idx--
+		y0 = c[idx]
+	}
+
+	for pairs := n / 2; pairs > 0; pairs-- { //nolint: gomnd
+		idx--
+		y1 = ar*y0 - y1 + c[idx]
+		idx--
+		y0 = ar*y1 - y0 + c[idx]
+	}
+
+	if sinp {
+		return 2 * sinx * cosx * y0 //nolint: gomnd
+	}
+
+	return cosx * (y0 - y1)
+}
+
+// sumx is the error free sum: u + v = s + t.
+func sumx(u float64, v float64) (float64, float64) {
+	s := u + v
+	up := s - v
+	vpp := s - up
+	up -= u
+	vpp -= v
+
+	return s, -(up + vpp)
+}
+
+func norm2(sinx float64, cosx float64) (float64, float64) {
+	r := math.Hypot(sinx, cosx)
+
+	return sinx / r, cosx / r
+}
+
+// angNormalize reduces an angle to (-180, 180].
+func angNormalize(x float64) float64 {
+	y := math.Remainder(x, 360) //nolint: gomnd
+	if y == -180 {              //nolint: gomnd
+		return 180 //nolint: gomnd
+	}
+
+	return y
+}
+
+// angDiff computes y - x, reduced to [-180, 180], with its rounding error.
+func angDiff(x float64, y float64) (float64, float64) {
+	d, t := sumx(angNormalize(-x), angNormalize(y))
+	d = angNormalize(d)
+
+	if d == 180 && t > 0 { //nolint: gomnd
+		d = -180
+	}
+
+	return sumx(d, t)
+}
+
+// angRound rounds tiny angles, so that they are exactly 0.
+func angRound(x float64) float64 {
+	const z = 1.0 / 16
+
+	y := math.Abs(x)
+	if y < z {
+		y = z - (z - y)
+	}
+
+	return math.Copysign(y, x)
+}
+
+// latFix rejects latitudes beyond the poles.
+func latFix(x float64) float64 {
+	if math.Abs(x) > 90 { //nolint: gomnd
+		return math.NaN()
+	}
+
+	return x
+}
+
+// sincosdx computes the sine and the cosine of an angle in degrees, exactly for
+// multiples of 90.
+func sincosdx(x float64) (float64, float64) {
+	r := math.Remainder(x, 90)         //nolint: gomnd
+	q := int(math.Round((x - r) / 90)) //nolint: gomnd
+
+	s, c := math.Sincos(r * degree)
+
+	var sinx, cosx float64
+
+	switch q & 3 { //nolint: gomnd
+	case 0:
+		sinx, cosx = s, c
+	case 1:
+		sinx, cosx = c, -s
+	case 2: //nolint: gomnd
+		sinx, cosx = -s, -c
+	default:
+		sinx, cosx = -c, s
+	}
+
+	if x != 0 {
+		sinx += 0
+		cosx += 0
+	}
+
+	return sinx, cosx
+}
+
+// atan2dx computes atan2(y, x) in degrees, exactly for the multiples of 90.
+func atan2dx(y float64, x float64) float64 {
+	q := 0
+
+	if math.Abs(y) > math.Abs(x) {
+		x, y = y, x
+		q = 2
+	}
+
+	if x < 0 {
+		x = -x
+		q++
+	}
+
+	ang := math.Atan2(y, x) / degree
+
+	switch q {
+	case 1:
+		if y >= 0 {
+			ang = 180 - ang //nolint: gomnd
+		} else {
+			ang = -180 - ang //nolint: gomnd
+		}
+	case 2: //nolint: gomnd
+		ang = 90 - ang //nolint: gomnd
+	case 3: //nolint: gomnd
+		ang = -90 + ang //nolint: gomnd
+	}
+
+	return ang
+}
@@ -0,0 +1,70 @@
+package geodesic
+
+import (
+	"math"
+)
+
+const (
+	vincentyIterations = 200
+	vincentyTolerance  = 1e-12
+)
+
+// Vincenty computes the length of the geodesic between two positions with the
+// iterative formula of T. Vincenty (1975), accurate to about 0.5 mm. It fails
+// with ErrNotConverged for nearly antipodal positions: prefer Distance, which
+// always converges.
+//
+// nolint: gomnd
+func (e *Ellipsoid) Vincenty(lat1 float64, lon1 float64, lat2 float64, lon2 float64) (float64, error) {
+	lon12, _ := angDiff(lon1, lon2)
+	lon12 *= degree
+
+	sinU1, cosU1 := reducedLatitude(e.f1, lat1)
+	sinU2, cosU2 := reducedLatitude(e.f1, lat2)
+
+	lambda := lon12
+
+	for iteration := 0; iteration < vincentyIterations; iteration++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// Coincident positions.
+			return 0, nil
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM := 0.0
+		if cos2Alpha != 0 {
+			// Not along the equator.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := e.f / 16 * cos2Alpha * (4 + e.f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = lon12 + (1-c)*e.f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-previous) < vincentyTolerance {
+			u2 := cos2Alpha * e.ep2
+			a := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+			b := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+			deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+			return e.b * a * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, ErrNotConverged
+}
+
+// reducedLatitude computes the sine and the cosine of the reduced latitude.
+func reducedLatitude(f1 float64, lat float64) (float64, float64) {
+	sinLat, cosLat := sincosdx(lat)
+
+	return norm2(f1*sinLat, cosLat)
+}
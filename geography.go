@@ -0,0 +1,167 @@
+package gogis
+
+import (
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/landru29/gogis/geodesic"
+)
+
+// Geodesic measurements on the WGS84 ellipsoid, as PostGIS computes them on
+// geography: X is the longitude and Y the latitude, in degrees; distances are
+// in meters and areas in square meters. Z and M are ignored. The Geodesic
+// methods match ST_Distance, ST_Length, ST_Perimeter and ST_Area on geography;
+// the Haversine methods are faster spherical approximations, as
+// ST_Distance(geography, false).
+
+// GeodesicDistance computes the distance to the other point on the WGS84
+// ellipsoid. It is NaN when a point is empty.
+func (p Point) GeodesicDistance(other Point) float64 {
+	return pointDistance(p, other, geodesic.WGS84.Distance)
+}
+
+// HaversineDistance computes the distance to the other point on the sphere of
+// the mean radius of WGS84. It is NaN when a point is empty.
+func (p Point) HaversineDistance(other Point) float64 {
+	return pointDistance(p, other, geodesic.WGS84.Haversine)
+}
+
+// GeodesicLength computes the length of the linestring on the WGS84 ellipsoid.
+func (l LineString) GeodesicLength() float64 {
+	return geodesicLength(l.ToEWKB(), geodesic.WGS84.Distance)
+}
+
+// HaversineLength computes the length of the linestring on the sphere of the
+// mean radius of WGS84.
+func (l LineString) HaversineLength() float64 {
+	return geodesicLength(l.ToEWKB(), geodesic.WGS84.Haversine)
+}
+
+// GeodesicLength computes the sum of the lengths of the linestrings on the WGS84 ellipsoid.
+func (m MultiLineString) GeodesicLength() float64 {
+	return geodesicLength(m.ToEWKB(), geodesic.WGS84.Distance)
+}
+
+// GeodesicArea computes the area of the polygon on the WGS84 ellipsoid: the
+// area of the shell minus the area of the holes, whatever their orientation.
+func (p Polygon) GeodesicArea() float64 {
+	return geodesicArea(p.ToEWKB())
+}
+
+// GeodesicPerimeter computes the length of the rings of the polygon on the WGS84 ellipsoid.
+func (p Polygon) GeodesicPerimeter() float64 {
+	return geodesicPerimeter(p.ToEWKB())
+}
+
+// GeodesicArea computes the sum of the areas of the polygons on the WGS84 ellipsoid.
+func (p MultiPolygon) GeodesicArea() float64 {
+	return geodesicArea(p.ToEWKB())
+}
+
+// GeodesicPerimeter computes the sum of the perimeters of the polygons on the WGS84 ellipsoid.
+func (p MultiPolygon) GeodesicPerimeter() float64 {
+	return geodesicPerimeter(p.ToEWKB())
+}
+
+// latLonDistance is the distance between two positions, latitude first.
+type latLonDistance func(lat1 float64, lon1 float64, lat2 float64, lon2 float64) float64
+
+func pointDistance(from Point, to Point, distance latLonDistance) float64 {
+	start, end := xyOf(from.Coordinate), xyOf(to.Coordinate)
+
+	if from.Coordinate.Coord().IsEmpty() || to.Coordinate.Coord().IsEmpty() || !start.isFinite() || !end.isFinite() {
+		return math.NaN()
+	}
+
+	return distance(start.y, start.x, end.y, end.x)
+}
+
+func geodesicLength(geometry ewkb.Geometry, distance latLonDistance) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.LineString:
+		output = lonLatLength(xysOf(geo.CoordinateSet), distance)
+	case *ewkb.MultiLineString:
+		for idx := range geo.LineStrings {
+			output += geodesicLength(&geo.LineStrings[idx], distance)
+		}
+	}
+
+	return output
+}
+
+func geodesicArea(geometry ewkb.Geometry) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.Polygon:
+		for idx, ring := range geo.CoordinateGroup {
+			ringArea := lonLatArea(xysOf(ring))
+			if idx > 0 {
+				ringArea = -ringArea
+			}
+
+			output += ringArea
+		}
+	case *ewkb.MultiPolygon:
+		for idx := range geo.Polygons {
+			output += geodesicArea(&geo.Polygons[idx])
+		}
+	}
+
+	return output
+}
+
+func geodesicPerimeter(geometry ewkb.Geometry) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.Polygon:
+		for _, ring := range geo.CoordinateGroup {
+			output += lonLatLength(xysOf(ring), geodesic.WGS84.Distance)
+		}
+	case *ewkb.MultiPolygon:
+		for idx := range geo.Polygons {
+			output += geodesicPerimeter(&geo.Polygons[idx])
+		}
+	}
+
+	return output
+}
+
+// lonLatLength computes the length of the segments; segments with a non
+// finite end are ignored.
+func lonLatLength(line []xy, distance latLonDistance) float64 {
+	output := 0.0
+
+	for idx := 1; idx < len(line); idx++ {
+		from, to := line[idx-1], line[idx]
+		if !from.isFinite() || !to.isFinite() {
+			continue
+		}
+
+		output += distance(from.y, from.x, to.y, to.x)
+	}
+
+	return output
+}
+
+// lonLatArea computes the absolute area of a ring; non finite vertices are ignored.
+func lonLatArea(ring []xy) float64 {
+	latitudes := make([]float64, 0, len(ring))
+	longitudes := make([]float64, 0, len(ring))
+
+	for _, position := range ring {
+		if !position.isFinite() {
+			continue
+		}
+
+		latitudes = append(latitudes, position.y)
+		longitudes = append(longitudes, position.x)
+	}
+
+	area, _ := geodesic.WGS84.PolygonArea(latitudes, longitudes)
+
+	return math.Abs(area)
+}
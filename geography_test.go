@@ -0,0 +1,58 @@
+package gogis_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeodesic(t *testing.T) {
+	jfk := gogis.Point{Coordinate: ewkb.Coordinate{'x': -73.8, 'y': 40.6}}
+	lhr := gogis.Point{Coordinate: ewkb.Coordinate{'x': -0.5, 'y': 51.6}}
+
+	t.Run("distance", func(t *testing.T) {
+		// SELECT ST_Distance('POINT(-73.8 40.6)'::geography, 'POINT(-0.5 51.6)'::geography).
+		assert.InDelta(t, 5551759.400319, jfk.GeodesicDistance(lhr), 1e-6)
+		assert.InDelta(t, 5551759.400319, jfk.HaversineDistance(lhr), 5551759.400319*0.005)
+		assert.True(t, math.IsNaN(jfk.GeodesicDistance(gogis.Point{})))
+	})
+
+	t.Run("length", func(t *testing.T) {
+		// A quarter of the equator, then a quarter meridian.
+		lineString := line(0, 0, 45, 0, 90, 0, 90, 90)
+
+		assert.InDelta(t, math.Pi*6378137/2+10001965.729, lineString.GeodesicLength(), 1e-3)
+		assert.InDelta(t, 20015114.352, lineString.HaversineLength(), 1e-3)
+
+		multiLineString := gogis.MultiLineString{lineString, line(0, 0, 45, 0)}
+		assert.InDelta(t, math.Pi*6378137*3/4+10001965.729, multiLineString.GeodesicLength(), 1e-3)
+	})
+
+	t.Run("area", func(t *testing.T) {
+		// An octant of the ellipsoid, in either orientation.
+		octant := 510065621724088.4 / 8
+		shell := line(0, 0, 90, 0, 0, 90, 0, 0)
+
+		assert.InDelta(t, octant, gogis.Polygon{shell}.GeodesicArea(), 1)
+		assert.InDelta(t, octant, gogis.Polygon{line(0, 0, 0, 90, 90, 0, 0, 0)}.GeodesicArea(), 1)
+		assert.InDelta(t, 30022685.630, gogis.Polygon{shell}.GeodesicPerimeter(), 1e-3)
+
+		// One degree square hole near the equator: about 12108 km².
+		hole := line(10, 10, 11, 10, 11, 11, 10, 11, 10, 10)
+		polygon := gogis.Polygon{shell, hole}
+
+		assert.InDelta(t, octant-lineArea(hole), polygon.GeodesicArea(), 1)
+		assert.InDelta(t, 12108e6, lineArea(hole), 1e6)
+
+		multiPolygon := gogis.MultiPolygon{polygon, {hole}}
+		assert.InDelta(t, octant, multiPolygon.GeodesicArea(), 1)
+		assert.InDelta(t, polygon.GeodesicPerimeter()+gogis.Polygon{hole}.GeodesicPerimeter(), multiPolygon.GeodesicPerimeter(), 1e-6)
+	})
+}
+
+func lineArea(ring gogis.LineString) float64 {
+	return gogis.Polygon{ring}.GeodesicArea()
+}
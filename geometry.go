@@ -1,9 +1,7 @@
 package gogis
 
 import (
-	"bytes"
 	"database/sql/driver"
-	"encoding/hex"
 
 	"github.com/landru29/gogis/ewkb"
 )
@@ -24,6 +22,7 @@ type Geometry struct {
 	Valid    bool
 
 	wellknown BindSet
+	decoding  decoding
 }
 
 // NewGeometry creates a new Geometry.
@@ -39,7 +38,11 @@ func NewGeometry(opts ...func(interface{})) *Geometry {
 
 // Scan implements the SQL driver.Scanner interface.
 func (g *Geometry) Scan(value interface{}) error {
-	if value == nil {
+	if isNullValue(value) {
+		g.Type = 0
+		g.Geometry = nil
+		g.Valid = false
+
 		return nil
 	}
 
@@ -52,16 +55,12 @@ func (g *Geometry) Scan(value interface{}) error {
 		return ewkb.ErrIncompatibleFormat
 	}
 
-	record, err := ewkb.DecodeHeader(
-		hex.NewDecoder(
-			bytes.NewBuffer(dataByte),
-		),
-	)
+	summary, err := ewkb.Peek(dataByte)
 	if err != nil {
 		return err
 	}
 
-	g.Type = record.Type
+	g.Type = summary.Type
 
 	wellknown := g.wellknown
 
@@ -70,14 +69,22 @@ func (g *Geometry) Scan(value interface{}) error {
 	}
 
 	for _, bind := range wellknown {
-		if bind.ewkbType.Type() == record.Type {
-			if err := bind.ewkbType.UnmarshalEWBK(*record); err != nil {
+		if bind.ewkbType.Type() == summary.Type {
+			// Fresh instances, so that bindings are never shared between scanned geometries.
+			geometry := wellknown.newEWKB(bind)
+
+			if err := unmarshal(geometry, dataByte, g.decoding); err != nil {
+				return err
+			}
+
+			model, err := BindSet{bind}.pick(summary.Type)
+			if err != nil {
 				return err
 			}
 
-			g.Geometry = bind.modelType
+			g.Geometry = model
 
-			err := bind.modelType.FromEWKB(bind.ewkbType)
+			err = model.FromEWKB(geometry)
 			if err == nil {
 				g.Valid = true
 			}
@@ -89,6 +96,32 @@ func (g *Geometry) Scan(value interface{}) error {
 	return ewkb.ErrWrongGeometryType
 }
 
+// State is the NULL / EMPTY / value state of the geometry.
+func (g Geometry) State() State {
+	converter, ok := g.Geometry.(EWKBConverter)
+	if !ok || isNilModel(converter) {
+		return StateNull
+	}
+
+	return stateOf(g.Valid, converter)
+}
+
+// IsEmpty checks if the geometry is EMPTY. A NULL geometry is not empty.
+func (g Geometry) IsEmpty() bool {
+	return g.State() == StateEmpty
+}
+
+// Envelope computes the extent of the geometry. A NULL geometry has an empty envelope.
+func (g Geometry) Envelope() ewkb.Box {
+	if g.State() == StateNull {
+		return ewkb.EmptyBox()
+	}
+
+	converter, _ := g.Geometry.(EWKBConverter)
+
+	return ewkb.EnvelopeOf(converter.ToEWKB())
+}
+
 // Value implements the driver Valuer interface.
 func (g *Geometry) Value() (driver.Value, error) {
 	if !g.Valid || g.Geometry == nil {
@@ -1,84 +1,40 @@
 package gogis
 
 import (
-	"bytes"
 	"database/sql/driver"
-
-	"github.com/landru29/gogis/ewkb"
-)
-
-const (
-	geometryArrayMinSize = 2
 )
 
-// GeometryArray is an array of geometries.
+// GeometryArray is an array of geometries (geometry[] in database).
+//
+// NULL elements are scanned as invalid geometries, and invalid geometries
+// are written as NULL. Multi-dimensional arrays are flattened.
 type GeometryArray []Geometry
 
 // Scan implements the SQL driver.Scanner interface.
 func (g *GeometryArray) Scan(value interface{}) error {
-	if value == nil {
-		return nil
-	}
-
-	if strData, ok := value.(string); ok {
-		return g.Scan([]byte(strData))
-	}
-
-	dataByte, ok := value.([]byte)
-	if !ok {
-		return ewkb.ErrIncompatibleFormat
-	}
-
-	if dataByte == nil {
-		return nil
-	}
-
-	if len(dataByte) < geometryArrayMinSize {
-		return ewkb.ErrIncompatibleFormat
-	}
-
-	splitted := bytes.Split(dataByte[1:len(dataByte)-1], []byte(":"))
-
-	*g = make(GeometryArray, len(splitted))
-
-	for idx, datab := range splitted {
-		geom := Geometry{}
-		if err := (&geom).Scan(datab); err != nil {
-			return err
-		}
-
-		(*g)[idx] = geom
-	}
-
-	return nil
+	return scanArray(
+		value,
+		true,
+		func(size int) {
+			*g = make(GeometryArray, size)
+		},
+		func(idx int, element []byte) error {
+			if element == nil {
+				return nil
+			}
+
+			return (&(*g)[idx]).Scan(element)
+		},
+	)
 }
 
 // Value implements the driver Valuer interface.
 func (g GeometryArray) Value() (driver.Value, error) {
-	if len(g) == 0 {
+	if g == nil {
 		return nil, nil
 	}
 
-	ewkbData := make([][]byte, len(g))
-
-	for idx, geo := range g {
-		drvValue, err := geo.Value()
-		if err != nil {
-			return nil, err
-		}
-
-		val, _ := drvValue.([]byte)
-
-		if idx == 0 {
-			ewkbData[idx] = []byte("{")
-		}
-
-		ewkbData[idx] = append(ewkbData[idx], val...)
-
-		if idx == len(g)-1 {
-			ewkbData[idx] = append(val, '}')
-		}
-	}
-
-	return bytes.Join(ewkbData, []byte(":")), nil
+	return arrayValue(len(g), func(idx int) (driver.Value, error) {
+		return g[idx].Value()
+	})
 }
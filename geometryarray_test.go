@@ -5,6 +5,8 @@ import (
 
 	"github.com/landru29/gogis"
 	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGeometryArray(t *testing.T) {
@@ -109,7 +111,131 @@ func TestGeometryArray(t *testing.T) {
 	t.Run("value null data", func(t *testing.T) {
 		valueTest(t, testFixtureValue{
 			expectedRawData: nil,
+			valuer:          gogis.GeometryArray(nil),
+		})
+	})
+
+	t.Run("value empty", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: []byte("{}"),
 			valuer:          gogis.GeometryArray{},
 		})
 	})
+
+	t.Run("value single element", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: []byte("{" + pointOneTwo + "}"),
+			valuer:          gogis.GeometryArray{fixturePointOneTwo().Geometry()},
+		})
+	})
+}
+
+const (
+	// SELECT 'POINT(1 2)'::geometry.
+	pointOneTwo = "0101000000000000000000F03F0000000000000040"
+
+	// SELECT 'POINT(3 4)'::geometry.
+	pointThreeFour = "010100000000000000000008400000000000001040"
+)
+
+func fixturePointOneTwo() gogis.Point {
+	return gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}}
+}
+
+func fixturePointThreeFour() gogis.Point {
+	return gogis.Point{Coordinate: ewkb.Coordinate{'x': 3, 'y': 4}}
+}
+
+func TestGeometryArrayLiteral(t *testing.T) {
+	fixtures := []struct {
+		title    string
+		rawData  string
+		expected gogis.GeometryArray
+		value    string
+	}{
+		{
+			title:    "empty",
+			rawData:  "{}",
+			expected: gogis.GeometryArray{},
+			value:    "{}",
+		},
+		{
+			// SELECT array_agg(g) FROM (VALUES (NULL::geometry), ('POINT(1 2)'), (NULL)) AS v(g).
+			title:   "NULL elements",
+			rawData: "{NULL:" + pointOneTwo + ":NULL}",
+			expected: gogis.GeometryArray{
+				{},
+				fixturePointOneTwo().Geometry(),
+				{},
+			},
+			value: "{NULL:" + pointOneTwo + ":NULL}",
+		},
+		{
+			title:   "quoted elements and spaces",
+			rawData: ` { "` + pointOneTwo + `" : ` + pointThreeFour + ` : null } `,
+			expected: gogis.GeometryArray{
+				fixturePointOneTwo().Geometry(),
+				fixturePointThreeFour().Geometry(),
+				{},
+			},
+		},
+		{
+			// SELECT ARRAY[['POINT(1 2)'::geometry, 'POINT(3 4)'], ['POINT(3 4)', 'POINT(1 2)']].
+			title:   "two dimensions",
+			rawData: "{{" + pointOneTwo + ":" + pointThreeFour + "}:{" + pointThreeFour + ":" + pointOneTwo + "}}",
+			expected: gogis.GeometryArray{
+				fixturePointOneTwo().Geometry(),
+				fixturePointThreeFour().Geometry(),
+				fixturePointThreeFour().Geometry(),
+				fixturePointOneTwo().Geometry(),
+			},
+			value: "{" + pointOneTwo + ":" + pointThreeFour + ":" + pointThreeFour + ":" + pointOneTwo + "}",
+		},
+		{
+			title:   "dimension decoration",
+			rawData: "[0:1]={" + pointOneTwo + ":" + pointThreeFour + "}",
+			expected: gogis.GeometryArray{
+				fixturePointOneTwo().Geometry(),
+				fixturePointThreeFour().Geometry(),
+			},
+			value: "{" + pointOneTwo + ":" + pointThreeFour + "}",
+		},
+	}
+
+	for idx := range fixtures {
+		fixture := fixtures[idx]
+
+		t.Run(fixture.title, func(t *testing.T) {
+			array := gogis.GeometryArray{}
+
+			require.NoError(t, array.Scan(fixture.rawData))
+			assert.Equal(t, fixture.expected, array)
+
+			if fixture.value != "" {
+				valueTest(t, testFixtureValue{
+					expectedRawData: []byte(fixture.value),
+					valuer:          array,
+				})
+			}
+		})
+	}
+}
+
+func TestGeometryArrayMalformed(t *testing.T) {
+	for _, rawData := range []string{
+		"",
+		"{",
+		"}",
+		pointOneTwo,
+		"{" + pointOneTwo,
+		"{" + pointOneTwo + "::" + pointOneTwo + "}",
+		"{" + pointOneTwo + "}}",
+		"{{" + pointOneTwo + "}:{" + pointOneTwo + ":" + pointOneTwo + "}}",
+		"{" + pointOneTwo + ":{" + pointOneTwo + "}}",
+		`{"` + pointOneTwo + "}",
+	} {
+		array := gogis.GeometryArray{}
+
+		assert.ErrorIs(t, array.Scan(rawData), gogis.ErrMalformedArray, rawData)
+	}
 }
@@ -13,6 +13,7 @@ type GeometryCollection struct {
 	SRID       *ewkb.SystemReferenceID
 
 	wellknown BindSet
+	decoding  decoding
 }
 
 // NewGeometryCollection creates a new empty collection.
@@ -60,14 +61,9 @@ func (g *GeometryCollection) Scan(value interface{}) error {
 		wellknownB = globalWellknownBindings
 	}
 
-	wellKnown := make([]ewkb.Geometry, len(wellknownB))
-	for idx, binding := range wellknownB {
-		wellKnown[idx] = binding.ewkbType
-	}
-
-	collection := ewkb.NewGeometryCollection(wellKnown...)
+	collection := wellknownB.newEWKB(Bind(&ewkb.GeometryCollection{}, g))
 
-	if err := ewkb.Unmarshal(collection, value); err != nil {
+	if err := unmarshal(collection, value, g.decoding); err != nil {
 		return err
 	}
 
@@ -92,9 +88,15 @@ func (g *GeometryCollection) FromEWKB(from interface{}) error {
 
 	g.SRID = collection.SRID
 
+	wellknown := g.wellknown
+
+	if len(wellknown) == 0 {
+		wellknown = globalWellknownBindings
+	}
+
 	g.Collection = make([]ModelConverter, len(collection.Collection))
 	for idx, geo := range collection.Collection {
-		converter, err := g.wellknown.pick(geo.Type())
+		converter, err := wellknown.pick(geo.Type())
 		if err != nil {
 			return err
 		}
@@ -128,6 +130,17 @@ func (g GeometryCollection) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return collection
 }
 
+// IsEmpty checks if the collection has no geometry, or only empty geometries.
+func (g GeometryCollection) IsEmpty() bool {
+	for _, geo := range g.Collection {
+		if !isNilModel(geo) && !isEmptyModel(geo) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Geometry converts to a generic geometry.
 func (g GeometryCollection) Geometry(opts ...func(interface{})) Geometry {
 	output := Geometry{
@@ -142,3 +155,8 @@ func (g GeometryCollection) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the geometries of the collection.
+func (g GeometryCollection) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(g.ToEWKB())
+}
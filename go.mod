@@ -1,6 +1,6 @@
 module github.com/landru29/gogis
 
-go 1.17
+go 1.18
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.0
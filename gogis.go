@@ -111,9 +111,14 @@ import (
 
 // ModelConverter is the converter from EWKB to Model.
 type ModelConverter interface {
+	EWKBConverter
+
 	// FromEWKB converts EWKB data type to model.
 	FromEWKB(geometry interface{}) error
+}
 
+// EWKBConverter is the converter from Model to EWKB.
+type EWKBConverter interface {
 	// ToEWKB converts model to EWKB.
 	ToEWKB() ewkb.Geometry
 }
@@ -0,0 +1,411 @@
+package gogis
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// ConvexHull computes the smallest convex geometry containing all the vertices
+// of the geometry, as ST_ConvexHull does, with the Andrew monotone chain
+// algorithm: a polygon, a linestring when the vertices are collinear, a point
+// when they are all the same, and an empty geometry collection when there is
+// none. Z and M are dropped, and curves are not supported (ErrUnsupportedGeometry).
+func ConvexHull(geometry EWKBConverter) (Geometry, error) {
+	positions, srid, err := hullPositions(geometry)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	return modelOf(hullGeometry(convexHull(positions), srid))
+}
+
+// ConcaveHull computes a polygon containing all the vertices of the geometry,
+// as ST_ConcaveHull does (without holes): the border triangles of the Delaunay
+// triangulation of the vertices are removed, longest edge first, while their
+// longest edge is longer than the ratio of the range of the edge lengths. A
+// ratio of 1 gives the convex hull, a ratio of 0 the most concave polygon. The
+// vertices are never removed from the hull, and the polygon is never split.
+//
+//	footprint, err := gogis.ConcaveHull(stops, 0.3)
+//
+// Collinear vertices give the convex hull (a point or a linestring).
+func ConcaveHull(geometry EWKBConverter, ratio float64) (Geometry, error) {
+	positions, srid, err := hullPositions(geometry)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	convex := convexHull(positions)
+	if len(convex) < 4 || ratio >= 1 { //nolint: gomnd
+		return modelOf(hullGeometry(convex, srid))
+	}
+
+	return modelOf(hullGeometry(concaveHull(positions, math.Max(ratio, 0)), srid))
+}
+
+// hullPositions lists the distinct vertices of a geometry.
+func hullPositions(geometry EWKBConverter) ([]xy, *ewkb.SystemReferenceID, error) {
+	geo := geometry.ToEWKB()
+
+	source, err := newRelateGeometry(geo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := map[xy]bool{}
+	output := []xy{}
+
+	add := func(positions ...xy) {
+		for _, position := range positions {
+			if !seen[position] {
+				seen[position] = true
+				output = append(output, position)
+			}
+		}
+	}
+
+	add(source.points...)
+
+	for _, line := range source.lines {
+		add(line...)
+	}
+
+	for _, polygon := range source.polygons {
+		for _, ring := range polygon {
+			add(ring...)
+		}
+	}
+
+	return output, geo.SystemReferenceID(), nil
+}
+
+// hullGeometry converts a hull to a geometry: a closed ring is a polygon, two
+// positions a linestring, one position a point.
+func hullGeometry(hull []xy, srid *ewkb.SystemReferenceID) ewkb.Geometry { //nolint: ireturn
+	switch len(hull) {
+	case 0:
+		return emptyOf(srid, -1)
+	case 1:
+		return &ewkb.Point{SRID: srid, Coordinate: ewkb.Coordinate{'x': hull[0].x, 'y': hull[0].y}}
+	case 2: //nolint: gomnd
+		return &ewkb.LineString{SRID: srid, CoordinateSet: coordinatesOf(hull)}
+	}
+
+	return &ewkb.Polygon{SRID: srid, CoordinateGroup: ewkb.CoordinateGroup{coordinatesOf(hull)}}
+}
+
+// convexHull computes the counter clockwise closed ring of the convex hull of
+// distinct positions; it is the extreme positions when they are collinear.
+func convexHull(positions []xy) []xy {
+	sorted := append([]xy{}, positions...)
+
+	sort.Slice(sorted, func(i int, j int) bool {
+		if sorted[i].x == sorted[j].x {
+			return sorted[i].y < sorted[j].y
+		}
+
+		return sorted[i].x < sorted[j].x
+	})
+
+	if len(sorted) < 3 { //nolint: gomnd
+		return sorted
+	}
+
+	hull := make([]xy, 0, 2*len(sorted))
+
+	// The lower chain from left to right, then the upper chain from right to left.
+	for _, chain := range [][]xy{sorted, reversed(sorted)} {
+		start := len(hull)
+
+		for _, position := range chain {
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], position) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+
+			hull = append(hull, position)
+		}
+
+		hull = hull[:len(hull)-1]
+	}
+
+	if len(hull) < 3 { //nolint: gomnd
+		return []xy{sorted[0], sorted[len(sorted)-1]}
+	}
+
+	return append(hull, hull[0])
+}
+
+func reversed(positions []xy) []xy {
+	output := make([]xy, len(positions))
+
+	for idx, position := range positions {
+		output[len(positions)-1-idx] = position
+	}
+
+	return output
+}
+
+// concaveHull removes the border triangles of the Delaunay triangulation of the
+// positions, and returns the closed ring of the remaining triangles.
+func concaveHull(positions []xy, ratio float64) []xy {
+	triangles := delaunay(positions)
+
+	hull := hullTriangulation{
+		positions: positions,
+		triangles: triangles,
+		alive:     make([]bool, len(triangles)),
+		neighbors: make([][3]int, len(triangles)),
+		border:    map[int]bool{},
+	}
+
+	edges := map[[2]int]int{}
+	minimum, maximum := math.Inf(1), 0.0
+
+	for idx, triangle := range triangles {
+		hull.alive[idx] = true
+		hull.neighbors[idx] = [3]int{-1, -1, -1}
+
+		for side := 0; side < 3; side++ {
+			from, to := triangle[side], triangle[(side+1)%3]
+
+			if other, ok := edges[[2]int{to, from}]; ok {
+				hull.neighbors[idx][side] = other
+
+				for otherSide := 0; otherSide < 3; otherSide++ {
+					if triangles[other][otherSide] == to {
+						hull.neighbors[other][otherSide] = idx
+					}
+				}
+			}
+
+			edges[[2]int{from, to}] = idx
+			length := distance(positions[from], positions[to])
+			minimum, maximum = math.Min(minimum, length), math.Max(maximum, length)
+		}
+	}
+
+	threshold := minimum + ratio*(maximum-minimum)
+	queue := &triangleQueue{}
+
+	for idx := range triangles {
+		for side := 0; side < 3; side++ {
+			if hull.neighbors[idx][side] < 0 {
+				hull.border[triangles[idx][side]] = true
+				hull.border[triangles[idx][(side+1)%3]] = true
+			}
+		}
+	}
+
+	for idx := range triangles {
+		if hull.borderSide(idx) >= 0 {
+			heap.Push(queue, triangleLength{index: idx, length: hull.longestEdge(idx)})
+		}
+	}
+
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(triangleLength) //nolint: forcetypeassert
+		if current.length <= threshold {
+			break
+		}
+
+		if !hull.alive[current.index] || !hull.removable(current.index) {
+			continue
+		}
+
+		hull.remove(current.index)
+
+		for _, neighbor := range hull.neighbors[current.index] {
+			if neighbor >= 0 && hull.alive[neighbor] {
+				heap.Push(queue, triangleLength{index: neighbor, length: hull.longestEdge(neighbor)})
+			}
+		}
+	}
+
+	return hull.ring()
+}
+
+// hullTriangulation is a triangulation whose border triangles are removed: the
+// sides of the counter clockwise triangle i are (i, i+1), neighbors is the
+// triangle across each side (-1 when none), and border the vertices on the border.
+type hullTriangulation struct {
+	positions []xy
+	triangles [][3]int
+	alive     []bool
+	neighbors [][3]int
+	border    map[int]bool
+}
+
+func (h hullTriangulation) onBorder(triangle int, side int) bool {
+	neighbor := h.neighbors[triangle][side]
+
+	return neighbor < 0 || !h.alive[neighbor]
+}
+
+// borderSide is the only side of the triangle on the border, or -1.
+func (h hullTriangulation) borderSide(triangle int) int {
+	output := -1
+
+	for side := 0; side < 3; side++ {
+		if h.onBorder(triangle, side) {
+			if output >= 0 {
+				return -1
+			}
+
+			output = side
+		}
+	}
+
+	return output
+}
+
+// removable checks whether the triangle has a single side on the border, and
+// whether its opposite vertex is not on the border: removing it would split
+// the polygon, or drop a vertex.
+func (h hullTriangulation) removable(triangle int) bool {
+	side := h.borderSide(triangle)
+
+	return side >= 0 && !h.border[h.triangles[triangle][(side+2)%3]]
+}
+
+func (h hullTriangulation) remove(triangle int) {
+	h.alive[triangle] = false
+	h.border[h.triangles[triangle][(h.borderSide(triangle)+2)%3]] = true
+}
+
+func (h hullTriangulation) longestEdge(triangle int) float64 {
+	output := 0.0
+
+	for side := 0; side < 3; side++ {
+		from, to := h.triangles[triangle][side], h.triangles[triangle][(side+1)%3]
+		output = math.Max(output, distance(h.positions[from], h.positions[to]))
+	}
+
+	return output
+}
+
+// ring links the border sides of the remaining triangles, counter clockwise.
+func (h hullTriangulation) ring() []xy {
+	next := map[int]int{}
+	start := -1
+
+	for idx, triangle := range h.triangles {
+		if !h.alive[idx] {
+			continue
+		}
+
+		for side := 0; side < 3; side++ {
+			if h.onBorder(idx, side) {
+				next[triangle[side]] = triangle[(side+1)%3]
+				start = triangle[side]
+			}
+		}
+	}
+
+	output := []xy{h.positions[start]}
+
+	for vertex := next[start]; vertex != start; vertex = next[vertex] {
+		output = append(output, h.positions[vertex])
+	}
+
+	return append(output, h.positions[start])
+}
+
+// triangleLength is the longest edge of a triangle.
+type triangleLength struct {
+	index  int
+	length float64
+}
+
+// triangleQueue is a max-heap of triangles by longest edge.
+type triangleQueue []triangleLength
+
+func (q triangleQueue) Len() int { return len(q) }
+
+func (q triangleQueue) Less(i int, j int) bool { return q[i].length > q[j].length }
+
+func (q triangleQueue) Swap(i int, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *triangleQueue) Push(value interface{}) {
+	*q = append(*q, value.(triangleLength)) //nolint: forcetypeassert
+}
+
+func (q *triangleQueue) Pop() interface{} {
+	old := *q
+	output := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return output
+}
+
+// delaunay triangulates distinct positions, not all collinear, with the
+// Bowyer-Watson algorithm; the triangles are counter clockwise.
+func delaunay(positions []xy) [][3]int {
+	box := ewkb.Box{XMin: positions[0].x, XMax: positions[0].x, YMin: positions[0].y, YMax: positions[0].y}
+
+	for _, position := range positions {
+		box.XMin, box.XMax = math.Min(box.XMin, position.x), math.Max(box.XMax, position.x)
+		box.YMin, box.YMax = math.Min(box.YMin, position.y), math.Max(box.YMax, position.y)
+	}
+
+	// A triangle far enough to contain the circumcircles of the hull triangles.
+	size := 1000 * math.Max(box.XMax-box.XMin, box.YMax-box.YMin)    //nolint: gomnd
+	centerX, centerY := (box.XMin+box.XMax)/2, (box.YMin+box.YMax)/2 //nolint: gomnd
+	all := append(append([]xy{}, positions...),
+		xy{x: centerX - size, y: centerY - size},
+		xy{x: centerX + size, y: centerY - size},
+		xy{x: centerX, y: centerY + size},
+	)
+
+	super := len(positions)
+	triangles := [][3]int{{super, super + 1, super + 2}}
+
+	for idx := range positions {
+		position := all[idx]
+		kept := triangles[:0:0]
+		edges := map[[2]int]int{}
+
+		for _, triangle := range triangles {
+			if !inCircle(all[triangle[0]], all[triangle[1]], all[triangle[2]], position) {
+				kept = append(kept, triangle)
+
+				continue
+			}
+
+			for side := 0; side < 3; side++ {
+				edges[[2]int{triangle[side], triangle[(side+1)%3]}]++
+			}
+		}
+
+		// The sides of the cavity are the sides of a single removed triangle.
+		for edge := range edges {
+			if edges[[2]int{edge[1], edge[0]}] == 0 {
+				kept = append(kept, [3]int{edge[0], edge[1], idx})
+			}
+		}
+
+		triangles = kept
+	}
+
+	output := [][3]int{}
+
+	for _, triangle := range triangles {
+		if triangle[0] < super && triangle[1] < super && triangle[2] < super {
+			output = append(output, triangle)
+		}
+	}
+
+	return output
+}
+
+// inCircle checks whether p is strictly inside the circumcircle of the counter
+// clockwise triangle (a, b, c).
+func inCircle(a xy, b xy, c xy, p xy) bool {
+	ax, ay := a.x-p.x, a.y-p.y
+	bx, by := b.x-p.x, b.y-p.y
+	cx, cy := c.x-p.x, c.y-p.y
+
+	return (ax*ax+ay*ay)*(bx*cy-cx*by)-(bx*bx+by*by)*(ax*cy-cx*ay)+(cx*cx+cy*cy)*(ax*by-bx*ay) > 0
+}
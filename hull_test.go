@@ -0,0 +1,159 @@
+package gogis_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func points(values ...float64) *gogis.MultiPoint {
+	output := gogis.MultiPoint{}
+
+	for idx := 0; idx+1 < len(values); idx += 2 {
+		output = append(output, gogis.Point{Coordinate: ewkb.Coordinate{'x': values[idx], 'y': values[idx+1]}})
+	}
+
+	return &output
+}
+
+func TestConvexHull(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		geometry gogis.EWKBConverter
+		expected gogis.EWKBConverter
+	}{
+		{
+			name:     "multipoint",
+			geometry: points(0, 0, 10, 0, 5, 5, 10, 10, 0, 10, 2, 8, 5, 0),
+			expected: &gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)},
+		},
+		{
+			name:     "concave polygon",
+			geometry: &gogis.Polygon{line(0, 0, 10, 0, 5, 5, 10, 10, 0, 10, 0, 0)},
+			expected: &gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)},
+		},
+		{
+			name:     "collinear",
+			geometry: line(0, 0, 1, 1, 3, 3, 2, 2),
+			expected: line(0, 0, 3, 3),
+		},
+		{
+			name:     "same points",
+			geometry: points(1, 2, 1, 2),
+			expected: &gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := gogis.ConvexHull(testCase.geometry)
+			require.NoError(t, err)
+
+			converter, ok := result.Geometry.(gogis.EWKBConverter)
+			require.True(t, ok)
+			assert.Equal(t, testCase.expected.ToEWKB().Type(), result.Type)
+
+			equals, err := gogis.Equals(converter, testCase.expected)
+			require.NoError(t, err)
+			assert.True(t, equals)
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := gogis.ConvexHull(&gogis.MultiPoint{})
+		require.NoError(t, err)
+		assert.Equal(t, ewkb.GeometryTypeGeometryCollection, result.Type)
+		assert.True(t, result.IsEmpty())
+	})
+
+	t.Run("curve", func(t *testing.T) {
+		_, err := gogis.ConvexHull(gogis.CircularString(line(0, 0, 1, 1, 2, 0)))
+		require.ErrorIs(t, err, gogis.ErrUnsupportedGeometry)
+	})
+}
+
+func TestConcaveHull(t *testing.T) {
+	// A U shape: the points along the sides and the bottom of a square, and
+	// not in its upper middle.
+	values := []float64{}
+
+	for x := 0.0; x <= 10; x++ {
+		for y := 0.0; y <= 10; y++ {
+			if x <= 2 || x >= 8 || y <= 2 {
+				values = append(values, x, y)
+			}
+		}
+	}
+
+	stops := points(values...)
+
+	t.Run("convex", func(t *testing.T) {
+		result, err := gogis.ConcaveHull(stops, 1)
+		require.NoError(t, err)
+
+		polygon, ok := result.Geometry.(*gogis.Polygon)
+		require.True(t, ok)
+		assert.InDelta(t, 100, polygon.Area(), 1e-9)
+	})
+
+	t.Run("concave", func(t *testing.T) {
+		result, err := gogis.ConcaveHull(stops, 0.1)
+		require.NoError(t, err)
+
+		polygon, ok := result.Geometry.(*gogis.Polygon)
+		require.True(t, ok)
+		assert.Empty(t, gogis.Validate(polygon))
+
+		// The U is 100-6*8, the cocircular points of the grid leave some
+		// triangles in the corners of the gap.
+		assert.GreaterOrEqual(t, polygon.Area(), 100.0-6*8)
+		assert.Less(t, polygon.Area(), 60.0)
+
+		covers, err := gogis.Covers(polygon, stops)
+		require.NoError(t, err)
+		assert.True(t, covers)
+	})
+
+	t.Run("random stops", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1)) //nolint: gosec
+
+		for idx := 0; idx < 20; idx++ {
+			values := make([]float64, 200)
+			for valueIdx := range values {
+				values[valueIdx] = random.Float64() * 100
+			}
+
+			stops := points(values...)
+
+			convex, err := gogis.ConvexHull(stops)
+			require.NoError(t, err)
+
+			convexPolygon, ok := convex.Geometry.(*gogis.Polygon)
+			require.True(t, ok)
+
+			for _, ratio := range []float64{0, 0.1, 0.5} {
+				result, err := gogis.ConcaveHull(stops, ratio)
+				require.NoError(t, err)
+
+				polygon, ok := result.Geometry.(*gogis.Polygon)
+				require.True(t, ok)
+				assert.Empty(t, gogis.Validate(polygon))
+				assert.LessOrEqual(t, polygon.Area(), convexPolygon.Area()+1e-9)
+
+				covers, err := gogis.Covers(polygon, stops)
+				require.NoError(t, err)
+				assert.True(t, covers)
+			}
+		}
+	})
+
+	t.Run("collinear", func(t *testing.T) {
+		result, err := gogis.ConcaveHull(points(0, 0, 1, 1, 2, 2), 0)
+		require.NoError(t, err)
+		assert.Equal(t, ewkb.GeometryTypeLineString, result.Type)
+	})
+}
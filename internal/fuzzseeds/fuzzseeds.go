@@ -0,0 +1,34 @@
+// Package fuzzseeds is the seed corpus of the fuzz targets of gogis and ewkb:
+// the EWKB test vectors, and hostile sizes.
+package fuzzseeds
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata/seeds.txt
+var corpus string //nolint: gochecknoglobals
+
+// Add adds the seeds to the corpus of a fuzz target, as binaries.
+func Add(f *testing.F) {
+	f.Helper()
+
+	for idx, line := range strings.Split(corpus, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		data, err := hex.DecodeString(line)
+		if err != nil {
+			f.Fatalf("seed at line %d: %v", idx+1, err)
+		}
+
+		f.Add(data)
+	}
+
+	f.Add([]byte{})
+}
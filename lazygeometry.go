@@ -0,0 +1,110 @@
+package gogis
+
+import (
+	"database/sql/driver"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// LazyGeometry is any PostGIS geometry, decoded on first access.
+//
+// Scanning only reads the header (type, layout and SRID) and keeps the raw
+// data; the coordinates are decoded by the first call to Geometry:
+//
+//	lazy := gogis.NewLazyGeometry()
+//	err := rows.Scan(lazy)
+//	...
+//	switch lazy.Type {
+//	case ewkb.GeometryTypePoint:
+//		geometry, err := lazy.Geometry()
+//		...
+//	}
+type LazyGeometry struct {
+	Type   ewkb.GeometryType
+	Layout ewkb.Layout
+	SRID   *ewkb.SystemReferenceID
+	Size   int
+	Valid  bool
+
+	raw       []byte
+	decoded   *Geometry
+	err       error
+	wellknown BindSet
+	decoding  decoding
+}
+
+// NewLazyGeometry creates a new LazyGeometry.
+func NewLazyGeometry(opts ...func(interface{})) *LazyGeometry {
+	output := &LazyGeometry{}
+
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	return output
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (l *LazyGeometry) Scan(value interface{}) error {
+	wellknown, config := l.wellknown, l.decoding
+
+	*l = LazyGeometry{wellknown: wellknown, decoding: config}
+
+	if isNullValue(value) {
+		return nil
+	}
+
+	summary, err := ewkb.Peek(value)
+	if err != nil {
+		return err
+	}
+
+	// The driver may reuse its buffer after Scan.
+	switch data := value.(type) {
+	case string:
+		l.raw = []byte(data)
+	case []byte:
+		l.raw = append([]byte{}, data...)
+	}
+
+	l.Type = summary.Type
+	l.Layout = summary.Layout
+	l.SRID = summary.SRID
+	l.Size = summary.Size
+	l.Valid = true
+
+	return nil
+}
+
+// Geometry decodes the geometry. It is decoded once; next calls return the same result.
+func (l *LazyGeometry) Geometry() (*Geometry, error) {
+	if !l.Valid {
+		return &Geometry{}, nil
+	}
+
+	if l.decoded == nil && l.err == nil {
+		decoded := &Geometry{wellknown: l.wellknown, decoding: l.decoding}
+
+		if err := decoded.Scan(l.raw); err != nil {
+			l.err = err
+		} else {
+			l.decoded = decoded
+		}
+	}
+
+	return l.decoded, l.err
+}
+
+// Raw is the hexadecimal EWKB, as scanned.
+func (l LazyGeometry) Raw() []byte {
+	return l.raw
+}
+
+// Value implements the driver Valuer interface. The geometry is not decoded.
+func (l LazyGeometry) Value() (driver.Value, error) {
+	if !l.Valid {
+		return nil, nil
+	}
+
+	return l.raw, nil
+}
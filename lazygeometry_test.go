@@ -0,0 +1,90 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyGeometry(t *testing.T) {
+	pointOneTwoFixture := fixturePointOneTwo()
+
+	t.Run("scan with data", func(t *testing.T) {
+		rawData := []byte(pointOneTwo)
+
+		lazy := gogis.NewLazyGeometry()
+		require.NoError(t, lazy.Scan(rawData))
+
+		// The driver may reuse its buffer.
+		rawData[0] = 'F'
+
+		assert.True(t, lazy.Valid)
+		assert.Equal(t, ewkb.GeometryTypePoint, lazy.Type)
+		assert.Equal(t, ewkb.LayoutWith(false, false), lazy.Layout)
+		assert.Nil(t, lazy.SRID)
+		assert.Equal(t, len(pointOneTwo)/2, lazy.Size)
+		assert.Equal(t, []byte(pointOneTwo), lazy.Raw())
+
+		geometry, err := lazy.Geometry()
+		require.NoError(t, err)
+		assert.Equal(t, &pointOneTwoFixture, geometry.Geometry)
+
+		again, err := lazy.Geometry()
+		require.NoError(t, err)
+		assert.Same(t, geometry, again)
+
+		value, err := lazy.Value()
+		require.NoError(t, err)
+		assert.Equal(t, []byte(pointOneTwo), value)
+	})
+
+	t.Run("scan null data", func(t *testing.T) {
+		lazy := gogis.NewLazyGeometry()
+		require.NoError(t, lazy.Scan(pointOneTwo))
+		require.NoError(t, lazy.Scan(nil))
+
+		assert.False(t, lazy.Valid)
+		assert.Nil(t, lazy.Raw())
+
+		geometry, err := lazy.Geometry()
+		require.NoError(t, err)
+		assert.False(t, geometry.Valid)
+
+		value, err := lazy.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("decode on access", func(t *testing.T) {
+		// Header of a POINT with truncated coordinates.
+		lazy := gogis.NewLazyGeometry()
+		require.NoError(t, lazy.Scan("0101000000000000000000F03F"))
+		assert.Equal(t, ewkb.GeometryTypePoint, lazy.Type)
+
+		_, err := lazy.Geometry()
+		assert.Error(t, err)
+	})
+
+	t.Run("scan wrong header", func(t *testing.T) {
+		lazy := gogis.NewLazyGeometry()
+
+		assert.ErrorIs(t, lazy.Scan("0501000000"), ewkb.ErrWrongByteOrder)
+		assert.ErrorIs(t, lazy.Scan(42), ewkb.ErrIncompatibleFormat)
+	})
+
+	t.Run("custom binding", func(t *testing.T) {
+		lazy := gogis.NewLazyGeometry(
+			gogis.WithWellKnownGeometry(gogis.Bind(&customEWKB{}, &customModel{})),
+		)
+
+		require.NoError(t, lazy.Scan("012A000000000000000000F03F0000000000000040"))
+		assert.Equal(t, customGeometryType, lazy.Type)
+
+		geometry, err := lazy.Geometry()
+		require.NoError(t, err)
+		assert.Equal(t, &customModel{X: 1, Y: 2}, geometry.Geometry)
+	})
+}
@@ -10,6 +10,7 @@ import (
 type LineString []Point
 
 // NullLineString represents a LineString that may be null.
+// It has the same semantics as Null[*LineString].
 // NullLineString implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullLineString struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (l *NullLineString) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	linestring := ewkb.LineString{}
-
-	if err := ewkb.Unmarshal(&linestring, value); err != nil {
-		return err
-	}
-
-	l.Valid = true
-
-	return (&l.LineString).FromEWKB(linestring)
+	return scanNull(value, &l.LineString, &l.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (l *LineString) Scan(value interface{}) error {
 	linestring := ewkb.LineString{}
 
-	if err := ewkb.Unmarshal(&linestring, value); err != nil {
+	if err := unmarshal(&linestring, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (l NullLineString) Value() (driver.Value, error) {
 	return l.LineString.Value()
 }
 
+// State is the NULL / EMPTY / value state of the LineString.
+func (l NullLineString) State() State {
+	return stateOf(l.Valid, l.LineString)
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (l *LineString) FromEWKB(from interface{}) error {
 	linestring, ok := fromPtr(from).(ewkb.LineString)
@@ -104,6 +98,11 @@ func (l LineString) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &linestring
 }
 
+// IsEmpty checks if the LineString has no point (LINESTRING EMPTY).
+func (l LineString) IsEmpty() bool {
+	return len(l) == 0
+}
+
 // Geometry converts to a generic geometry.
 func (l LineString) Geometry(opts ...func(interface{})) Geometry {
 	output := Geometry{
@@ -118,3 +117,37 @@ func (l LineString) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the linestring.
+func (l LineString) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(l.ToEWKB())
+}
+
+// LineStringArray is an array of LineString (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type LineStringArray []LineString
+
+// Scan implements the SQL driver.Scanner interface.
+func (l *LineStringArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*l = make(LineStringArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*l)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (l LineStringArray) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(l), func(idx int) (driver.Value, error) {
+		return l[idx].Value()
+	})
+}
@@ -0,0 +1,465 @@
+package gogis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// ErrInvalidGeometry occurs when a geometry can not be made valid.
+const ErrInvalidGeometry = ewkb.Error("invalid geometry")
+
+// MakeValid repairs an invalid geometry, as ST_MakeValid does; valid geometries
+// are returned unchanged. Rings are closed, repeated and collinear points are
+// removed, self-intersecting rings are split at their intersections (a bow-tie
+// polygon becomes a multipolygon of two triangles), and degenerate rings are
+// dropped. Loops nested in other loops become holes, and holes nested in holes
+// become islands (even-odd rule).
+//
+// Defects between rings, such as a hole crossing its shell, or overlapping
+// members of a multipolygon, are repaired with overlays, as the structure
+// method of ST_MakeValid: each polygon is its shell minus its holes, and the
+// polygons are merged by union. Z and M are then dropped.
+func MakeValid(geometry EWKBConverter) (Geometry, error) {
+	geo := geometry.ToEWKB()
+
+	if len(Validate(geometry)) == 0 {
+		return modelOf(geo)
+	}
+
+	repaired, err := makeValid(geo)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	if violations := validate(repaired, ""); len(violations) > 0 {
+		return Geometry{}, fmt.Errorf("%w: %s in %s", ErrInvalidGeometry, violations[0], violations[0].Path)
+	}
+
+	return modelOf(repaired)
+}
+
+// modelOf converts an EWKB geometry to a model.
+func modelOf(geometry ewkb.Geometry) (Geometry, error) {
+	if collection, ok := geometry.(*ewkb.GeometryCollection); ok {
+		output := GeometryCollection{SRID: collection.SRID, Valid: true}
+
+		for _, sub := range collection.Collection {
+			model, err := modelOf(sub)
+			if err != nil {
+				return Geometry{}, err
+			}
+
+			converter, _ := model.Geometry.(ModelConverter)
+			output.Collection = append(output.Collection, converter)
+		}
+
+		return output.Geometry(), nil
+	}
+
+	model, err := DefaultWellKnownBinding().pick(geometry.Type())
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	if err := model.FromEWKB(geometry); err != nil {
+		return Geometry{}, err
+	}
+
+	return Geometry{Type: geometry.Type(), Geometry: model, Valid: true}, nil
+}
+
+func makeValid(geometry ewkb.Geometry) (ewkb.Geometry, error) { //nolint: ireturn
+	switch geo := geometry.(type) {
+	case *ewkb.Point:
+		if !xyOf(geo.Coordinate).isFinite() {
+			return &ewkb.Point{SRID: geo.SRID}, nil
+		}
+
+		return geo, nil
+	case *ewkb.MultiPoint:
+		output := ewkb.MultiPoint{SRID: geo.SRID}
+
+		for _, pnt := range geo.Points {
+			if xyOf(pnt.Coordinate).isFinite() {
+				output.Points = append(output.Points, pnt)
+			}
+		}
+
+		return &output, nil
+	case *ewkb.LineString:
+		return makeValidLine(geo.SRID, geo.CoordinateSet), nil
+	case *ewkb.MultiLineString:
+		output := ewkb.MultiLineString{SRID: geo.SRID}
+
+		for _, line := range geo.LineStrings {
+			if repaired, ok := makeValidLine(geo.SRID, line.CoordinateSet).(*ewkb.LineString); ok && !repaired.IsEmpty() {
+				output.LineStrings = append(output.LineStrings, *repaired)
+			}
+		}
+
+		return &output, nil
+	case *ewkb.Triangle:
+		return makeValidAreas(geo.SRID, []ewkb.CoordinateGroup{{geo.CoordinateSet}})
+	case *ewkb.Polygon:
+		return makeValidAreas(geo.SRID, []ewkb.CoordinateGroup{geo.CoordinateGroup})
+	case *ewkb.MultiPolygon:
+		groups := make([]ewkb.CoordinateGroup, len(geo.Polygons))
+
+		for idx, polygon := range geo.Polygons {
+			groups[idx] = polygon.CoordinateGroup
+		}
+
+		return makeValidAreas(geo.SRID, groups)
+	case *ewkb.GeometryCollection:
+		output := ewkb.GeometryCollection{SRID: geo.SRID}
+
+		for _, sub := range geo.Collection {
+			repaired, err := makeValid(sub)
+			if err != nil {
+				return nil, err
+			}
+
+			output.Collection = append(output.Collection, repaired)
+		}
+
+		return &output, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s can not be made valid", ErrInvalidGeometry, geometry.Type())
+}
+
+// makeValidAreas repairs polygons with the even-odd rule, or with overlays
+// when rings of different loops still cross or overlap.
+func makeValidAreas(srid *ewkb.SystemReferenceID, groups []ewkb.CoordinateGroup) (ewkb.Geometry, error) { //nolint: ireturn
+	repaired := makeValidPolygons(srid, groups)
+	if len(validate(repaired, "")) == 0 {
+		return repaired, nil
+	}
+
+	areas := make([]ewkb.Geometry, 0, len(groups))
+
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		area := makeValidPolygons(srid, []ewkb.CoordinateGroup{group[:1]})
+
+		for _, hole := range group[1:] {
+			var err error
+
+			area, err = overlayGeometries(area, makeValidPolygons(srid, []ewkb.CoordinateGroup{{hole}}), overlayDifference)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		areas = append(areas, area)
+	}
+
+	output, err := unionAll(areas)
+	if err != nil {
+		return nil, err
+	}
+
+	if output == nil {
+		return &ewkb.Polygon{SRID: srid}, nil
+	}
+
+	setSystemReferenceID(output, srid)
+
+	return output, nil
+}
+
+// makeValidLine removes invalid and repeated points; a line collapsed to a single
+// point becomes a point.
+func makeValidLine(srid *ewkb.SystemReferenceID, set ewkb.CoordinateSet) ewkb.Geometry { //nolint: ireturn
+	cleaned := cleanVertices(set)
+
+	if len(cleaned) == 1 {
+		return &ewkb.Point{SRID: srid, Coordinate: cleaned[0]}
+	}
+
+	return &ewkb.LineString{SRID: srid, CoordinateSet: cleaned}
+}
+
+// cleanVertices removes the non finite and the repeated vertices.
+func cleanVertices(set ewkb.CoordinateSet) ewkb.CoordinateSet {
+	output := ewkb.CoordinateSet{}
+
+	for _, coord := range set {
+		position := xyOf(coord)
+
+		if position.isFinite() && (len(output) == 0 || xyOf(output[len(output)-1]) != position) {
+			output = append(output, coord)
+		}
+	}
+
+	return output
+}
+
+// openRing cleans a ring, and removes its closing vertex: the result is a
+// cyclic list of vertices without repeated or collinear vertices.
+func openRing(set ewkb.CoordinateSet) ewkb.CoordinateSet {
+	ring := cleanVertices(set)
+
+	if len(ring) > 1 && xyOf(ring[0]) == xyOf(ring[len(ring)-1]) {
+		ring = ring[:len(ring)-1]
+	}
+
+	// Removing a vertex may make its neighbours collinear (or repeated).
+	for removed := true; removed && len(ring) >= 3; {
+		removed = false
+
+		for idx := 0; idx < len(ring) && len(ring) >= 3; idx++ {
+			previous := xyOf(ring[(idx+len(ring)-1)%len(ring)])
+			next := xyOf(ring[(idx+1)%len(ring)])
+
+			if current := xyOf(ring[idx]); current == previous || cross(previous, current, next) == 0 {
+				ring = append(ring[:idx:idx], ring[idx+1:]...)
+				removed = true
+			}
+		}
+	}
+
+	if len(ring) < 3 { //nolint: gomnd
+		return nil
+	}
+
+	return ring
+}
+
+// nodeInsertion is a vertex to insert in a segment of a ring.
+type nodeInsertion struct {
+	ratio float64
+	coord ewkb.Coordinate
+}
+
+// nodeRing inserts the self-intersections of a cyclic ring as vertices.
+func nodeRing(ring ewkb.CoordinateSet) ewkb.CoordinateSet {
+	size := len(ring)
+	insertions := make([][]nodeInsertion, size)
+
+	insert := func(segmentIdx int, position xy) {
+		from, to := ring[segmentIdx], ring[(segmentIdx+1)%size]
+
+		if position == xyOf(from) || position == xyOf(to) {
+			return
+		}
+
+		ratio := segmentRatio(xyOf(from), xyOf(to), position)
+		insertions[segmentIdx] = append(insertions[segmentIdx], nodeInsertion{
+			ratio: ratio,
+			coord: interpolateCoordinate(from, to, ratio, position),
+		})
+	}
+
+	for first := 0; first < size; first++ {
+		for second := first + 2; second < size; second++ {
+			if first == 0 && second == size-1 {
+				continue
+			}
+
+			intersection := intersectSegments(
+				xyOf(ring[first]), xyOf(ring[(first+1)%size]),
+				xyOf(ring[second]), xyOf(ring[(second+1)%size]),
+			)
+
+			switch intersection.kind {
+			case intersectionNone:
+			case intersectionOverlap:
+				for _, position := range []xy{intersection.point, intersection.end} {
+					insert(first, position)
+					insert(second, position)
+				}
+			case intersectionProper, intersectionTouch:
+				insert(first, intersection.point)
+				insert(second, intersection.point)
+			}
+		}
+	}
+
+	output := ewkb.CoordinateSet{}
+
+	for idx, coord := range ring {
+		output = append(output, coord)
+
+		sort.SliceStable(insertions[idx], func(i, j int) bool {
+			return insertions[idx][i].ratio < insertions[idx][j].ratio
+		})
+
+		for _, insertion := range insertions[idx] {
+			if xyOf(output[len(output)-1]) != xyOf(insertion.coord) {
+				output = append(output, insertion.coord)
+			}
+		}
+	}
+
+	return output
+}
+
+// segmentRatio is the position of p on the segment (from, to), from 0 to 1.
+func segmentRatio(from xy, to xy, p xy) float64 {
+	if math.Abs(to.x-from.x) > math.Abs(to.y-from.y) {
+		return (p.x - from.x) / (to.x - from.x)
+	}
+
+	return (p.y - from.y) / (to.y - from.y)
+}
+
+// interpolateCoordinate creates a coordinate at the position, interpolating the
+// other values (Z, M) of the segment.
+func interpolateCoordinate(from ewkb.Coordinate, to ewkb.Coordinate, ratio float64, position xy) ewkb.Coordinate {
+	output := ewkb.Coordinate{}
+
+	for name, value := range from {
+		output[name] = value + ratio*(to[name]-value)
+	}
+
+	output['x'] = position.x
+	output['y'] = position.y
+
+	return output
+}
+
+// splitLoops splits a noded cyclic ring (without its closing vertex) at its
+// repeated positions into closed loops; the vertices are kept as they are, and
+// the loops may be degenerate.
+func splitLoops[T any](ring []T, positionOf func(T) xy) [][]T {
+	output := [][]T{}
+	stack := []T{}
+	positions := map[xy]int{}
+
+	for idx := 0; idx <= len(ring); idx++ {
+		vertex := ring[idx%len(ring)]
+
+		pos, found := positions[positionOf(vertex)]
+		if !found {
+			positions[positionOf(vertex)] = len(stack)
+			stack = append(stack, vertex)
+
+			continue
+		}
+
+		output = append(output, append(append([]T{}, stack[pos:]...), vertex))
+
+		for _, popped := range stack[pos+1:] {
+			delete(positions, positionOf(popped))
+		}
+
+		stack = stack[:pos+1]
+	}
+
+	return output
+}
+
+// loop is a simple closed ring, in the even-odd nesting of loops.
+type loop struct {
+	coords ewkb.CoordinateSet
+	points []xy
+	area   float64
+	parent int
+	depth  int
+}
+
+// makeValidPolygons repairs polygons: their rings are split in simple loops,
+// and the loops are nested with the even-odd rule.
+func makeValidPolygons(srid *ewkb.SystemReferenceID, groups []ewkb.CoordinateGroup) ewkb.Geometry { //nolint: ireturn
+	loops := []loop{}
+
+	for _, group := range groups {
+		for _, set := range group {
+			ring := openRing(set)
+			if ring == nil {
+				continue
+			}
+
+			for _, coords := range splitLoops(nodeRing(ring), xyOf) {
+				cleaned := openRing(coords)
+				if cleaned == nil {
+					continue
+				}
+
+				coords = append(cleaned, cleaned[0])
+				points := xysOf(coords)
+
+				loops = append(loops, loop{coords: coords, points: points, area: math.Abs(signedArea(points))})
+			}
+		}
+	}
+
+	sort.SliceStable(loops, func(i, j int) bool { return loops[i].area > loops[j].area })
+
+	for idx := range loops {
+		loops[idx].parent = -1
+
+		// The smallest containing loop is the last one, as loops are sorted by area.
+		for container := idx - 1; container >= 0; container-- {
+			if loopInLoop(loops[idx].points, loops[container].points) {
+				loops[idx].parent = container
+				loops[idx].depth = loops[container].depth + 1
+
+				break
+			}
+		}
+	}
+
+	polygons := []ewkb.Polygon{}
+	shells := map[int]int{}
+
+	for idx, current := range loops {
+		if current.depth%2 == 0 {
+			shells[idx] = len(polygons)
+			polygons = append(polygons, ewkb.Polygon{SRID: srid, CoordinateGroup: ewkb.CoordinateGroup{current.coords}})
+
+			continue
+		}
+
+		polygon := &polygons[shells[current.parent]]
+		polygon.CoordinateGroup = append(polygon.CoordinateGroup, current.coords)
+	}
+
+	if len(polygons) == 1 {
+		return &polygons[0]
+	}
+
+	if len(polygons) == 0 {
+		return &ewkb.Polygon{SRID: srid}
+	}
+
+	return &ewkb.MultiPolygon{SRID: srid, Polygons: polygons}
+}
+
+// loopInLoop checks if a loop is inside another one: no vertex is outside, and
+// the loops do not only share their boundary.
+func loopInLoop(inner []xy, outer []xy) bool {
+	inside := false
+
+	for _, point := range inner {
+		switch locateInRing(point, outer) {
+		case locationExterior:
+			return false
+		case locationInterior:
+			inside = true
+		case locationBoundary:
+		}
+	}
+
+	if inside {
+		return true
+	}
+
+	// All the vertices are on the boundary: check the middle of the segments.
+	for idx := 1; idx < len(inner); idx++ {
+		middle := xy{x: (inner[idx-1].x + inner[idx].x) / 2, y: (inner[idx-1].y + inner[idx].y) / 2} //nolint: gomnd
+
+		if where := locateInRing(middle, outer); where != locationBoundary {
+			return where == locationInterior
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeValid(t *testing.T) {
+	square := line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)
+
+	for name, fixture := range map[string]struct {
+		geometry gogis.EWKBConverter
+		expected gogis.EWKBConverter
+	}{
+		"valid polygon is unchanged": {
+			geometry: gogis.Polygon{line(0, 0, 5, 0, 10, 0, 10, 10, 0, 10, 0, 0)},
+			expected: &gogis.Polygon{line(0, 0, 5, 0, 10, 0, 10, 10, 0, 10, 0, 0)},
+		},
+		"open ring": {
+			geometry: gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10)},
+			expected: &gogis.Polygon{square},
+		},
+		"repeated and collinear points": {
+			// POLYGON((0 0,0 0,5 0,10 0,10 10,0 10)).
+			geometry: gogis.Polygon{line(0, 0, 0, 0, 5, 0, 10, 0, 10, 10, 0, 10)},
+			expected: &gogis.Polygon{square},
+		},
+		"spike": {
+			// POLYGON((0 0,10 0,10 10,10 20,10 10,0 10,0 0)).
+			geometry: gogis.Polygon{line(0, 0, 10, 0, 10, 10, 10, 20, 10, 10, 0, 10, 0, 0)},
+			expected: &gogis.Polygon{square},
+		},
+		"bow tie": {
+			// POLYGON((100 200,100 100,200 200,200 100,100 200)).
+			geometry: gogis.Polygon{line(100, 200, 100, 100, 200, 200, 200, 100, 100, 200)},
+			expected: &gogis.MultiPolygon{
+				{line(150, 150, 200, 200, 200, 100, 150, 150)},
+				{line(100, 200, 100, 100, 150, 150, 100, 200)},
+			},
+		},
+		"ring self-intersection": {
+			// POLYGON((0 0,10 0,10 10,5 0,0 10,0 0)).
+			geometry: gogis.Polygon{line(0, 0, 10, 0, 10, 10, 5, 0, 0, 10, 0, 0)},
+			expected: &gogis.MultiPolygon{
+				{line(5, 0, 10, 0, 10, 10, 5, 0)},
+				{line(0, 0, 5, 0, 0, 10, 0, 0)},
+			},
+		},
+		"degenerate hole": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 2,1 1)).
+			geometry: gogis.Polygon{square, line(1, 1, 2, 2, 1, 1)},
+			expected: &gogis.Polygon{square},
+		},
+		"degenerate polygon": {
+			geometry: gogis.Polygon{line(0, 0, 1, 1, 0, 0, 0, 0)},
+			expected: &gogis.Polygon{},
+		},
+		"hole outside shell": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(15 15,15 20,20 20,20 15,15 15)).
+			geometry: gogis.Polygon{square, line(15, 15, 15, 20, 20, 20, 20, 15, 15, 15)},
+			expected: &gogis.MultiPolygon{{square}, {line(15, 15, 15, 20, 20, 20, 20, 15, 15, 15)}},
+		},
+		"nested holes": {
+			geometry: gogis.Polygon{square, line(1, 1, 9, 1, 9, 9, 1, 9, 1, 1), line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)},
+			expected: &gogis.MultiPolygon{
+				{square, line(1, 1, 9, 1, 9, 9, 1, 9, 1, 1)},
+				{line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)},
+			},
+		},
+		"nested shells": {
+			geometry: gogis.MultiPolygon{{square}, {line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)}},
+			expected: &gogis.Polygon{square, line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)},
+		},
+		"collapsed linestring": {
+			geometry: line(1, 2, 1, 2),
+			expected: &gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 2}},
+		},
+	} {
+		fixture := fixture
+
+		t.Run(name, func(t *testing.T) {
+			output, err := gogis.MakeValid(fixture.geometry)
+			require.NoError(t, err)
+
+			assert.Equal(t, fixture.expected, output.Geometry)
+
+			converter, ok := output.Geometry.(gogis.EWKBConverter)
+			require.True(t, ok)
+			assert.Empty(t, gogis.Validate(converter))
+		})
+	}
+
+	t.Run("Z is interpolated", func(t *testing.T) {
+		ring := func(values ...float64) gogis.LineString {
+			output := gogis.LineString{}
+
+			for idx := 0; idx+2 < len(values); idx += 3 {
+				output = append(output, gogis.Point{Coordinate: ewkb.Coordinate{'x': values[idx], 'y': values[idx+1], 'z': values[idx+2]}})
+			}
+
+			return output
+		}
+
+		output, err := gogis.MakeValid(gogis.Polygon{ring(100, 200, 1, 100, 100, 2, 200, 200, 4, 200, 100, 5, 100, 200, 1)})
+		require.NoError(t, err)
+
+		multiPolygon, ok := output.Geometry.(*gogis.MultiPolygon)
+		require.True(t, ok)
+		require.Len(t, *multiPolygon, 2)
+		assert.Equal(t, ewkb.Coordinate{'x': 150, 'y': 150, 'z': 3}, (*multiPolygon)[0][0][0].Coordinate)
+	})
+
+	for name, fixture := range map[string]struct {
+		geometry gogis.EWKBConverter
+		expected gogis.EWKBConverter
+	}{
+		"hole crossing its shell": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(5 5,5 15,15 15,15 5,5 5)).
+			geometry: gogis.Polygon{square, line(5, 5, 5, 15, 15, 15, 15, 5, 5, 5)},
+			expected: gogis.Polygon{line(0, 0, 10, 0, 10, 5, 5, 5, 5, 10, 0, 10, 0, 0)},
+		},
+		"overlapping multipolygon": {
+			geometry: gogis.MultiPolygon{{square}, {line(5, 5, 15, 5, 15, 15, 5, 15, 5, 5)}},
+			expected: gogis.Polygon{line(0, 0, 10, 0, 10, 5, 15, 5, 15, 15, 5, 15, 5, 10, 0, 10, 0, 0)},
+		},
+		"overlapping members with holes": {
+			geometry: gogis.MultiPolygon{
+				{square, line(2, 2, 2, 4, 4, 4, 4, 2, 2, 2)},
+				{line(3, 3, 15, 3, 15, 15, 3, 15, 3, 3)},
+			},
+			expected: gogis.Polygon{
+				line(0, 0, 10, 0, 10, 3, 15, 3, 15, 15, 3, 15, 3, 10, 0, 10, 0, 0),
+				line(2, 2, 2, 4, 3, 4, 3, 3, 4, 3, 4, 2, 2, 2),
+			},
+		},
+	} {
+		fixture := fixture
+
+		t.Run(name, func(t *testing.T) {
+			output, err := gogis.MakeValid(fixture.geometry)
+			require.NoError(t, err)
+
+			converter, ok := output.Geometry.(gogis.EWKBConverter)
+			require.True(t, ok)
+			assert.Empty(t, gogis.Validate(converter))
+
+			equals, err := gogis.Equals(converter, fixture.expected)
+			require.NoError(t, err)
+			assert.True(t, equals, "%v", output.Geometry)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package gogis
+
+import (
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// Planar measurements, in the unit of the coordinates, as PostGIS computes them
+// on geometry (not geography). Areas, centroids, lengths and perimeters are 2D;
+// Length3D and Perimeter3D use Z when the vertices have it.
+
+// Area computes the area of the polygon (ST_Area): the area of the shell minus
+// the area of the holes.
+func (p Polygon) Area() float64 {
+	return area(p.ToEWKB())
+}
+
+// Perimeter computes the length of the rings of the polygon (ST_Perimeter).
+func (p Polygon) Perimeter() float64 {
+	return perimeter(p.ToEWKB(), false)
+}
+
+// Perimeter3D computes the 3D length of the rings of the polygon (ST_3DPerimeter).
+func (p Polygon) Perimeter3D() float64 {
+	return perimeter(p.ToEWKB(), true)
+}
+
+// Centroid computes the center of mass of the polygon (ST_Centroid).
+func (p Polygon) Centroid() Point {
+	return centroidOf(p.ToEWKB())
+}
+
+// Area computes the sum of the areas of the polygons (ST_Area).
+func (p MultiPolygon) Area() float64 {
+	return area(p.ToEWKB())
+}
+
+// Perimeter computes the sum of the perimeters of the polygons (ST_Perimeter).
+func (p MultiPolygon) Perimeter() float64 {
+	return perimeter(p.ToEWKB(), false)
+}
+
+// Perimeter3D computes the sum of the 3D perimeters of the polygons (ST_3DPerimeter).
+func (p MultiPolygon) Perimeter3D() float64 {
+	return perimeter(p.ToEWKB(), true)
+}
+
+// Centroid computes the center of mass of the polygons, weighted by their area (ST_Centroid).
+func (p MultiPolygon) Centroid() Point {
+	return centroidOf(p.ToEWKB())
+}
+
+// Area computes the area of the triangle (ST_Area).
+func (t Triangle) Area() float64 {
+	return area(t.ToEWKB())
+}
+
+// Perimeter computes the perimeter of the triangle (ST_Perimeter).
+func (t Triangle) Perimeter() float64 {
+	return perimeter(t.ToEWKB(), false)
+}
+
+// Perimeter3D computes the 3D perimeter of the triangle (ST_3DPerimeter).
+func (t Triangle) Perimeter3D() float64 {
+	return perimeter(t.ToEWKB(), true)
+}
+
+// Centroid computes the center of mass of the triangle (ST_Centroid).
+func (t Triangle) Centroid() Point {
+	return centroidOf(t.ToEWKB())
+}
+
+// Length computes the length of the linestring (ST_Length).
+func (l LineString) Length() float64 {
+	return length(l.ToEWKB(), false)
+}
+
+// Length3D computes the 3D length of the linestring (ST_3DLength).
+func (l LineString) Length3D() float64 {
+	return length(l.ToEWKB(), true)
+}
+
+// Centroid computes the center of mass of the linestring, weighted by the length
+// of its segments (ST_Centroid).
+func (l LineString) Centroid() Point {
+	return centroidOf(l.ToEWKB())
+}
+
+// Length computes the sum of the lengths of the linestrings (ST_Length).
+func (m MultiLineString) Length() float64 {
+	return length(m.ToEWKB(), false)
+}
+
+// Length3D computes the sum of the 3D lengths of the linestrings (ST_3DLength).
+func (m MultiLineString) Length3D() float64 {
+	return length(m.ToEWKB(), true)
+}
+
+// Centroid computes the center of mass of the linestrings, weighted by the length
+// of their segments (ST_Centroid).
+func (m MultiLineString) Centroid() Point {
+	return centroidOf(m.ToEWKB())
+}
+
+// Length computes the length of the arcs (ST_Length).
+func (c CircularString) Length() float64 {
+	return length(c.ToEWKB(), false)
+}
+
+// Centroid computes the center of mass of the points (ST_Centroid).
+func (m MultiPoint) Centroid() Point {
+	return centroidOf(m.ToEWKB())
+}
+
+// Area computes the sum of the areas of the polygonal geometries of the collection (ST_Area).
+func (g GeometryCollection) Area() float64 {
+	return area(g.ToEWKB())
+}
+
+// Length computes the sum of the lengths of the linear geometries of the collection (ST_Length).
+func (g GeometryCollection) Length() float64 {
+	return length(g.ToEWKB(), false)
+}
+
+// Centroid computes the center of mass of the geometries of the collection, as
+// ST_Centroid: only the geometries of the highest dimension are weighted.
+func (g GeometryCollection) Centroid() Point {
+	return centroidOf(g.ToEWKB())
+}
+
+func area(geometry ewkb.Geometry) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.Triangle:
+		output = math.Abs(signedArea(xysOf(geo.CoordinateSet)))
+	case *ewkb.Polygon:
+		for idx, ring := range geo.CoordinateGroup {
+			ringArea := math.Abs(signedArea(xysOf(ring)))
+			if idx > 0 {
+				ringArea = -ringArea
+			}
+
+			output += ringArea
+		}
+	case *ewkb.MultiPolygon:
+		for idx := range geo.Polygons {
+			output += area(&geo.Polygons[idx])
+		}
+	case *ewkb.GeometryCollection:
+		for _, sub := range geo.Collection {
+			output += area(sub)
+		}
+	}
+
+	return output
+}
+
+// length computes the length of the linear geometries; in 3D when threeD is
+// set. Arcs are 2D.
+func length(geometry ewkb.Geometry, threeD bool) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.LineString:
+		output = setLength(geo.CoordinateSet, threeD)
+	case *ewkb.CircularString:
+		output = curveLength(geo.CoordinateSet)
+	case *ewkb.MultiLineString:
+		for idx := range geo.LineStrings {
+			output += length(&geo.LineStrings[idx], threeD)
+		}
+	case *ewkb.GeometryCollection:
+		for _, sub := range geo.Collection {
+			output += length(sub, threeD)
+		}
+	}
+
+	return output
+}
+
+// perimeter computes the length of the rings of the polygonal geometries; in
+// 3D when threeD is set.
+func perimeter(geometry ewkb.Geometry, threeD bool) float64 {
+	output := 0.0
+
+	switch geo := geometry.(type) {
+	case *ewkb.Triangle:
+		output = setLength(geo.CoordinateSet, threeD)
+	case *ewkb.Polygon:
+		for _, ring := range geo.CoordinateGroup {
+			output += setLength(ring, threeD)
+		}
+	case *ewkb.MultiPolygon:
+		for idx := range geo.Polygons {
+			output += perimeter(&geo.Polygons[idx], threeD)
+		}
+	case *ewkb.GeometryCollection:
+		for _, sub := range geo.Collection {
+			output += perimeter(sub, threeD)
+		}
+	}
+
+	return output
+}
+
+// setLength computes the length of the segments; in 3D when threeD is set and
+// both ends have Z.
+func setLength(set ewkb.CoordinateSet, threeD bool) float64 {
+	output := 0.0
+
+	for idx := 1; idx < len(set); idx++ {
+		from, to := set[idx-1], set[idx]
+		segment := distance(xyOf(from), xyOf(to))
+
+		fromZ, fromHasZ := from['z']
+		toZ, toHasZ := to['z']
+
+		if threeD && fromHasZ && toHasZ {
+			segment = math.Hypot(segment, toZ-fromZ)
+		}
+
+		output += segment
+	}
+
+	return output
+}
+
+// curveLength computes the length of the arcs of a circular string.
+func curveLength(set ewkb.CoordinateSet) float64 {
+	output := 0.0
+
+	for idx := 2; idx < len(set); idx += 2 {
+		start, middle, end := xyOf(set[idx-2]), xyOf(set[idx-1]), xyOf(set[idx])
+
+		arc, ok := ewkb.ArcOf(start.coord(), middle.coord(), end.coord())
+		if !ok {
+			output += distance(start, middle) + distance(middle, end)
+
+			continue
+		}
+
+		output += arc.Radius * math.Abs(arc.Sweep)
+	}
+
+	return output
+}
+
+// centroid accumulates the weighted centers of the areas, the lines and the
+// points: the centroid is computed on the highest dimension with a weight, so
+// that a degenerate polygon falls back to its lines, then to its points.
+type centroid struct {
+	area   float64
+	areaX  float64
+	areaY  float64
+	length float64
+	lineX  float64
+	lineY  float64
+	points float64
+	pointX float64
+	pointY float64
+}
+
+func centroidOf(geometry ewkb.Geometry) Point {
+	var accumulator centroid
+
+	accumulator.add(geometry)
+
+	output := Point{SRID: geometry.SystemReferenceID()}
+
+	if position, ok := accumulator.result(); ok {
+		output.Coordinate = ewkb.Coordinate{'x': position.x, 'y': position.y}
+	}
+
+	return output
+}
+
+func (c *centroid) add(geometry ewkb.Geometry) {
+	switch geo := geometry.(type) {
+	case *ewkb.Point:
+		c.addPoint(xyOf(geo.Coordinate))
+	case *ewkb.LineString:
+		c.addLine(xysOf(geo.CoordinateSet))
+	case *ewkb.CircularString:
+		c.addLine(xysOf(geo.CoordinateSet))
+	case *ewkb.Triangle:
+		c.addRing(xysOf(geo.CoordinateSet), false)
+	case *ewkb.Polygon:
+		for idx, ring := range geo.CoordinateGroup {
+			c.addRing(xysOf(ring), idx > 0)
+		}
+	case *ewkb.MultiPoint:
+		for idx := range geo.Points {
+			c.add(&geo.Points[idx])
+		}
+	case *ewkb.MultiLineString:
+		for idx := range geo.LineStrings {
+			c.add(&geo.LineStrings[idx])
+		}
+	case *ewkb.MultiPolygon:
+		for idx := range geo.Polygons {
+			c.add(&geo.Polygons[idx])
+		}
+	case *ewkb.GeometryCollection:
+		for _, sub := range geo.Collection {
+			c.add(sub)
+		}
+	}
+}
+
+func (c *centroid) addPoint(position xy) {
+	if !position.isFinite() {
+		return
+	}
+
+	c.points++
+	c.pointX += position.x
+	c.pointY += position.y
+}
+
+func (c *centroid) addLine(line []xy) {
+	for idx := 1; idx < len(line); idx++ {
+		if !line[idx-1].isFinite() || !line[idx].isFinite() {
+			continue
+		}
+
+		segment := distance(line[idx-1], line[idx])
+
+		c.length += segment
+		c.lineX += segment * (line[idx-1].x + line[idx].x) / 2 //nolint: gomnd
+		c.lineY += segment * (line[idx-1].y + line[idx].y) / 2 //nolint: gomnd
+	}
+
+	for _, position := range line {
+		c.addPoint(position)
+	}
+}
+
+// addRing adds the area of a ring (removes it for a hole), and its boundary.
+func (c *centroid) addRing(ring []xy, hole bool) {
+	c.addLine(ring)
+
+	if len(ring) == 0 {
+		return
+	}
+
+	// Relative to the first vertex, for the precision on large coordinates.
+	base := ring[0]
+	doubleArea, sumX, sumY := 0.0, 0.0, 0.0
+
+	for idx := 1; idx < len(ring); idx++ {
+		from := xy{x: ring[idx-1].x - base.x, y: ring[idx-1].y - base.y}
+		to := xy{x: ring[idx].x - base.x, y: ring[idx].y - base.y}
+		crossed := from.x*to.y - to.x*from.y
+
+		doubleArea += crossed
+		sumX += (from.x + to.x) * crossed
+		sumY += (from.y + to.y) * crossed
+	}
+
+	if doubleArea == 0 {
+		return
+	}
+
+	weight := math.Abs(doubleArea) / 2 //nolint: gomnd
+	if hole {
+		weight = -weight
+	}
+
+	c.area += weight
+	c.areaX += weight * (sumX/(3*doubleArea) + base.x) //nolint: gomnd
+	c.areaY += weight * (sumY/(3*doubleArea) + base.y) //nolint: gomnd
+}
+
+func (c centroid) result() (xy, bool) {
+	switch {
+	case c.area != 0:
+		return xy{x: c.areaX / c.area, y: c.areaY / c.area}, true
+	case c.length != 0:
+		return xy{x: c.lineX / c.length, y: c.lineY / c.length}, true
+	case c.points != 0:
+		return xy{x: c.pointX / c.points, y: c.pointY / c.points}, true
+	}
+
+	return xy{}, false
+}
@@ -0,0 +1,121 @@
+package gogis_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasure(t *testing.T) {
+	square := line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)
+	hole := line(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)
+
+	t.Run("polygon", func(t *testing.T) {
+		// POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,4 2,4 4,2 4,2 2)).
+		polygon := gogis.Polygon{square, hole}
+
+		assert.InDelta(t, 96.0, polygon.Area(), 1e-9)
+		assert.InDelta(t, 48.0, polygon.Perimeter(), 1e-9)
+
+		// SELECT ST_AsText(ST_Centroid(polygon)): POINT(5.08333333333333 5.08333333333333).
+		centroid := polygon.Centroid()
+		assert.InDelta(t, 5.083333333333333, centroid.Coordinate['x'], 1e-9)
+		assert.InDelta(t, 5.083333333333333, centroid.Coordinate['y'], 1e-9)
+	})
+
+	t.Run("large coordinates", func(t *testing.T) {
+		polygon := gogis.Polygon{line(1e8, 1e8, 1e8+10, 1e8, 1e8+10, 1e8+10, 1e8, 1e8+10, 1e8, 1e8)}
+
+		assert.InDelta(t, 100.0, polygon.Area(), 1e-6)
+		assert.InDelta(t, 1e8+5, polygon.Centroid().Coordinate['x'], 1e-6)
+	})
+
+	t.Run("multipolygon", func(t *testing.T) {
+		multiPolygon := gogis.MultiPolygon{{square}, {line(20, 0, 22, 0, 22, 2, 20, 2, 20, 0)}}
+
+		assert.InDelta(t, 104.0, multiPolygon.Area(), 1e-9)
+		assert.InDelta(t, 48.0, multiPolygon.Perimeter(), 1e-9)
+
+		// Weighted by area: (100 * 5 + 4 * 21) / 104.
+		centroid := multiPolygon.Centroid()
+		assert.InDelta(t, 584.0/104, centroid.Coordinate['x'], 1e-9)
+		assert.InDelta(t, 504.0/104, centroid.Coordinate['y'], 1e-9)
+	})
+
+	t.Run("triangle", func(t *testing.T) {
+		triangle := gogis.Triangle(line(0, 0, 3, 0, 0, 4, 0, 0))
+
+		assert.InDelta(t, 6.0, triangle.Area(), 1e-9)
+		assert.InDelta(t, 12.0, triangle.Perimeter(), 1e-9)
+		assert.Equal(t, ewkb.Coordinate{'x': 1, 'y': 4.0 / 3}, triangle.Centroid().Coordinate)
+	})
+
+	t.Run("linestring", func(t *testing.T) {
+		lineString := line(0, 0, 10, 0, 10, 30)
+
+		assert.InDelta(t, 40.0, lineString.Length(), 1e-9)
+
+		// Weighted by length: (10 * (5 0) + 30 * (10 15)) / 40.
+		assert.Equal(t, ewkb.Coordinate{'x': 8.75, 'y': 11.25}, lineString.Centroid().Coordinate)
+	})
+
+	t.Run("3D length", func(t *testing.T) {
+		lineString := gogis.LineString{
+			{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0, 'z': 0}},
+			{Coordinate: ewkb.Coordinate{'x': 3, 'y': 4, 'z': 12}},
+		}
+
+		assert.InDelta(t, 5.0, lineString.Length(), 1e-9)
+		assert.InDelta(t, 13.0, lineString.Length3D(), 1e-9)
+		assert.InDelta(t, 5.0, gogis.MultiLineString{lineString}.Length(), 1e-9)
+		assert.InDelta(t, 13.0, gogis.MultiLineString{lineString}.Length3D(), 1e-9)
+		assert.InDelta(t, 5.0, gogis.MultiLineString{line(0, 0, 3, 4)}.Length3D(), 1e-9)
+
+		polygon := gogis.Polygon{append(lineString, gogis.LineString{
+			{Coordinate: ewkb.Coordinate{'x': 6, 'y': 0, 'z': 0}},
+			{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0, 'z': 0}},
+		}...)}
+
+		assert.InDelta(t, 16.0, polygon.Perimeter(), 1e-9)
+		assert.InDelta(t, 32.0, polygon.Perimeter3D(), 1e-9)
+	})
+
+	t.Run("circular string", func(t *testing.T) {
+		// CIRCULARSTRING(0 0,1 1,2 0): half circle of radius 1.
+		assert.InDelta(t, math.Pi, gogis.CircularString(line(0, 0, 1, 1, 2, 0)).Length(), 1e-9)
+
+		// CIRCULARSTRING(0 0,1.7071 -0.7071,1 -1): quarter circle, clockwise.
+		assert.InDelta(t, math.Pi/2, gogis.CircularString(line(0, 0, 1-math.Sqrt2/2, -math.Sqrt2/2, 1, -1)).Length(), 1e-9)
+
+		// CIRCULARSTRING(0 0,2 0,0 0): full circle.
+		assert.InDelta(t, 2*math.Pi, gogis.CircularString(line(0, 0, 2, 0, 0, 0)).Length(), 1e-9)
+	})
+
+	t.Run("multipoint", func(t *testing.T) {
+		assert.Equal(t, ewkb.Coordinate{'x': 2, 'y': 1}, gogis.MultiPoint(line(0, 0, 4, 0, 2, 3)).Centroid().Coordinate)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		lineString := line(100, 100, 200, 100)
+		polygon := gogis.Polygon{square}
+		collection := gogis.GeometryCollection{Collection: []gogis.ModelConverter{&lineString, &polygon}}
+
+		assert.InDelta(t, 100.0, collection.Area(), 1e-9)
+		assert.InDelta(t, 100.0, collection.Length(), 1e-9)
+
+		// Only the polygon is weighted.
+		assert.Equal(t, ewkb.Coordinate{'x': 5, 'y': 5}, collection.Centroid().Coordinate)
+	})
+
+	t.Run("degenerate", func(t *testing.T) {
+		// A polygon without area falls back to its boundary.
+		assert.Equal(t, ewkb.Coordinate{'x': 5, 'y': 0}, gogis.Polygon{line(0, 0, 10, 0, 0, 0)}.Centroid().Coordinate)
+
+		assert.True(t, gogis.Polygon{}.Centroid().Coordinate.IsEmpty())
+		assert.Zero(t, gogis.Polygon{}.Area())
+		assert.Zero(t, gogis.LineString{}.Length())
+	})
+}
@@ -10,6 +10,7 @@ import (
 type MultiLineString []LineString
 
 // NullMultiLineString represents a MultiLineString that may be null.
+// It has the same semantics as Null[*MultiLineString].
 // NullMultiLineString implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullMultiLineString struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (m *NullMultiLineString) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	multi := ewkb.MultiLineString{}
-
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
-		return err
-	}
-
-	m.Valid = true
-
-	return (&m.MultiLineString).FromEWKB(multi)
+	return scanNull(value, &m.MultiLineString, &m.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (m *MultiLineString) Scan(value interface{}) error {
 	multi := ewkb.MultiLineString{}
 
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
+	if err := unmarshal(&multi, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (m NullMultiLineString) Value() (driver.Value, error) {
 	return m.MultiLineString.Value()
 }
 
+// State is the NULL / EMPTY / value state of the MultiLineString.
+func (m NullMultiLineString) State() State {
+	return stateOf(m.Valid, m.MultiLineString)
+}
+
 func (m MultiLineString) srid() *ewkb.SystemReferenceID {
 	for _, poly := range m {
 		for _, pnt := range poly {
@@ -94,6 +88,17 @@ func (m MultiLineString) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &multi
 }
 
+// IsEmpty checks if the MultiLineString has no line, or only empty lines.
+func (m MultiLineString) IsEmpty() bool {
+	for _, line := range m {
+		if !line.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (m *MultiLineString) FromEWKB(from interface{}) error {
 	multi, ok := fromPtr(from).(ewkb.MultiLineString)
@@ -128,3 +133,37 @@ func (m MultiLineString) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the multilinestring.
+func (m MultiLineString) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(m.ToEWKB())
+}
+
+// MultiLineStringArray is an array of MultiLineString (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type MultiLineStringArray []MultiLineString
+
+// Scan implements the SQL driver.Scanner interface.
+func (m *MultiLineStringArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*m = make(MultiLineStringArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*m)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (m MultiLineStringArray) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(m), func(idx int) (driver.Value, error) {
+		return m[idx].Value()
+	})
+}
@@ -10,6 +10,7 @@ import (
 type MultiPoint []Point
 
 // NullMultiPoint represents a MultiPoint that may be null.
+// It has the same semantics as Null[*MultiPoint].
 // NullMultiPoint implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullMultiPoint struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (m *NullMultiPoint) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	multi := ewkb.MultiPoint{}
-
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
-		return err
-	}
-
-	m.Valid = true
-
-	return (&m.MultiPoint).FromEWKB(multi)
+	return scanNull(value, &m.MultiPoint, &m.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (m *MultiPoint) Scan(value interface{}) error {
 	multi := ewkb.MultiPoint{}
 
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
+	if err := unmarshal(&multi, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (m NullMultiPoint) Value() (driver.Value, error) {
 	return m.MultiPoint.Value()
 }
 
+// State is the NULL / EMPTY / value state of the MultiPoint.
+func (m NullMultiPoint) State() State {
+	return stateOf(m.Valid, m.MultiPoint)
+}
+
 // ToEWKB implements the ModelConverter interface.
 func (m MultiPoint) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	multi := ewkb.MultiPoint{
@@ -85,6 +79,17 @@ func (m MultiPoint) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &multi
 }
 
+// IsEmpty checks if the MultiPoint has no point, or only empty points.
+func (m MultiPoint) IsEmpty() bool {
+	for _, pnt := range m {
+		if !pnt.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (m *MultiPoint) FromEWKB(from interface{}) error {
 	multi, ok := fromPtr(from).(ewkb.MultiPoint)
@@ -117,3 +122,37 @@ func (m MultiPoint) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the multipoint.
+func (m MultiPoint) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(m.ToEWKB())
+}
+
+// MultiPointArray is an array of MultiPoint (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type MultiPointArray []MultiPoint
+
+// Scan implements the SQL driver.Scanner interface.
+func (m *MultiPointArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*m = make(MultiPointArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*m)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (m MultiPointArray) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(m), func(idx int) (driver.Value, error) {
+		return m[idx].Value()
+	})
+}
@@ -10,6 +10,7 @@ import (
 type MultiPolygon []Polygon
 
 // NullMultiPolygon represents a MultiPolygon that may be null.
+// It has the same semantics as Null[*MultiPolygon].
 // NullMultiPolygon implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullMultiPolygon struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *NullMultiPolygon) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	multi := ewkb.MultiPolygon{}
-
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
-		return err
-	}
-
-	p.Valid = true
-
-	return (&p.MultiPolygon).FromEWKB(multi)
+	return scanNull(value, &p.MultiPolygon, &p.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *MultiPolygon) Scan(value interface{}) error {
 	multi := ewkb.MultiPolygon{}
 
-	if err := ewkb.Unmarshal(&multi, value); err != nil {
+	if err := unmarshal(&multi, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (p NullMultiPolygon) Value() (driver.Value, error) {
 	return p.MultiPolygon.Value()
 }
 
+// State is the NULL / EMPTY / value state of the MultiPolygon.
+func (p NullMultiPolygon) State() State {
+	return stateOf(p.Valid, p.MultiPolygon)
+}
+
 func (p MultiPolygon) srid() *ewkb.SystemReferenceID {
 	for _, poly := range p {
 		for _, line := range poly {
@@ -98,6 +92,17 @@ func (p MultiPolygon) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &multi
 }
 
+// IsEmpty checks if the MultiPolygon has no polygon, or only empty polygons.
+func (p MultiPolygon) IsEmpty() bool {
+	for _, poly := range p {
+		if !poly.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (p *MultiPolygon) FromEWKB(from interface{}) error {
 	multi, ok := fromPtr(from).(ewkb.MultiPolygon)
@@ -132,3 +137,37 @@ func (p MultiPolygon) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the multipolygon.
+func (p MultiPolygon) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(p.ToEWKB())
+}
+
+// MultiPolygonArray is an array of MultiPolygon (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type MultiPolygonArray []MultiPolygon
+
+// Scan implements the SQL driver.Scanner interface.
+func (p *MultiPolygonArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*p = make(MultiPolygonArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*p)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (p MultiPolygonArray) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(p), func(idx int) (driver.Value, error) {
+		return p[idx].Value()
+	})
+}
@@ -0,0 +1,215 @@
+package gogis
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// State is the state of a nullable geometry: NULL, EMPTY or a value.
+type State uint8
+
+const (
+	// StateNull is a NULL geometry.
+	StateNull State = iota
+
+	// StateEmpty is an EMPTY geometry (POINT EMPTY, LINESTRING EMPTY, ...).
+	StateEmpty
+
+	// StateValue is a geometry with coordinates.
+	StateValue
+)
+
+// String implements the fmt.Stringer interface.
+func (s State) String() string {
+	switch s {
+	case StateNull:
+		return "NULL"
+	case StateEmpty:
+		return "EMPTY"
+	case StateValue:
+		return "VALUE"
+	}
+
+	return fmt.Sprintf("State(%d)", uint8(s))
+}
+
+// Null represents a geometry that may be null. T is a pointer to a model
+// type (*Point, *Polygon, or any custom type registered with
+// AppendWellKnownBinding). Null implements the SQL driver.Scanner interface
+// so it can be used as a scan destination:
+//
+//	var poly gogis.Null[*gogis.Polygon]
+//	err := db.QueryRow("SELECT coordinate FROM foo WHERE id=?", id).Scan(&poly)
+//	...
+//	if poly.Valid {
+//	   // use poly.Geometry
+//	} else {
+//	   // NULL value
+//	}
+//
+// Valid is false only for NULL; an EMPTY geometry is valid.
+type Null[T ModelConverter] struct {
+	Geometry T
+	Valid    bool
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (n *Null[T]) Scan(value interface{}) error {
+	if isNullValue(value) {
+		*n = Null[T]{}
+
+		return nil
+	}
+
+	geometry, err := scanModel[T](value)
+	if err != nil {
+		return err
+	}
+
+	n.Geometry = geometry
+	n.Valid = true
+
+	return nil
+}
+
+// State is the NULL / EMPTY / value state of the geometry.
+func (n Null[T]) State() State {
+	if isNilModel(n.Geometry) {
+		return StateNull
+	}
+
+	return stateOf(n.Valid, n.Geometry)
+}
+
+// Value implements the driver.Valuer interface.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid || isNilModel(n.Geometry) {
+		return nil, nil
+	}
+
+	return ewkb.Marshal(n.Geometry.ToEWKB())
+}
+
+// Array is an array of geometries of the same type (geometry[] in database).
+// T is a pointer to a model type; NULL elements are nil pointers.
+type Array[T ModelConverter] []T
+
+// Scan implements the SQL driver.Scanner interface.
+func (a *Array[T]) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		true,
+		func(size int) {
+			*a = make(Array[T], size)
+		},
+		func(idx int, element []byte) error {
+			if element == nil {
+				return nil
+			}
+
+			geometry, err := scanModel[T](element)
+			if err != nil {
+				return err
+			}
+
+			(*a)[idx] = geometry
+
+			return nil
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (a Array[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(a), func(idx int) (driver.Value, error) {
+		return Null[T]{Geometry: a[idx], Valid: true}.Value()
+	})
+}
+
+// scanNull scans the value in the fields of the legacy Null* types.
+func scanNull[T any, PT interface {
+	*T
+	ModelConverter
+}](value interface{}, geometry *T, valid *bool) error {
+	null := Null[PT]{}
+
+	if err := null.Scan(value); err != nil {
+		return err
+	}
+
+	var zero T
+
+	*geometry = zero
+	*valid = null.Valid
+
+	if null.Valid {
+		*geometry = *null.Geometry
+	}
+
+	return nil
+}
+
+// scanModel decodes the value with the binding of the model type.
+func scanModel[T ModelConverter](value interface{}) (T, error) {
+	var output T
+
+	modelType := reflect.TypeOf(&output).Elem()
+	if modelType.Kind() != reflect.Pointer {
+		return output, fmt.Errorf("%w: %s is not a pointer", ewkb.ErrIncompatibleFormat, modelType)
+	}
+
+	bind, err := globalWellknownBindings.byModel(modelType)
+	if err != nil {
+		return output, err
+	}
+
+	geometry := globalWellknownBindings.newEWKB(bind)
+
+	if err := unmarshal(geometry, value, decoding{}); err != nil {
+		return output, err
+	}
+
+	output, _ = reflect.New(modelType.Elem()).Interface().(T)
+
+	return output, output.FromEWKB(geometry)
+}
+
+// stateOf computes the state of a nullable geometry.
+func stateOf(valid bool, geometry EWKBConverter) State {
+	if !valid || geometry == nil {
+		return StateNull
+	}
+
+	if isEmptyModel(geometry) {
+		return StateEmpty
+	}
+
+	return StateValue
+}
+
+// isEmptyModel checks if the geometry is EMPTY, with its own IsEmpty method
+// if any, or with its EWKB form.
+func isEmptyModel(geometry EWKBConverter) bool {
+	if emptier, ok := geometry.(interface{ IsEmpty() bool }); ok {
+		return emptier.IsEmpty()
+	}
+
+	return ewkb.IsEmpty(geometry.ToEWKB())
+}
+
+func isNilModel(model EWKBConverter) bool {
+	if model == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(model)
+
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
@@ -0,0 +1,262 @@
+package gogis_test
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customGeometryType ewkb.GeometryType = 42
+
+type customEWKB struct {
+	ewkb.Point
+}
+
+func (c customEWKB) Type() ewkb.GeometryType {
+	return customGeometryType
+}
+
+func (c *customEWKB) UnmarshalEWBK(record ewkb.ExtendedWellKnownBytes) error {
+	if record.Type != customGeometryType {
+		return ewkb.ErrWrongGeometryType
+	}
+
+	record.Type = ewkb.GeometryTypePoint
+
+	return c.Point.UnmarshalEWBK(record)
+}
+
+func (c customEWKB) MarshalEWBK(byteOrder binary.ByteOrder) ([]byte, error) {
+	return c.Point.MarshalEWBK(byteOrder)
+}
+
+type customModel struct {
+	X float64
+	Y float64
+}
+
+func (c *customModel) FromEWKB(from interface{}) error {
+	geometry, ok := from.(*customEWKB)
+	if !ok {
+		return ewkb.ErrWrongGeometryType
+	}
+
+	c.X = geometry.Coordinate['x']
+	c.Y = geometry.Coordinate['y']
+
+	return nil
+}
+
+func (c customModel) ToEWKB() ewkb.Geometry { //nolint: ireturn
+	return &customEWKB{Point: ewkb.Point{Coordinate: ewkb.Coordinate{'x': c.X, 'y': c.Y}}}
+}
+
+type unboundModel struct {
+	customModel
+}
+
+func TestNull(t *testing.T) {
+	pointOneTwoFixture := fixturePointOneTwo()
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData: []byte(pointOneTwo),
+			scanner: &gogis.Null[*gogis.Point]{},
+			expectedGeometry: &gogis.Null[*gogis.Point]{
+				Geometry: &pointOneTwoFixture,
+				Valid:    true,
+			},
+		})
+	})
+
+	t.Run("scan null data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          nil,
+			scanner:          &gogis.Null[*gogis.Point]{Valid: true},
+			expectedGeometry: &gogis.Null[*gogis.Point]{},
+		})
+	})
+
+	t.Run("scan empty data", func(t *testing.T) {
+		// SELECT 'LINESTRING EMPTY'::geometry.
+		null := gogis.Null[*gogis.LineString]{}
+
+		require.NoError(t, null.Scan("010200000000000000"))
+		assert.True(t, null.Valid)
+		assert.Equal(t, &gogis.LineString{}, null.Geometry)
+	})
+
+	t.Run("scan wrong type", func(t *testing.T) {
+		null := gogis.Null[*gogis.Polygon]{}
+
+		assert.ErrorIs(t, null.Scan(pointOneTwo), ewkb.ErrWrongGeometryType)
+	})
+
+	t.Run("scan collection", func(t *testing.T) {
+		// SELECT 'GEOMETRYCOLLECTION(POINT(1 2))'::geometry.
+		null := gogis.Null[*gogis.GeometryCollection]{}
+
+		require.NoError(t, null.Scan("010700000001000000"+pointOneTwo))
+		assert.True(t, null.Valid)
+		assert.Equal(t, []gogis.ModelConverter{&pointOneTwoFixture}, null.Geometry.Collection)
+	})
+
+	t.Run("value with data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: []byte(pointOneTwo),
+			valuer:          gogis.Null[*gogis.Point]{Geometry: &pointOneTwoFixture, Valid: true},
+		})
+	})
+
+	t.Run("value null data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: nil,
+			valuer:          gogis.Null[*gogis.Point]{Geometry: &pointOneTwoFixture},
+		})
+
+		valueTest(t, testFixtureValue{
+			expectedRawData: nil,
+			valuer:          gogis.Null[*gogis.Point]{Valid: true},
+		})
+	})
+
+	t.Run("custom binding", func(t *testing.T) {
+		gogis.AppendWellKnownBinding(gogis.Bind(&customEWKB{}, &customModel{}))
+
+		rawData := "012A000000000000000000F03F0000000000000040"
+
+		null := gogis.Null[*customModel]{}
+
+		require.NoError(t, null.Scan(rawData))
+		assert.Equal(t, gogis.Null[*customModel]{Geometry: &customModel{X: 1, Y: 2}, Valid: true}, null)
+
+		valueTest(t, testFixtureValue{
+			expectedRawData: []byte(rawData),
+			valuer:          null,
+		})
+	})
+
+	t.Run("unbound type", func(t *testing.T) {
+		null := gogis.Null[*unboundModel]{}
+
+		assert.ErrorIs(t, null.Scan(pointOneTwo), ewkb.ErrWrongGeometryType)
+	})
+}
+
+func TestArray(t *testing.T) {
+	pointOneTwoFixture := fixturePointOneTwo()
+	pointThreeFourFixture := fixturePointThreeFour()
+
+	fixture := gogis.Array[*gogis.Point]{&pointOneTwoFixture, nil, &pointThreeFourFixture}
+
+	dataByte := []byte("{" + pointOneTwo + ":NULL:" + pointThreeFour + "}")
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          dataByte,
+			scanner:          &gogis.Array[*gogis.Point]{},
+			expectedGeometry: &fixture,
+		})
+	})
+
+	t.Run("scan wrong type", func(t *testing.T) {
+		array := gogis.Array[*gogis.Triangle]{}
+
+		assert.ErrorIs(t, array.Scan(dataByte), ewkb.ErrWrongGeometryType)
+	})
+
+	t.Run("value with data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: dataByte,
+			valuer:          fixture,
+		})
+	})
+
+	t.Run("value null data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: nil,
+			valuer:          gogis.Array[*gogis.Point](nil),
+		})
+	})
+}
+
+func TestState(t *testing.T) {
+	// Binaries are the output of PostGIS (SELECT 'POINT EMPTY'::geometry).
+	fixtures := []struct {
+		strGeometry string
+		binary      string
+		scanner     interface {
+			Scan(value interface{}) error
+			Value() (driver.Value, error)
+			State() gogis.State
+		}
+	}{
+		{strGeometry: "POINT EMPTY", binary: "0101000000000000000000f87f000000000000f87f", scanner: &gogis.Null[*gogis.Point]{}},
+		{strGeometry: "LINESTRING EMPTY", binary: "010200000000000000", scanner: &gogis.Null[*gogis.LineString]{}},
+		{strGeometry: "POLYGON EMPTY", binary: "010300000000000000", scanner: &gogis.Null[*gogis.Polygon]{}},
+		{strGeometry: "MULTIPOINT EMPTY", binary: "010400000000000000", scanner: &gogis.Null[*gogis.MultiPoint]{}},
+		{strGeometry: "MULTILINESTRING EMPTY", binary: "010500000000000000", scanner: &gogis.Null[*gogis.MultiLineString]{}},
+		{strGeometry: "MULTIPOLYGON EMPTY", binary: "010600000000000000", scanner: &gogis.Null[*gogis.MultiPolygon]{}},
+		{strGeometry: "GEOMETRYCOLLECTION EMPTY", binary: "010700000000000000", scanner: &gogis.Null[*gogis.GeometryCollection]{}},
+		{strGeometry: "CIRCULARSTRING EMPTY", binary: "010800000000000000", scanner: &gogis.Null[*gogis.CircularString]{}},
+		{strGeometry: "TRIANGLE EMPTY", binary: "011100000000000000", scanner: &gogis.Null[*gogis.Triangle]{}},
+		{strGeometry: "POINT EMPTY", binary: "0101000000000000000000f87f000000000000f87f", scanner: &gogis.NullPoint{}},
+		{strGeometry: "POLYGON EMPTY", binary: "010300000000000000", scanner: &gogis.NullPolygon{}},
+		{strGeometry: "MULTIPOLYGON EMPTY", binary: "010600000000000000", scanner: &gogis.NullMultiPolygon{}},
+	}
+
+	for idx, elt := range fixtures {
+		fixture := elt
+
+		t.Run(fmt.Sprintf("%d - %T %s", idx, fixture.scanner, fixture.strGeometry), func(t *testing.T) {
+			assert.Equal(t, gogis.StateNull, fixture.scanner.State())
+
+			require.NoError(t, fixture.scanner.Scan(fixture.binary))
+			assert.Equal(t, gogis.StateEmpty, fixture.scanner.State())
+
+			value, err := fixture.scanner.Value()
+			require.NoError(t, err)
+			assert.Equal(t, []byte(fixture.binary), value)
+
+			require.NoError(t, fixture.scanner.Scan(nil))
+			assert.Equal(t, gogis.StateNull, fixture.scanner.State())
+		})
+	}
+
+	t.Run("value", func(t *testing.T) {
+		null := gogis.Null[*gogis.Point]{}
+
+		require.NoError(t, null.Scan(pointOneTwo))
+		assert.Equal(t, gogis.StateValue, null.State())
+		assert.Equal(t, "VALUE", null.State().String())
+	})
+
+	t.Run("geometry", func(t *testing.T) {
+		geometry := gogis.NewGeometry()
+		assert.Equal(t, gogis.StateNull, geometry.State())
+
+		require.NoError(t, geometry.Scan("0107000000010000000101000000000000000000F87F000000000000F87F"))
+		assert.Equal(t, gogis.StateEmpty, geometry.State())
+		assert.True(t, geometry.IsEmpty())
+
+		require.NoError(t, geometry.Scan(pointOneTwo))
+		assert.Equal(t, gogis.StateValue, geometry.State())
+		assert.False(t, geometry.IsEmpty())
+
+		require.NoError(t, geometry.Scan(nil))
+		assert.Equal(t, gogis.StateNull, geometry.State())
+	})
+
+	t.Run("model", func(t *testing.T) {
+		assert.True(t, gogis.Point{}.IsEmpty())
+		assert.True(t, gogis.MultiPolygon{gogis.Polygon{}}.IsEmpty())
+		assert.True(t, gogis.GeometryCollection{Collection: []gogis.ModelConverter{&gogis.LineString{}}}.IsEmpty())
+		assert.False(t, gogis.MultiPoint{{}, fixturePointOneTwo()}.IsEmpty())
+	})
+}
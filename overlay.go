@@ -0,0 +1,478 @@
+package gogis
+
+import (
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// Overlay operations compute the points shared by two geometries, or covered
+// by one of them only, as PostGIS does with GEOS. The geometries are noded
+// against each other: the result is made of the areas, the lines and the points
+// of the operation, each part being dropped when it is covered by a part of a
+// higher dimension.
+//
+//	clipped, err := gogis.Intersection(polygon, coverage)
+//	if err != nil {
+//		return err
+//	}
+//	// clipped.Type is a polygon, a multipolygon, or a collection of the
+//	// degenerate parts (lines and points where the polygons only touch).
+//
+// Shells are counter clockwise and holes clockwise; Z and M are dropped. Curves
+// are not supported (ErrUnsupportedGeometry).
+
+// Intersection computes the points shared by both geometries (ST_Intersection).
+func Intersection(a EWKBConverter, b EWKBConverter) (Geometry, error) {
+	return overlay(a, b, overlayIntersection)
+}
+
+// Union computes the points of either geometry (ST_Union).
+func Union(a EWKBConverter, b EWKBConverter) (Geometry, error) {
+	return overlay(a, b, overlayUnion)
+}
+
+// Difference computes the points of a that are not in b (ST_Difference).
+func Difference(a EWKBConverter, b EWKBConverter) (Geometry, error) {
+	return overlay(a, b, overlayDifference)
+}
+
+// SymDifference computes the points of only one of the geometries (ST_SymDifference).
+func SymDifference(a EWKBConverter, b EWKBConverter) (Geometry, error) {
+	return overlay(a, b, overlaySymDifference)
+}
+
+// overlayOperation is a boolean operation on point sets.
+type overlayOperation uint8
+
+const (
+	overlayIntersection overlayOperation = iota
+	overlayUnion
+	overlayDifference
+	overlaySymDifference
+)
+
+// keep checks if a part in a (or not), and in b (or not), is in the result.
+func (o overlayOperation) keep(inA bool, inB bool) bool {
+	switch o {
+	case overlayIntersection:
+		return inA && inB
+	case overlayUnion:
+		return inA || inB
+	case overlayDifference:
+		return inA && !inB
+	case overlaySymDifference:
+	}
+
+	return inA != inB
+}
+
+// emptyDimension is the dimension of an empty result, as GEOS computes it.
+func (o overlayOperation) emptyDimension(dimensionA int, dimensionB int) int {
+	switch o {
+	case overlayIntersection:
+		if dimensionA < dimensionB {
+			return dimensionA
+		}
+
+		return dimensionB
+	case overlayDifference:
+		return dimensionA
+	case overlayUnion, overlaySymDifference:
+	}
+
+	return maxInt(dimensionA, dimensionB)
+}
+
+func overlay(a EWKBConverter, b EWKBConverter, operation overlayOperation) (Geometry, error) {
+	output, err := overlayGeometries(a.ToEWKB(), b.ToEWKB(), operation)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	return modelOf(output)
+}
+
+func overlayGeometries(a ewkb.Geometry, b ewkb.Geometry, operation overlayOperation) (ewkb.Geometry, error) { //nolint: ireturn
+	first, err := newRelateGeometry(a)
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := newRelateGeometry(b)
+	if err != nil {
+		return nil, err
+	}
+
+	emptyDimension := operation.emptyDimension(first.dimension(), second.dimension())
+
+	node(first, second)
+
+	result := overlayResult{
+		ends: map[xy]bool{},
+	}
+
+	result.classifyEdges(first, second, operation)
+	result.buildPolygons()
+	result.mergeLines()
+	result.collectPoints(first, second, operation)
+
+	return result.geometry(a.SystemReferenceID(), emptyDimension), nil
+}
+
+// overlayEdge is a segment of the result.
+type overlayEdge struct {
+	from xy
+	to   xy
+}
+
+// overlayResult is the result of an overlay: the edges of the areas (the area
+// is on their left) and of the lines, then the parts built from them.
+type overlayResult struct {
+	areaEdges []overlayEdge
+	lineEdges []overlayEdge
+
+	// ends are the ends of the edges of the result.
+	ends map[xy]bool
+
+	polygons [][][]xy
+	lines    [][]xy
+	points   []xy
+}
+
+// classifyEdges checks each segment of both geometries: a segment is an edge of
+// an area of the result when one of its sides only is in the result, and an
+// edge of a line when it is in the result, but none of its sides.
+func (r *overlayResult) classifyEdges(first *relateGeometry, second *relateGeometry, operation overlayOperation) {
+	seen := map[edgeKey]bool{}
+
+	for _, path := range append(first.paths(), second.paths()...) {
+		for idx := 1; idx < len(*path); idx++ {
+			from, to := (*path)[idx-1], (*path)[idx]
+
+			key, _ := newEdgeKey(from, to)
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+
+			leftA, rightA, _ := first.sides(from, to)
+			leftB, rightB, _ := second.sides(from, to)
+
+			leftIn := operation.keep(leftA == locationInterior, leftB == locationInterior)
+			rightIn := operation.keep(rightA == locationInterior, rightB == locationInterior)
+
+			switch {
+			case leftIn && !rightIn:
+				r.areaEdges = append(r.areaEdges, overlayEdge{from: from, to: to})
+			case rightIn && !leftIn:
+				r.areaEdges = append(r.areaEdges, overlayEdge{from: to, to: from})
+			case !leftIn && !rightIn && operation.keep(
+				first.locateSegment(from, to) != locationExterior,
+				second.locateSegment(from, to) != locationExterior,
+			):
+				r.lineEdges = append(r.lineEdges, overlayEdge{from: from, to: to})
+			default:
+				continue
+			}
+
+			r.ends[from] = true
+			r.ends[to] = true
+		}
+	}
+}
+
+// buildPolygons links the edges of the areas into rings, turning left at each
+// node so that polygons touching at a point are separated, then assigns the
+// holes (clockwise rings) to the smallest shell containing them.
+func (r *overlayResult) buildPolygons() {
+	outgoing := map[xy][]int{}
+
+	for idx, current := range r.areaEdges {
+		outgoing[current.from] = append(outgoing[current.from], idx)
+	}
+
+	visited := make([]bool, len(r.areaEdges))
+	shells := [][]xy{}
+	holes := [][]xy{}
+
+	for start := range r.areaEdges {
+		if visited[start] {
+			continue
+		}
+
+		ring := []xy{r.areaEdges[start].from}
+
+		for current := start; ; {
+			visited[current] = true
+			ring = append(ring, r.areaEdges[current].to)
+
+			next, ok := leftmostEdge(r.areaEdges, outgoing[r.areaEdges[current].to], r.areaEdges[current])
+			if !ok || next == start || visited[next] {
+				break
+			}
+
+			current = next
+		}
+
+		if ring[0] == ring[len(ring)-1] {
+			ring = ring[:len(ring)-1]
+		}
+
+		// A ring through a node twice (a hole touching its shell) is split in simple loops.
+		for _, loop := range splitLoops(ring, func(position xy) xy { return position }) {
+			switch area := signedArea(loop); {
+			case len(loop) < 4, negligible(loop, area): //nolint: gomnd
+			case area > 0:
+				shells = append(shells, loop)
+			case area < 0:
+				holes = append(holes, loop)
+			}
+		}
+	}
+
+	r.polygons = make([][][]xy, len(shells))
+
+	for idx, shell := range shells {
+		r.polygons[idx] = [][]xy{shell}
+	}
+
+	for _, hole := range holes {
+		owner, ownerArea := -1, math.Inf(1)
+
+		for idx, shell := range shells {
+			if area := signedArea(shell); area < ownerArea && loopInLoop(hole, shell) {
+				owner, ownerArea = idx, area
+			}
+		}
+
+		if owner >= 0 {
+			r.polygons[owner] = append(r.polygons[owner], hole)
+		}
+	}
+}
+
+// negligible checks whether the area of a loop is only a rounding error: the
+// slivers between two computations of the same intersection.
+func negligible(loop []xy, area float64) bool {
+	perimeter := 0.0
+
+	for idx := 1; idx < len(loop); idx++ {
+		perimeter += distance(loop[idx-1], loop[idx])
+	}
+
+	return math.Abs(area) <= 1e-12*perimeter*perimeter //nolint: gomnd
+}
+
+// leftmostEdge selects the outgoing edge making the leftmost turn after the
+// incoming edge: the first one clockwise from the reverse of the incoming edge.
+func leftmostEdge(edges []overlayEdge, candidates []int, incoming overlayEdge) (int, bool) {
+	reverse := math.Atan2(incoming.from.y-incoming.to.y, incoming.from.x-incoming.to.x)
+	output, best := -1, math.Inf(1)
+
+	for _, candidate := range candidates {
+		current := edges[candidate]
+		turn := math.Mod(reverse-math.Atan2(current.to.y-current.from.y, current.to.x-current.from.x), 2*math.Pi) //nolint: gomnd
+
+		if turn <= 0 {
+			turn += 2 * math.Pi
+		}
+
+		if turn < best {
+			output, best = candidate, turn
+		}
+	}
+
+	return output, output >= 0
+}
+
+// mergeLines merges the edges of the lines into linestrings, in the direction of
+// the input geometries; the linestrings are split where more than two edges meet.
+func (r *overlayResult) mergeLines() {
+	incident := map[xy][]int{}
+
+	for idx, current := range r.lineEdges {
+		incident[current.from] = append(incident[current.from], idx)
+		incident[current.to] = append(incident[current.to], idx)
+	}
+
+	used := make([]bool, len(r.lineEdges))
+
+	// next finds the unused edge continuing the line at a node of degree 2.
+	next := func(position xy) (int, bool) {
+		if len(incident[position]) != 2 { //nolint: gomnd
+			return -1, false
+		}
+
+		for _, candidate := range incident[position] {
+			if !used[candidate] {
+				return candidate, true
+			}
+		}
+
+		return -1, false
+	}
+
+	for start, current := range r.lineEdges {
+		if used[start] {
+			continue
+		}
+
+		used[start] = true
+		line := []xy{current.from, current.to}
+
+		for idx, ok := next(line[len(line)-1]); ok; idx, ok = next(line[len(line)-1]) {
+			used[idx] = true
+			line = append(line, otherEnd(r.lineEdges[idx], line[len(line)-1]))
+		}
+
+		for idx, ok := next(line[0]); ok; idx, ok = next(line[0]) {
+			used[idx] = true
+			line = append([]xy{otherEnd(r.lineEdges[idx], line[0])}, line...)
+		}
+
+		r.lines = append(r.lines, line)
+	}
+}
+
+func otherEnd(current overlayEdge, position xy) xy {
+	if current.from == position {
+		return current.to
+	}
+
+	return current.from
+}
+
+// collectPoints collects the points and the vertices of both geometries that
+// are in the result, but not covered by its areas or its lines: the isolated
+// points, and the points where the geometries only touch.
+func (r *overlayResult) collectPoints(first *relateGeometry, second *relateGeometry, operation overlayOperation) {
+	candidates := append(append([]xy{}, first.points...), second.points...)
+
+	for _, path := range append(first.paths(), second.paths()...) {
+		candidates = append(candidates, *path...)
+	}
+
+	seen := map[xy]bool{}
+
+	for _, position := range candidates {
+		if seen[position] || r.ends[position] {
+			continue
+		}
+
+		seen[position] = true
+
+		if operation.keep(first.locate(position) != locationExterior, second.locate(position) != locationExterior) &&
+			!r.covers(position) {
+			r.points = append(r.points, position)
+		}
+	}
+}
+
+// covers checks if a position is on a line, or in an area of the result.
+func (r *overlayResult) covers(position xy) bool {
+	for _, current := range r.lineEdges {
+		if onSegment(position, current.from, current.to) {
+			return true
+		}
+	}
+
+	for _, polygon := range r.polygons {
+		if locateInPolygon(position, polygon) != locationExterior {
+			return true
+		}
+	}
+
+	return false
+}
+
+// geometry converts the result: a single part, a multi geometry of parts of the
+// same dimension, or a collection of areas, then lines, then points. An empty
+// result has the given dimension.
+func (r *overlayResult) geometry(srid *ewkb.SystemReferenceID, emptyDimension int) ewkb.Geometry { //nolint: ireturn
+	polygons := make([]ewkb.Polygon, len(r.polygons))
+
+	for idx, polygon := range r.polygons {
+		polygons[idx].CoordinateGroup = make(ewkb.CoordinateGroup, len(polygon))
+
+		for ringIdx, ring := range polygon {
+			polygons[idx].CoordinateGroup[ringIdx] = coordinatesOf(ring)
+		}
+	}
+
+	lines := make([]ewkb.LineString, len(r.lines))
+
+	for idx, line := range r.lines {
+		lines[idx].CoordinateSet = coordinatesOf(line)
+	}
+
+	points := make([]ewkb.Point, len(r.points))
+
+	for idx, position := range r.points {
+		points[idx].Coordinate = ewkb.Coordinate{'x': position.x, 'y': position.y}
+	}
+
+	switch {
+	case len(polygons)+len(lines)+len(points) == 0:
+		return emptyOf(srid, emptyDimension)
+	case len(lines)+len(points) == 0:
+		if len(polygons) == 1 {
+			return &ewkb.Polygon{SRID: srid, CoordinateGroup: polygons[0].CoordinateGroup}
+		}
+
+		return &ewkb.MultiPolygon{SRID: srid, Polygons: polygons}
+	case len(polygons)+len(points) == 0:
+		if len(lines) == 1 {
+			return &ewkb.LineString{SRID: srid, CoordinateSet: lines[0].CoordinateSet}
+		}
+
+		return &ewkb.MultiLineString{SRID: srid, LineStrings: lines}
+	case len(polygons)+len(lines) == 0:
+		if len(points) == 1 {
+			return &ewkb.Point{SRID: srid, Coordinate: points[0].Coordinate}
+		}
+
+		return &ewkb.MultiPoint{SRID: srid, Points: points}
+	}
+
+	output := ewkb.GeometryCollection{SRID: srid}
+
+	for idx := range polygons {
+		output.Collection = append(output.Collection, &polygons[idx])
+	}
+
+	for idx := range lines {
+		output.Collection = append(output.Collection, &lines[idx])
+	}
+
+	for idx := range points {
+		output.Collection = append(output.Collection, &points[idx])
+	}
+
+	return &output
+}
+
+// emptyOf creates an empty geometry of a dimension.
+func emptyOf(srid *ewkb.SystemReferenceID, dimension int) ewkb.Geometry { //nolint: ireturn
+	switch dimension {
+	case 0:
+		return &ewkb.Point{SRID: srid}
+	case 1:
+		return &ewkb.LineString{SRID: srid}
+	case 2: //nolint: gomnd
+		return &ewkb.Polygon{SRID: srid}
+	}
+
+	return &ewkb.GeometryCollection{SRID: srid}
+}
+
+func coordinatesOf(positions []xy) ewkb.CoordinateSet {
+	output := make(ewkb.CoordinateSet, len(positions))
+
+	for idx, position := range positions {
+		output[idx] = ewkb.Coordinate{'x': position.x, 'y': position.y}
+	}
+
+	return output
+}
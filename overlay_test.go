@@ -0,0 +1,235 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlay(t *testing.T) {
+	square := gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}
+	other := gogis.Polygon{line(5, 5, 15, 5, 15, 15, 5, 15, 5, 5)}
+
+	type operation func(gogis.EWKBConverter, gogis.EWKBConverter) (gogis.Geometry, error)
+
+	for _, testCase := range []struct {
+		name         string
+		operation    operation
+		first        gogis.EWKBConverter
+		second       gogis.EWKBConverter
+		expectedType ewkb.GeometryType
+		expected     gogis.EWKBConverter
+	}{
+		{
+			name:         "intersection",
+			operation:    gogis.Intersection,
+			first:        square,
+			second:       other,
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(5, 5, 10, 5, 10, 10, 5, 10, 5, 5)},
+		},
+		{
+			name:         "union",
+			operation:    gogis.Union,
+			first:        square,
+			second:       other,
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(0, 0, 10, 0, 10, 5, 15, 5, 15, 15, 5, 15, 5, 10, 0, 10, 0, 0)},
+		},
+		{
+			name:         "difference",
+			operation:    gogis.Difference,
+			first:        square,
+			second:       other,
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(0, 0, 10, 0, 10, 5, 5, 5, 5, 10, 0, 10, 0, 0)},
+		},
+		{
+			name:         "symmetric difference",
+			operation:    gogis.SymDifference,
+			first:        square,
+			second:       other,
+			expectedType: ewkb.GeometryTypeMultiPolygon,
+			expected: gogis.MultiPolygon{
+				{line(0, 0, 10, 0, 10, 5, 5, 5, 5, 10, 0, 10, 0, 0)},
+				{line(10, 5, 15, 5, 15, 15, 5, 15, 5, 10, 10, 10, 10, 5)},
+			},
+		},
+		{
+			name:         "hole",
+			operation:    gogis.Difference,
+			first:        square,
+			second:       gogis.Polygon{line(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{square[0], line(2, 2, 2, 4, 4, 4, 4, 2, 2, 2)},
+		},
+		{
+			name:         "hole filled by union",
+			operation:    gogis.Union,
+			first:        gogis.Polygon{square[0], line(2, 2, 2, 4, 4, 4, 4, 2, 2, 2)},
+			second:       gogis.Polygon{line(1, 1, 5, 1, 5, 5, 1, 5, 1, 1)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     square,
+		},
+		{
+			name:         "hole clipped",
+			operation:    gogis.Intersection,
+			first:        gogis.Polygon{square[0], line(2, 2, 2, 4, 4, 4, 4, 2, 2, 2)},
+			second:       gogis.Polygon{line(3, 0, 10, 0, 10, 10, 3, 10, 3, 0)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(3, 0, 10, 0, 10, 10, 3, 10, 3, 4, 4, 4, 4, 2, 3, 2, 3, 0)},
+		},
+		{
+			name:         "hole touching the shell",
+			operation:    gogis.Difference,
+			first:        square,
+			second:       gogis.Polygon{line(0, 5, 4, 3, 4, 7, 0, 5)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{square[0], line(0, 5, 4, 7, 4, 3, 0, 5)},
+		},
+		{
+			name:      "ring through a node twice",
+			operation: gogis.Union,
+			first:     gogis.Polygon{line(0, 0, 5, 0, 5, 2, 2, 2, 2, 8, 5, 8, 5, 10, 0, 10, 0, 0)},
+			second: gogis.MultiPolygon{
+				{line(5, 0, 10, 0, 10, 5, 8, 2, 5, 2, 5, 0)},
+				{line(10, 5, 10, 10, 5, 10, 5, 8, 8, 8, 10, 5)},
+			},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{square[0], line(10, 5, 8, 2, 2, 2, 2, 8, 8, 8, 10, 5)},
+		},
+		{
+			// 9, 2.6999999999999997 (0.3 * 9) is at a rounding error from the diagonal.
+			name:         "sliver",
+			operation:    gogis.Union,
+			first:        gogis.Polygon{line(0, 0, 10, 3, 0, 3, 0, 0)},
+			second:       gogis.Polygon{line(0, 0, 10, 0, 10, 3, 9, 2.6999999999999997, 0, 0)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(0, 0, 10, 0, 10, 3, 0, 3, 0, 0)},
+		},
+		{
+			// The area of the triangle is a rounding error.
+			name:         "sliver dropped",
+			operation:    gogis.Union,
+			first:        other,
+			second:       gogis.Polygon{line(0, 0, -10, -3, -9, -2.6999999999999997, 0, 0)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     other,
+		},
+		{
+			name:         "sliver intersection",
+			operation:    gogis.Intersection,
+			first:        gogis.Polygon{line(0, 0, 10, 3, 0, 3, 0, 0)},
+			second:       gogis.Polygon{line(0, 0, 10, 0, 10, 3, 9, 2.6999999999999997, 0, 0)},
+			expectedType: ewkb.GeometryTypeLineString,
+			expected:     line(0, 0, 9, 2.6999999999999997, 10, 3),
+		},
+		{
+			name:         "adjacent union",
+			operation:    gogis.Union,
+			first:        square,
+			second:       gogis.Polygon{line(10, 0, 20, 0, 20, 10, 10, 10, 10, 0)},
+			expectedType: ewkb.GeometryTypePolygon,
+			expected:     gogis.Polygon{line(0, 0, 20, 0, 20, 10, 0, 10, 0, 0)},
+		},
+		{
+			name:         "shared edge",
+			operation:    gogis.Intersection,
+			first:        square,
+			second:       gogis.Polygon{line(10, 0, 20, 0, 20, 10, 10, 10, 10, 0)},
+			expectedType: ewkb.GeometryTypeLineString,
+			expected:     line(10, 0, 10, 10),
+		},
+		{
+			name:         "shared corner",
+			operation:    gogis.Intersection,
+			first:        square,
+			second:       gogis.Polygon{line(10, 10, 20, 10, 20, 20, 10, 20, 10, 10)},
+			expectedType: ewkb.GeometryTypePoint,
+			expected:     &gogis.Point{Coordinate: ewkb.Coordinate{'x': 10, 'y': 10}},
+		},
+		{
+			name:         "touching union",
+			operation:    gogis.Union,
+			first:        square,
+			second:       gogis.Polygon{line(10, 10, 20, 10, 20, 20, 10, 20, 10, 10)},
+			expectedType: ewkb.GeometryTypeMultiPolygon,
+			expected:     gogis.MultiPolygon{square, {line(10, 10, 20, 10, 20, 20, 10, 20, 10, 10)}},
+		},
+		{
+			name:         "clipped line",
+			operation:    gogis.Intersection,
+			first:        line(-5, 5, 15, 5),
+			second:       square,
+			expectedType: ewkb.GeometryTypeLineString,
+			expected:     line(0, 5, 10, 5),
+		},
+		{
+			name:         "line difference",
+			operation:    gogis.Difference,
+			first:        line(-5, 5, 15, 5),
+			second:       square,
+			expectedType: ewkb.GeometryTypeMultiLineString,
+			expected:     gogis.MultiLineString{line(-5, 5, 0, 5), line(10, 5, 15, 5)},
+		},
+		{
+			name:         "points",
+			operation:    gogis.Intersection,
+			first:        &gogis.MultiPoint{{Coordinate: ewkb.Coordinate{'x': 5, 'y': 5}}, {Coordinate: ewkb.Coordinate{'x': 20, 'y': 20}}},
+			second:       square,
+			expectedType: ewkb.GeometryTypePoint,
+			expected:     &gogis.Point{Coordinate: ewkb.Coordinate{'x': 5, 'y': 5}},
+		},
+		{
+			name:         "crossing lines",
+			operation:    gogis.Intersection,
+			first:        line(0, 0, 2, 2),
+			second:       line(0, 2, 2, 0),
+			expectedType: ewkb.GeometryTypePoint,
+			expected:     &gogis.Point{Coordinate: ewkb.Coordinate{'x': 1, 'y': 1}},
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := testCase.operation(testCase.first, testCase.second)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedType, result.Type)
+
+			converter, ok := result.Geometry.(gogis.EWKBConverter)
+			require.True(t, ok)
+			assert.Empty(t, gogis.Validate(converter))
+
+			equals, err := gogis.Equals(converter, testCase.expected)
+			require.NoError(t, err)
+			assert.True(t, equals, "%v", result.Geometry)
+		})
+	}
+
+	t.Run("collection", func(t *testing.T) {
+		result, err := gogis.Union(square, line(5, 5, 15, 5))
+		require.NoError(t, err)
+		require.Equal(t, ewkb.GeometryTypeGeometryCollection, result.Type)
+
+		collection, ok := result.Geometry.(*gogis.GeometryCollection)
+		require.True(t, ok)
+		require.Len(t, collection.Collection, 2)
+		assert.InDelta(t, 100.0, collection.Area(), 1e-9)
+		assert.InDelta(t, 5.0, collection.Length(), 1e-9)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := gogis.Intersection(square, gogis.Polygon{line(20, 20, 30, 20, 30, 30, 20, 20)})
+		require.NoError(t, err)
+		assert.Equal(t, ewkb.GeometryTypePolygon, result.Type)
+		assert.True(t, result.IsEmpty())
+	})
+
+	t.Run("curve", func(t *testing.T) {
+		_, err := gogis.Intersection(square, gogis.CircularString(line(0, 0, 1, 1, 2, 0)))
+		require.ErrorIs(t, err, gogis.ErrUnsupportedGeometry)
+	})
+}
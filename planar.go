@@ -0,0 +1,275 @@
+package gogis
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// xy is a planar position: planar algorithms ignore Z and M.
+type xy struct {
+	x float64
+	y float64
+}
+
+// location is the location of a position relatively to an area.
+type location int8
+
+const (
+	locationExterior location = iota
+	locationBoundary
+	locationInterior
+)
+
+// intersectionKind is the kind of intersection of two segments.
+type intersectionKind uint8
+
+const (
+	// intersectionNone: the segments do not intersect.
+	intersectionNone intersectionKind = iota
+
+	// intersectionProper: the segments cross at a point, inside both segments.
+	intersectionProper
+
+	// intersectionTouch: the segments intersect at a point, at an end of a segment.
+	intersectionTouch
+
+	// intersectionOverlap: the segments are collinear, and share a part.
+	intersectionOverlap
+)
+
+// segmentIntersection is the intersection of two segments: a point, or the
+// part from point to end when they overlap.
+type segmentIntersection struct {
+	kind  intersectionKind
+	point xy
+	end   xy
+}
+
+func xyOf(coord ewkb.Coordinate) xy {
+	return xy{x: coord['x'], y: coord['y']}
+}
+
+// xysOf converts a set of coordinates.
+func xysOf(set ewkb.CoordinateSet) []xy {
+	output := make([]xy, len(set))
+
+	for idx, coord := range set {
+		output[idx] = xyOf(coord)
+	}
+
+	return output
+}
+
+// coord converts to a 2D coordinate.
+func (p xy) coord() ewkb.Coord {
+	return ewkb.Coord{X: p.x, Y: p.y}
+}
+
+// point converts to a 2D point.
+func (p xy) point() Point {
+	return Point{Coordinate: ewkb.Coordinate{'x': p.x, 'y': p.y}}
+}
+
+// isFinite checks that the position has finite coordinates.
+func (p xy) isFinite() bool {
+	return !math.IsNaN(p.x) && !math.IsNaN(p.y) && !math.IsInf(p.x, 0) && !math.IsInf(p.y, 0)
+}
+
+// String formats the position as GEOS does in its messages.
+func (p xy) String() string {
+	return strconv.FormatFloat(p.x, 'g', 15, 64) + " " + strconv.FormatFloat(p.y, 'g', 15, 64) //nolint: gomnd
+}
+
+// cross is the cross product of (a, b) and (a, c): positive when c is on the
+// left of (a, b), negative on the right, 0 when collinear.
+func cross(a xy, b xy, c xy) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
+
+// inSegmentBox checks if p is in the bounding box of (a, b).
+func inSegmentBox(p xy, a xy, b xy) bool {
+	return p.x >= math.Min(a.x, b.x) && p.x <= math.Max(a.x, b.x) &&
+		p.y >= math.Min(a.y, b.y) && p.y <= math.Max(a.y, b.y)
+}
+
+// onSegment checks if p is on the segment (a, b).
+func onSegment(p xy, a xy, b xy) bool {
+	return cross(a, b, p) == 0 && inSegmentBox(p, a, b)
+}
+
+// segmentBoxesIntersect checks if the bounding boxes of the segments intersect.
+func segmentBoxesIntersect(a xy, b xy, c xy, d xy) bool {
+	return math.Max(a.x, b.x) >= math.Min(c.x, d.x) && math.Max(c.x, d.x) >= math.Min(a.x, b.x) &&
+		math.Max(a.y, b.y) >= math.Min(c.y, d.y) && math.Max(c.y, d.y) >= math.Min(a.y, b.y)
+}
+
+// intersectSegments computes the intersection of the segments (a, b) and (c, d).
+func intersectSegments(a xy, b xy, c xy, d xy) segmentIntersection {
+	if !segmentBoxesIntersect(a, b, c, d) {
+		return segmentIntersection{}
+	}
+
+	crossA := cross(c, d, a)
+	crossB := cross(c, d, b)
+	crossC := cross(a, b, c)
+	crossD := cross(a, b, d)
+
+	if crossA == 0 && crossB == 0 {
+		return intersectCollinear(a, b, c, d)
+	}
+
+	if crossA*crossB < 0 && crossC*crossD < 0 {
+		ratio := crossA / (crossA - crossB)
+
+		return segmentIntersection{
+			kind:  intersectionProper,
+			point: xy{x: a.x + ratio*(b.x-a.x), y: a.y + ratio*(b.y-a.y)},
+		}
+	}
+
+	for _, candidate := range []struct {
+		point xy
+		cross float64
+		from  xy
+		to    xy
+	}{
+		{point: a, cross: crossA, from: c, to: d},
+		{point: b, cross: crossB, from: c, to: d},
+		{point: c, cross: crossC, from: a, to: b},
+		{point: d, cross: crossD, from: a, to: b},
+	} {
+		if candidate.cross == 0 && inSegmentBox(candidate.point, candidate.from, candidate.to) {
+			return segmentIntersection{kind: intersectionTouch, point: candidate.point}
+		}
+	}
+
+	return segmentIntersection{}
+}
+
+// intersectCollinear computes the intersection of collinear segments.
+func intersectCollinear(a xy, b xy, c xy, d xy) segmentIntersection {
+	// Project on the main axis of (a, b).
+	project := func(p xy) float64 { return p.x }
+	if math.Abs(b.y-a.y) > math.Abs(b.x-a.x) {
+		project = func(p xy) float64 { return p.y }
+	}
+
+	start, end := a, b
+	if project(start) > project(end) {
+		start, end = end, start
+	}
+
+	otherStart, otherEnd := c, d
+	if project(otherStart) > project(otherEnd) {
+		otherStart, otherEnd = otherEnd, otherStart
+	}
+
+	if project(otherStart) > project(start) {
+		start = otherStart
+	}
+
+	if project(otherEnd) < project(end) {
+		end = otherEnd
+	}
+
+	switch {
+	case project(start) > project(end):
+		return segmentIntersection{}
+	case project(start) == project(end):
+		return segmentIntersection{kind: intersectionTouch, point: start}
+	default:
+		return segmentIntersection{kind: intersectionOverlap, point: start, end: end}
+	}
+}
+
+// locateInRing locates a position relatively to the area of a closed ring.
+func locateInRing(p xy, ring []xy) location {
+	inside := false
+
+	for idx := 1; idx < len(ring); idx++ {
+		from, to := ring[idx-1], ring[idx]
+
+		if onSegment(p, from, to) {
+			return locationBoundary
+		}
+
+		if (from.y > p.y) != (to.y > p.y) &&
+			p.x < from.x+(p.y-from.y)*(to.x-from.x)/(to.y-from.y) {
+			inside = !inside
+		}
+	}
+
+	if inside {
+		return locationInterior
+	}
+
+	return locationExterior
+}
+
+// locateInPolygon locates a position relatively to the area of a polygon
+// (the first ring is the shell, the next ones are the holes).
+func locateInPolygon(p xy, rings [][]xy) location {
+	if len(rings) == 0 {
+		return locationExterior
+	}
+
+	output := locateInRing(p, rings[0])
+	if output != locationInterior {
+		return output
+	}
+
+	for _, hole := range rings[1:] {
+		switch locateInRing(p, hole) {
+		case locationBoundary:
+			return locationBoundary
+		case locationInterior:
+			return locationExterior
+		case locationExterior:
+		}
+	}
+
+	return locationInterior
+}
+
+// signedArea is the area of a closed ring: positive when counter clockwise.
+func signedArea(ring []xy) float64 {
+	area := 0.0
+
+	for idx := 1; idx < len(ring); idx++ {
+		area += ring[idx-1].x*ring[idx].y - ring[idx].x*ring[idx-1].y
+	}
+
+	return area / 2 //nolint: gomnd
+}
+
+// removeRepeated removes the consecutive repeated positions.
+func removeRepeated(positions []xy) []xy {
+	output := make([]xy, 0, len(positions))
+
+	for _, position := range positions {
+		if len(output) == 0 || output[len(output)-1] != position {
+			output = append(output, position)
+		}
+	}
+
+	return output
+}
+
+// distance is the distance between two positions.
+func distance(a xy, b xy) float64 {
+	return math.Hypot(b.x-a.x, b.y-a.y)
+}
+
+// segmentDistance is the distance from p to the segment (a, b).
+func segmentDistance(p xy, a xy, b xy) float64 {
+	length := (b.x-a.x)*(b.x-a.x) + (b.y-a.y)*(b.y-a.y)
+	if length == 0 {
+		return distance(p, a)
+	}
+
+	ratio := math.Max(0, math.Min(1, ((p.x-a.x)*(b.x-a.x)+(p.y-a.y)*(b.y-a.y))/length))
+
+	return distance(p, xy{x: a.x + ratio*(b.x-a.x), y: a.y + ratio*(b.y-a.y)})
+}
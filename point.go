@@ -10,6 +10,7 @@ import (
 type Point ewkb.Point
 
 // NullPoint represents a Point that may be null.
+// It has the same semantics as Null[*Point].
 // NullPoint implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,24 +29,13 @@ type NullPoint struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *NullPoint) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	point := ewkb.Point{}
-	if err := ewkb.Unmarshal(&point, value); err != nil {
-		return err
-	}
-
-	p.Valid = !point.Coordinate.IsNull()
-
-	return (&p.Point).FromEWKB(point)
+	return scanNull(value, &p.Point, &p.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *Point) Scan(value interface{}) error {
 	point := ewkb.Point{}
-	if err := ewkb.Unmarshal(&point, value); err != nil {
+	if err := unmarshal(&point, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -66,6 +56,11 @@ func (p NullPoint) Value() (driver.Value, error) {
 	return p.Point.Value()
 }
 
+// State is the NULL / EMPTY / value state of the Point.
+func (p NullPoint) State() State {
+	return stateOf(p.Valid, p.Point)
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (p *Point) FromEWKB(from interface{}) error {
 	pnt, ok := fromPtr(from).(ewkb.Point)
@@ -97,3 +92,37 @@ func (p Point) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the point.
+func (p Point) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(p.ToEWKB())
+}
+
+// PointArray is an array of Point (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type PointArray []Point
+
+// Scan implements the SQL driver.Scanner interface.
+func (p *PointArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*p = make(PointArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*p)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (p PointArray) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(p), func(idx int) (driver.Value, error) {
+		return p[idx].Value()
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/landru29/gogis"
 	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestPoint(t *testing.T) {
@@ -70,3 +71,45 @@ func TestPoint(t *testing.T) {
 		})
 	})
 }
+
+func TestPointArray(t *testing.T) {
+	fixture := gogis.PointArray{fixturePointOneTwo(), fixturePointThreeFour()}
+
+	dataByte := []byte("{" + pointOneTwo + ":" + pointThreeFour + "}")
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          dataByte,
+			scanner:          &gogis.PointArray{},
+			expectedGeometry: &fixture,
+		})
+	})
+
+	t.Run("scan empty", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          []byte("{}"),
+			scanner:          &gogis.PointArray{},
+			expectedGeometry: &gogis.PointArray{},
+		})
+	})
+
+	t.Run("scan NULL element", func(t *testing.T) {
+		array := gogis.PointArray{}
+
+		assert.ErrorIs(t, array.Scan("{NULL:"+pointOneTwo+"}"), gogis.ErrNullArrayElement)
+	})
+
+	t.Run("value with data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: dataByte,
+			valuer:          fixture,
+		})
+	})
+
+	t.Run("value null data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: nil,
+			valuer:          gogis.PointArray(nil),
+		})
+	})
+}
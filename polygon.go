@@ -10,6 +10,7 @@ import (
 type Polygon []LineString
 
 // NullPolygon represents a Polygon that may be null.
+// It has the same semantics as Null[*Polygon].
 // NullPolygon implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullPolygon struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *NullPolygon) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	polygon := ewkb.Polygon{}
-
-	if err := ewkb.Unmarshal(&polygon, value); err != nil {
-		return err
-	}
-
-	p.Valid = true
-
-	return (&p.Polygon).FromEWKB(polygon)
+	return scanNull(value, &p.Polygon, &p.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (p *Polygon) Scan(value interface{}) error {
 	polygon := ewkb.Polygon{}
 
-	if err := ewkb.Unmarshal(&polygon, value); err != nil {
+	if err := unmarshal(&polygon, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -85,6 +74,11 @@ func (p NullPolygon) Value() (driver.Value, error) {
 	return p.Polygon.Value()
 }
 
+// State is the NULL / EMPTY / value state of the Polygon.
+func (p NullPolygon) State() State {
+	return stateOf(p.Valid, p.Polygon)
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (p *Polygon) FromEWKB(from interface{}) error {
 	polygon, ok := fromPtr(from).(ewkb.Polygon)
@@ -130,6 +124,17 @@ func (p Polygon) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &polygon
 }
 
+// IsEmpty checks if the Polygon has no ring, or only empty rings.
+func (p Polygon) IsEmpty() bool {
+	for _, ring := range p {
+		if !ring.IsEmpty() {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Geometry converts to a generic geometry.
 func (p Polygon) Geometry(opts ...func(interface{})) Geometry {
 	output := Geometry{
@@ -144,3 +149,37 @@ func (p Polygon) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the polygon.
+func (p Polygon) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(p.ToEWKB())
+}
+
+// PolygonArray is an array of Polygon (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type PolygonArray []Polygon
+
+// Scan implements the SQL driver.Scanner interface.
+func (p *PolygonArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*p = make(PolygonArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*p)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (p PolygonArray) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(p), func(idx int) (driver.Value, error) {
+		return p[idx].Value()
+	})
+}
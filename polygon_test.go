@@ -132,3 +132,35 @@ func TestPolygon(t *testing.T) {
 		})
 	})
 }
+
+func TestPolygonArray(t *testing.T) {
+	// SELECT array_agg(g) FROM (VALUES ('POLYGON((0 0,1 0,1 1,0 0))'::geometry), ('POLYGON EMPTY')) AS v(g).
+	dataByte := []byte("{0103000000010000000400000000000000000000000000000000000000000000000000F03F0000000000000000000000000000F03F000000000000F03F00000000000000000000000000000000:010300000000000000}")
+
+	fixture := gogis.PolygonArray{
+		{
+			{
+				{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}},
+				{Coordinate: ewkb.Coordinate{'x': 1, 'y': 0}},
+				{Coordinate: ewkb.Coordinate{'x': 1, 'y': 1}},
+				{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0}},
+			},
+		},
+		{},
+	}
+
+	t.Run("scan with data", func(t *testing.T) {
+		scanTest(t, testFixtureScan{
+			rawData:          dataByte,
+			scanner:          &gogis.PolygonArray{},
+			expectedGeometry: &fixture,
+		})
+	})
+
+	t.Run("value with data", func(t *testing.T) {
+		valueTest(t, testFixtureValue{
+			expectedRawData: dataByte,
+			valuer:          fixture,
+		})
+	})
+}
@@ -0,0 +1,353 @@
+package raster
+
+import (
+	"fmt"
+	"math"
+)
+
+// PixelType is the type of the pixels of a band (4 lower bits of the band flags).
+type PixelType uint8
+
+const (
+	// PixelType1BB stands for 1-bit boolean.
+	PixelType1BB PixelType = 0
+
+	// PixelType2BUI stands for 2-bit unsigned integer.
+	PixelType2BUI PixelType = 1
+
+	// PixelType4BUI stands for 4-bit unsigned integer.
+	PixelType4BUI PixelType = 2
+
+	// PixelType8BSI stands for 8-bit signed integer.
+	PixelType8BSI PixelType = 3
+
+	// PixelType8BUI stands for 8-bit unsigned integer.
+	PixelType8BUI PixelType = 4
+
+	// PixelType16BSI stands for 16-bit signed integer.
+	PixelType16BSI PixelType = 5
+
+	// PixelType16BUI stands for 16-bit unsigned integer.
+	PixelType16BUI PixelType = 6
+
+	// PixelType32BSI stands for 32-bit signed integer.
+	PixelType32BSI PixelType = 7
+
+	// PixelType32BUI stands for 32-bit unsigned integer.
+	PixelType32BUI PixelType = 8
+
+	// PixelType32BF stands for 32-bit float.
+	PixelType32BF PixelType = 10
+
+	// PixelType64BF stands for 64-bit float.
+	PixelType64BF PixelType = 11
+)
+
+const (
+	bandFlagOffline   uint8 = 0x80
+	bandFlagHasNoData uint8 = 0x40
+	bandFlagIsNoData  uint8 = 0x20
+	bandFlagPixelType uint8 = 0x0f
+)
+
+// String implements the fmt.Stringer interface.
+func (p PixelType) String() string {
+	switch p {
+	case PixelType1BB:
+		return "1BB"
+	case PixelType2BUI:
+		return "2BUI"
+	case PixelType4BUI:
+		return "4BUI"
+	case PixelType8BSI:
+		return "8BSI"
+	case PixelType8BUI:
+		return "8BUI"
+	case PixelType16BSI:
+		return "16BSI"
+	case PixelType16BUI:
+		return "16BUI"
+	case PixelType32BSI:
+		return "32BSI"
+	case PixelType32BUI:
+		return "32BUI"
+	case PixelType32BF:
+		return "32BF"
+	case PixelType64BF:
+		return "64BF"
+	}
+
+	return fmt.Sprintf("PixelType(%d)", uint8(p))
+}
+
+// Size is the number of bytes used to store a pixel.
+// Sub-byte types are stored on a whole byte.
+func (p PixelType) Size() int {
+	switch p {
+	case PixelType1BB, PixelType2BUI, PixelType4BUI, PixelType8BSI, PixelType8BUI:
+		return 1
+	case PixelType16BSI, PixelType16BUI:
+		return 2 //nolint: gomnd
+	case PixelType32BSI, PixelType32BUI, PixelType32BF:
+		return 4 //nolint: gomnd
+	case PixelType64BF:
+		return 8 //nolint: gomnd
+	}
+
+	return 0
+}
+
+// MaxValue is the maximum value a pixel can hold.
+func (p PixelType) MaxValue() float64 {
+	switch p {
+	case PixelType1BB:
+		return 1
+	case PixelType2BUI:
+		return 3 //nolint: gomnd
+	case PixelType4BUI:
+		return 15 //nolint: gomnd
+	case PixelType8BSI:
+		return math.MaxInt8
+	case PixelType8BUI:
+		return math.MaxUint8
+	case PixelType16BSI:
+		return math.MaxInt16
+	case PixelType16BUI:
+		return math.MaxUint16
+	case PixelType32BSI:
+		return math.MaxInt32
+	case PixelType32BUI:
+		return math.MaxUint32
+	case PixelType32BF:
+		return math.MaxFloat32
+	case PixelType64BF:
+		return math.MaxFloat64
+	}
+
+	return 0
+}
+
+// newData allocates the typed slice matching the pixel type.
+func (p PixelType) newData(size int) (interface{}, error) {
+	switch p {
+	case PixelType1BB, PixelType2BUI, PixelType4BUI, PixelType8BUI:
+		return make([]uint8, size), nil
+	case PixelType8BSI:
+		return make([]int8, size), nil
+	case PixelType16BSI:
+		return make([]int16, size), nil
+	case PixelType16BUI:
+		return make([]uint16, size), nil
+	case PixelType32BSI:
+		return make([]int32, size), nil
+	case PixelType32BUI:
+		return make([]uint32, size), nil
+	case PixelType32BF:
+		return make([]float32, size), nil
+	case PixelType64BF:
+		return make([]float64, size), nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrWrongPixelType, uint8(p))
+}
+
+// Band is a raster band.
+//
+// Pixels of an in-db band are stored in Data, row by row, with the Go type
+// matching the pixel type:
+//
+//   - 1BB, 2BUI, 4BUI, 8BUI: []uint8
+//   - 8BSI: []int8
+//   - 16BSI: []int16
+//   - 16BUI: []uint16
+//   - 32BSI: []int32
+//   - 32BUI: []uint32
+//   - 32BF: []float32
+//   - 64BF: []float64
+//
+// An out-db band has no Data, but a band number and a path to the file.
+type Band struct {
+	PixelType PixelType
+	HasNoData bool
+	IsNoData  bool
+	NoData    float64
+
+	Data interface{}
+
+	IsOffline       bool
+	OutDBBandNumber uint8
+	OutDBPath       string
+}
+
+// Len is the number of pixels stored in the band.
+func (b Band) Len() int {
+	switch data := b.Data.(type) {
+	case []uint8:
+		return len(data)
+	case []int8:
+		return len(data)
+	case []int16:
+		return len(data)
+	case []uint16:
+		return len(data)
+	case []int32:
+		return len(data)
+	case []uint32:
+		return len(data)
+	case []float32:
+		return len(data)
+	case []float64:
+		return len(data)
+	}
+
+	return 0
+}
+
+// Values converts the pixels to float64 whatever the pixel type.
+func (b Band) Values() ([]float64, error) {
+	if err := b.check(); err != nil {
+		return nil, err
+	}
+
+	output := make([]float64, b.Len())
+
+	for idx := range output {
+		output[idx] = b.at(idx)
+	}
+
+	return output, nil
+}
+
+// Uint8s returns the pixels of 1BB, 2BUI, 4BUI and 8BUI bands.
+func (b Band) Uint8s() ([]uint8, error) {
+	data, ok := b.Data.([]uint8)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Int8s returns the pixels of 8BSI bands.
+func (b Band) Int8s() ([]int8, error) {
+	data, ok := b.Data.([]int8)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Int16s returns the pixels of 16BSI bands.
+func (b Band) Int16s() ([]int16, error) {
+	data, ok := b.Data.([]int16)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Uint16s returns the pixels of 16BUI bands.
+func (b Band) Uint16s() ([]uint16, error) {
+	data, ok := b.Data.([]uint16)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Int32s returns the pixels of 32BSI bands.
+func (b Band) Int32s() ([]int32, error) {
+	data, ok := b.Data.([]int32)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Uint32s returns the pixels of 32BUI bands.
+func (b Band) Uint32s() ([]uint32, error) {
+	data, ok := b.Data.([]uint32)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Float32s returns the pixels of 32BF bands.
+func (b Band) Float32s() ([]float32, error) {
+	data, ok := b.Data.([]float32)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// Float64s returns the pixels of 64BF bands.
+func (b Band) Float64s() ([]float64, error) {
+	data, ok := b.Data.([]float64)
+	if !ok {
+		return nil, b.typeError()
+	}
+
+	return data, nil
+}
+
+// IsNoDataValue checks if the value is the nodata value of the band.
+func (b Band) IsNoDataValue(value float64) bool {
+	return b.HasNoData && (value == b.NoData || (value != value && b.NoData != b.NoData))
+}
+
+func (b Band) typeError() error {
+	if b.IsOffline {
+		return ErrOutDBBand
+	}
+
+	return fmt.Errorf("%w: band is %s, data is %T", ErrWrongPixelType, b.PixelType, b.Data)
+}
+
+// check verifies the consistency between the pixel type and the data.
+func (b Band) check() error {
+	if b.IsOffline {
+		return ErrOutDBBand
+	}
+
+	expected, err := b.PixelType.newData(0)
+	if err != nil {
+		return err
+	}
+
+	if fmt.Sprintf("%T", expected) != fmt.Sprintf("%T", b.Data) {
+		return b.typeError()
+	}
+
+	return nil
+}
+
+func (b Band) at(idx int) float64 {
+	switch data := b.Data.(type) {
+	case []uint8:
+		return float64(data[idx])
+	case []int8:
+		return float64(data[idx])
+	case []int16:
+		return float64(data[idx])
+	case []uint16:
+		return float64(data[idx])
+	case []int32:
+		return float64(data[idx])
+	case []uint32:
+		return float64(data[idx])
+	case []float32:
+		return float64(data[idx])
+	case []float64:
+		return data[idx]
+	}
+
+	return math.NaN()
+}
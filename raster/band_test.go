@@ -0,0 +1,51 @@
+package raster_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis/raster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPixelType(t *testing.T) {
+	assert.Equal(t, "1BB", raster.PixelType1BB.String())
+	assert.Equal(t, "64BF", raster.PixelType64BF.String())
+	assert.Equal(t, "PixelType(9)", raster.PixelType(9).String())
+
+	assert.Equal(t, 1, raster.PixelType4BUI.Size())
+	assert.Equal(t, 2, raster.PixelType16BSI.Size())
+	assert.Equal(t, 4, raster.PixelType32BF.Size())
+	assert.Equal(t, 8, raster.PixelType64BF.Size())
+
+	assert.Equal(t, 3.0, raster.PixelType2BUI.MaxValue())
+}
+
+func TestBandAccessors(t *testing.T) {
+	band := raster.Band{
+		PixelType: raster.PixelType16BSI,
+		HasNoData: true,
+		NoData:    -9999,
+		Data:      []int16{-9999, 1200},
+	}
+
+	data, err := band.Int16s()
+	require.NoError(t, err)
+	assert.Equal(t, []int16{-9999, 1200}, data)
+
+	values, err := band.Values()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{-9999, 1200}, values)
+
+	assert.True(t, band.IsNoDataValue(-9999))
+	assert.False(t, band.IsNoDataValue(1200))
+
+	_, err = band.Uint8s()
+	assert.ErrorIs(t, err, raster.ErrWrongPixelType)
+
+	_, err = band.Float32s()
+	assert.ErrorIs(t, err, raster.ErrWrongPixelType)
+
+	_, err = raster.Band{PixelType: raster.PixelType8BUI, Data: []int16{1}}.Values()
+	assert.ErrorIs(t, err, raster.ErrWrongPixelType)
+}
@@ -0,0 +1,188 @@
+package raster
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+const (
+	bigEndian    = 0
+	littleEndian = 1
+)
+
+// Decoder is a binary WKB raster decoder.
+type Decoder struct {
+	reader    io.Reader
+	byteOrder binary.ByteOrder
+}
+
+// NewDecoder creates a WKB raster decoder reading binary data.
+// Use hex.NewDecoder to read the hexadecimal form.
+func NewDecoder(reader io.Reader) *Decoder {
+	return &Decoder{
+		reader: reader,
+	}
+}
+
+// Decode decodes a raster.
+func (d *Decoder) Decode(rast *Raster) error {
+	endianness := make([]byte, 1)
+	if _, err := io.ReadFull(d.reader, endianness); err != nil {
+		return err
+	}
+
+	switch endianness[0] {
+	case bigEndian:
+		d.byteOrder = binary.BigEndian
+	case littleEndian:
+		d.byteOrder = binary.LittleEndian
+	default:
+		return ErrWrongByteOrder
+	}
+
+	var header struct {
+		Version    uint16
+		BandCount  uint16
+		ScaleX     float64
+		ScaleY     float64
+		UpperLeftX float64
+		UpperLeftY float64
+		SkewX      float64
+		SkewY      float64
+		SRID       int32
+		Width      uint16
+		Height     uint16
+	}
+
+	if err := d.read(&header); err != nil {
+		return err
+	}
+
+	if header.Version != 0 {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, header.Version)
+	}
+
+	*rast = Raster{
+		Version:    header.Version,
+		ScaleX:     header.ScaleX,
+		ScaleY:     header.ScaleY,
+		UpperLeftX: header.UpperLeftX,
+		UpperLeftY: header.UpperLeftY,
+		SkewX:      header.SkewX,
+		SkewY:      header.SkewY,
+		SRID:       ewkb.SystemReferenceID(header.SRID),
+		Width:      header.Width,
+		Height:     header.Height,
+		Bands:      make([]Band, 0, header.BandCount),
+	}
+
+	for idx := 0; idx < int(header.BandCount); idx++ {
+		band, err := d.decodeBand(rast.PixelCount())
+		if err != nil {
+			return fmt.Errorf("band %d: %w", idx, err)
+		}
+
+		rast.Bands = append(rast.Bands, band)
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeBand(pixelCount int) (Band, error) {
+	var flags uint8
+
+	if err := d.read(&flags); err != nil {
+		return Band{}, err
+	}
+
+	band := Band{
+		PixelType: PixelType(flags & bandFlagPixelType),
+		IsOffline: flags&bandFlagOffline != 0,
+		HasNoData: flags&bandFlagHasNoData != 0,
+		IsNoData:  flags&bandFlagIsNoData != 0,
+	}
+
+	noData, err := band.PixelType.newData(1)
+	if err != nil {
+		return Band{}, err
+	}
+
+	if err := d.read(noData); err != nil {
+		return Band{}, err
+	}
+
+	band.NoData = Band{Data: noData}.at(0)
+
+	if band.IsOffline {
+		if err := d.read(&band.OutDBBandNumber); err != nil {
+			return Band{}, err
+		}
+
+		path, err := d.readString()
+		if err != nil {
+			return Band{}, err
+		}
+
+		band.OutDBPath = path
+
+		return band, nil
+	}
+
+	// The pixels are read before the band is allocated, so that a header
+	// claiming more pixels than the input holds allocates no more than the input.
+	size := int64(pixelCount) * int64(band.PixelType.Size())
+
+	pixels, err := io.ReadAll(io.LimitReader(d.reader, size))
+	if err != nil {
+		return Band{}, err
+	}
+
+	if int64(len(pixels)) < size {
+		return Band{}, io.ErrUnexpectedEOF
+	}
+
+	data, err := band.PixelType.newData(pixelCount)
+	if err != nil {
+		return Band{}, err
+	}
+
+	if err := binary.Read(bytes.NewReader(pixels), d.byteOrder, data); err != nil {
+		return Band{}, err
+	}
+
+	band.Data = data
+
+	return band, nil
+}
+
+// readString reads a null-terminated string byte per byte, so that
+// nothing is consumed after the terminator.
+func (d *Decoder) readString() (string, error) {
+	output := []byte{}
+	char := make([]byte, 1)
+
+	for {
+		if _, err := io.ReadFull(d.reader, char); err != nil {
+			return "", io.ErrUnexpectedEOF
+		}
+
+		if char[0] == 0 {
+			return string(output), nil
+		}
+
+		output = append(output, char[0])
+	}
+}
+
+func (d *Decoder) read(data interface{}) error {
+	err := binary.Read(d.reader, d.byteOrder, data)
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
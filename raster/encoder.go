@@ -0,0 +1,147 @@
+package raster
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Encoder is a binary WKB raster encoder.
+type Encoder struct {
+	writer    io.Writer
+	byteOrder binary.ByteOrder
+}
+
+// NewEncoder creates a WKB raster encoder writing binary data.
+// Use hex.NewEncoder to write the hexadecimal form.
+func NewEncoder(writer io.Writer) *Encoder {
+	return &Encoder{
+		writer:    writer,
+		byteOrder: binary.LittleEndian,
+	}
+}
+
+// Encode encodes a raster.
+func (e *Encoder) Encode(rast Raster) error {
+	endianness := uint8(littleEndian)
+	if e.byteOrder == binary.BigEndian {
+		endianness = bigEndian
+	}
+
+	if len(rast.Bands) > math.MaxUint16 {
+		return fmt.Errorf("%w: too many bands", ErrIncompatibleFormat)
+	}
+
+	header := struct {
+		Endianness uint8
+		Version    uint16
+		BandCount  uint16
+		ScaleX     float64
+		ScaleY     float64
+		UpperLeftX float64
+		UpperLeftY float64
+		SkewX      float64
+		SkewY      float64
+		SRID       int32
+		Width      uint16
+		Height     uint16
+	}{
+		Endianness: endianness,
+		Version:    rast.Version,
+		BandCount:  uint16(len(rast.Bands)),
+		ScaleX:     rast.ScaleX,
+		ScaleY:     rast.ScaleY,
+		UpperLeftX: rast.UpperLeftX,
+		UpperLeftY: rast.UpperLeftY,
+		SkewX:      rast.SkewX,
+		SkewY:      rast.SkewY,
+		SRID:       int32(rast.SRID),
+		Width:      rast.Width,
+		Height:     rast.Height,
+	}
+
+	if err := binary.Write(e.writer, e.byteOrder, header); err != nil {
+		return err
+	}
+
+	for idx, band := range rast.Bands {
+		if err := e.encodeBand(band, rast.PixelCount()); err != nil {
+			return fmt.Errorf("band %d: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Encoder) encodeBand(band Band, pixelCount int) error {
+	flags := uint8(band.PixelType) & bandFlagPixelType
+
+	if band.IsOffline {
+		flags |= bandFlagOffline
+	}
+
+	if band.HasNoData {
+		flags |= bandFlagHasNoData
+	}
+
+	if band.IsNoData {
+		flags |= bandFlagIsNoData
+	}
+
+	noData, err := band.PixelType.newData(1)
+	if err != nil {
+		return err
+	}
+
+	setValue(noData, band.NoData)
+
+	if err := binary.Write(e.writer, e.byteOrder, flags); err != nil {
+		return err
+	}
+
+	if err := binary.Write(e.writer, e.byteOrder, noData); err != nil {
+		return err
+	}
+
+	if band.IsOffline {
+		if err := binary.Write(e.writer, e.byteOrder, band.OutDBBandNumber); err != nil {
+			return err
+		}
+
+		_, err := e.writer.Write(append([]byte(band.OutDBPath), 0))
+
+		return err
+	}
+
+	if err := band.check(); err != nil {
+		return err
+	}
+
+	if band.Len() != pixelCount {
+		return fmt.Errorf("%w: found %d pixels, expected %d", ErrWrongBandSize, band.Len(), pixelCount)
+	}
+
+	return binary.Write(e.writer, e.byteOrder, band.Data)
+}
+
+func setValue(data interface{}, value float64) {
+	switch out := data.(type) {
+	case []uint8:
+		out[0] = uint8(value)
+	case []int8:
+		out[0] = int8(value)
+	case []int16:
+		out[0] = int16(value)
+	case []uint16:
+		out[0] = uint16(value)
+	case []int32:
+		out[0] = int32(value)
+	case []uint32:
+		out[0] = uint32(value)
+	case []float32:
+		out[0] = float32(value)
+	case []float64:
+		out[0] = value
+	}
+}
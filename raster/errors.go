@@ -0,0 +1,31 @@
+package raster
+
+// Error is a raster error.
+type Error string
+
+const (
+	// ErrWrongByteOrder occurs when byte order is not recognized.
+	ErrWrongByteOrder = Error("wrong byte order")
+
+	// ErrIncompatibleFormat occurs when raster formats are incompatible.
+	ErrIncompatibleFormat = Error("incompatible format")
+
+	// ErrUnsupportedVersion occurs when the serialization version is not 0.
+	ErrUnsupportedVersion = Error("unsupported raster version")
+
+	// ErrWrongPixelType occurs when the pixel type is unknown or does not match the band data.
+	ErrWrongPixelType = Error("wrong pixel type")
+
+	// ErrWrongBandSize occurs when the band data does not match the raster dimension.
+	ErrWrongBandSize = Error("wrong band size")
+
+	// ErrOutDBBand occurs when accessing pixels of a band stored outside the database.
+	ErrOutDBBand = Error("out-db band has no pixel")
+
+	// ErrUnsupportedImage occurs when bands cannot be converted to an image.
+	ErrUnsupportedImage = Error("unsupported image")
+)
+
+func (e Error) Error() string {
+	return string(e)
+}
@@ -0,0 +1,152 @@
+package raster
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+const (
+	rgbBandCount  = 3
+	rgbaBandCount = 4
+)
+
+// BandImage converts a band to a grayscale image.
+//
+//   - 8BUI bands give *image.Gray with the raw pixel values.
+//   - 16BUI bands give *image.Gray16 with the raw pixel values.
+//   - 1BB, 2BUI and 4BUI bands give *image.Gray, stretched to the full 0-255 range.
+//   - Other bands give *image.Gray16, linearly stretched between the minimum and
+//     the maximum of the band (nodata pixels are black).
+func (r Raster) BandImage(index int) (image.Image, error) { //nolint: ireturn
+	if index < 0 || index >= len(r.Bands) {
+		return nil, fmt.Errorf("%w: no band %d", ErrUnsupportedImage, index)
+	}
+
+	band := r.Bands[index]
+
+	if err := band.check(); err != nil {
+		return nil, err
+	}
+
+	if band.Len() != r.PixelCount() {
+		return nil, fmt.Errorf("%w: found %d pixels, expected %d", ErrWrongBandSize, band.Len(), r.PixelCount())
+	}
+
+	bounds := image.Rect(0, 0, int(r.Width), int(r.Height))
+
+	switch band.PixelType {
+	case PixelType8BUI:
+		img := image.NewGray(bounds)
+		copy(img.Pix, band.Data.([]uint8)) //nolint: forcetypeassert
+
+		return img, nil
+
+	case PixelType16BUI:
+		img := image.NewGray16(bounds)
+
+		for idx, value := range band.Data.([]uint16) { //nolint: forcetypeassert
+			img.SetGray16(idx%int(r.Width), idx/int(r.Width), color.Gray16{Y: value})
+		}
+
+		return img, nil
+
+	case PixelType1BB, PixelType2BUI, PixelType4BUI:
+		img := image.NewGray(bounds)
+		maxValue := band.PixelType.MaxValue()
+
+		for idx, value := range band.Data.([]uint8) { //nolint: forcetypeassert
+			img.Pix[idx] = uint8(math.Min(float64(value), maxValue) * math.MaxUint8 / maxValue)
+		}
+
+		return img, nil
+
+	case PixelType8BSI, PixelType16BSI, PixelType32BSI, PixelType32BUI, PixelType32BF, PixelType64BF:
+		return r.stretchedImage(band, bounds), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrWrongPixelType, band.PixelType)
+}
+
+// Image converts the raster to an image.
+//
+// Rasters with 3 (RGB) or 4 (RGBA) 8BUI bands give *image.NRGBA.
+// Rasters with a single band give the same image as BandImage(0).
+func (r Raster) Image() (image.Image, error) { //nolint: ireturn
+	switch len(r.Bands) {
+	case 1:
+		return r.BandImage(0)
+	case rgbBandCount, rgbaBandCount:
+	default:
+		return nil, fmt.Errorf("%w: %d bands", ErrUnsupportedImage, len(r.Bands))
+	}
+
+	channels := make([][]uint8, len(r.Bands))
+
+	for idx, band := range r.Bands {
+		if band.PixelType != PixelType8BUI {
+			return nil, fmt.Errorf("%w: band %d is %s", ErrUnsupportedImage, idx, band.PixelType)
+		}
+
+		data, err := band.Uint8s()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(data) != r.PixelCount() {
+			return nil, fmt.Errorf("%w: found %d pixels, expected %d", ErrWrongBandSize, len(data), r.PixelCount())
+		}
+
+		channels[idx] = data
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, int(r.Width), int(r.Height)))
+
+	for idx := 0; idx < r.PixelCount(); idx++ {
+		alpha := uint8(math.MaxUint8)
+		if len(channels) == rgbaBandCount {
+			alpha = channels[3][idx]
+		}
+
+		img.Pix[idx*4] = channels[0][idx]
+		img.Pix[idx*4+1] = channels[1][idx]
+		img.Pix[idx*4+2] = channels[2][idx]
+		img.Pix[idx*4+3] = alpha
+	}
+
+	return img, nil
+}
+
+func (r Raster) stretchedImage(band Band, bounds image.Rectangle) *image.Gray16 {
+	img := image.NewGray16(bounds)
+
+	minValue := math.Inf(1)
+	maxValue := math.Inf(-1)
+
+	for idx := 0; idx < band.Len(); idx++ {
+		value := band.at(idx)
+		if band.IsNoDataValue(value) || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+	}
+
+	for idx := 0; idx < band.Len(); idx++ {
+		value := band.at(idx)
+		if band.IsNoDataValue(value) || math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+
+		gray := uint16(math.MaxUint16)
+		if maxValue > minValue {
+			gray = uint16((value - minValue) / (maxValue - minValue) * math.MaxUint16)
+		}
+
+		img.SetGray16(idx%int(r.Width), idx/int(r.Width), color.Gray16{Y: gray})
+	}
+
+	return img
+}
@@ -0,0 +1,100 @@
+package raster_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/landru29/gogis/raster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRasterBandImage(t *testing.T) {
+	t.Run("8BUI", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterLittleEndian8BUI))
+
+		img, err := rast.BandImage(0)
+		require.NoError(t, err)
+
+		gray, ok := img.(*image.Gray)
+		require.True(t, ok)
+		assert.Equal(t, image.Rect(0, 0, 2, 2), gray.Bounds())
+		assert.Equal(t, color.Gray{Y: 3}, gray.GrayAt(0, 1))
+	})
+
+	t.Run("1BB", func(t *testing.T) {
+		img, err := raster.Raster{
+			Width:  2,
+			Height: 1,
+			Bands: []raster.Band{
+				{PixelType: raster.PixelType1BB, Data: []uint8{0, 1}},
+			},
+		}.BandImage(0)
+		require.NoError(t, err)
+
+		assert.Equal(t, []uint8{0, 255}, img.(*image.Gray).Pix) //nolint: forcetypeassert
+	})
+
+	t.Run("16BSI stretched", func(t *testing.T) {
+		img, err := raster.Raster{
+			Width:  3,
+			Height: 1,
+			Bands: []raster.Band{
+				{
+					PixelType: raster.PixelType16BSI,
+					HasNoData: true,
+					NoData:    -9999,
+					Data:      []int16{-9999, 100, 200},
+				},
+			},
+		}.BandImage(0)
+		require.NoError(t, err)
+
+		gray, ok := img.(*image.Gray16)
+		require.True(t, ok)
+		assert.Equal(t, color.Gray16{Y: 0}, gray.Gray16At(0, 0))
+		assert.Equal(t, color.Gray16{Y: 0}, gray.Gray16At(1, 0))
+		assert.Equal(t, color.Gray16{Y: 65535}, gray.Gray16At(2, 0))
+	})
+
+	t.Run("no band", func(t *testing.T) {
+		_, err := raster.Raster{}.BandImage(0)
+		assert.ErrorIs(t, err, raster.ErrUnsupportedImage)
+	})
+}
+
+func TestRasterImage(t *testing.T) {
+	t.Run("RGB", func(t *testing.T) {
+		img, err := raster.Raster{
+			Width:  1,
+			Height: 1,
+			Bands: []raster.Band{
+				{PixelType: raster.PixelType8BUI, Data: []uint8{10}},
+				{PixelType: raster.PixelType8BUI, Data: []uint8{20}},
+				{PixelType: raster.PixelType8BUI, Data: []uint8{30}},
+			},
+		}.Image()
+		require.NoError(t, err)
+
+		assert.Equal(t, color.NRGBA{R: 10, G: 20, B: 30, A: 255}, img.(*image.NRGBA).NRGBAAt(0, 0)) //nolint: forcetypeassert
+	})
+
+	t.Run("single band", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterLittleEndian8BUI))
+
+		img, err := rast.Image()
+		require.NoError(t, err)
+		assert.IsType(t, &image.Gray{}, img)
+	})
+
+	t.Run("two bands", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterBigEndianTwoBands))
+
+		_, err := rast.Image()
+		assert.ErrorIs(t, err, raster.ErrUnsupportedImage)
+	})
+}
@@ -0,0 +1,164 @@
+// Package raster decodes PostGIS raster serialization (WKB raster).
+//
+// The raster is encoded in hexadecimal by the database. There are 2 parts:
+//
+//   - The header
+//
+//   - The bands
+//
+// # HEADER
+//
+// Byte 0: 0 means big endian, 1 means little endian.
+//
+// Bytes 1-2: version (always 0).
+//
+// Bytes 3-4: number of bands.
+//
+// Then come 6 float64: scale X, scale Y, upper-left X, upper-left Y, skew X and skew Y,
+// followed by the SRID (int32), the width and the height (uint16).
+//
+// # BANDS
+//
+// Each band starts with a flag byte:
+//
+//   - bit 7 means out-db band.
+//
+//   - bit 6 means with nodata value.
+//
+//   - bit 5 means all pixels are nodata.
+//
+//   - bits 0-3 are for the pixel type.
+//
+// After that, come the nodata value (the size of a pixel), then either the pixels
+// (width x height values, row by row) for in-db bands, or the band number (uint8) and
+// the null-terminated path of the file for out-db bands.
+package raster
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/hex"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// Raster is a RASTER in database.
+type Raster struct {
+	Version    uint16
+	ScaleX     float64
+	ScaleY     float64
+	UpperLeftX float64
+	UpperLeftY float64
+	SkewX      float64
+	SkewY      float64
+	SRID       ewkb.SystemReferenceID
+	Width      uint16
+	Height     uint16
+	Bands      []Band
+}
+
+// NullRaster represents a Raster that may be null.
+// NullRaster implements the SQL driver.Scanner interface so it
+// can be used as a scan destination:
+//
+//	var tile raster.NullRaster
+//	err := db.QueryRow("SELECT rast FROM dem WHERE rid=?", id).Scan(&tile)
+//	...
+//	if tile.Valid {
+//	   // use tile.Raster
+//	} else {
+//	   // NULL value
+//	}
+type NullRaster struct {
+	Raster Raster
+	Valid  bool
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (r *Raster) Scan(value interface{}) error {
+	return Unmarshal(r, value)
+}
+
+// Value implements the driver.Valuer interface.
+func (r Raster) Value() (driver.Value, error) {
+	return Marshal(r)
+}
+
+// Scan implements the SQL driver.Scanner interface.
+func (r *NullRaster) Scan(value interface{}) error {
+	if dataBytes, ok := value.([]byte); value == nil || (ok && dataBytes == nil) {
+		*r = NullRaster{}
+
+		return nil
+	}
+
+	if err := (&r.Raster).Scan(value); err != nil {
+		return err
+	}
+
+	r.Valid = true
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (r NullRaster) Value() (driver.Value, error) {
+	if !r.Valid {
+		return nil, nil
+	}
+
+	return r.Raster.Value()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (r *Raster) UnmarshalBinary(data []byte) error {
+	return NewDecoder(bytes.NewBuffer(data)).Decode(r)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (r Raster) MarshalBinary() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+
+	err := NewEncoder(buffer).Encode(r)
+
+	return buffer.Bytes(), err
+}
+
+// Unmarshal converts hexadecimal WKB raster (as returned by the database) to Raster.
+func Unmarshal(rast *Raster, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	if strData, ok := value.(string); ok {
+		return Unmarshal(rast, []byte(strData))
+	}
+
+	dataByte, ok := value.([]byte)
+	if !ok {
+		return ErrIncompatibleFormat
+	}
+
+	return NewDecoder(hex.NewDecoder(bytes.NewBuffer(dataByte))).Decode(rast)
+}
+
+// Marshal converts Raster to hexadecimal WKB raster (as expected by the database).
+func Marshal(rast Raster) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+
+	err := NewEncoder(hex.NewEncoder(buffer)).Encode(rast)
+
+	return buffer.Bytes(), err
+}
+
+// PixelCount is the number of pixels of each band.
+func (r Raster) PixelCount() int {
+	return int(r.Width) * int(r.Height)
+}
+
+// Transform converts pixel coordinates (column, row) to world coordinates,
+// as ST_RasterToWorldCoord does (with 0-based pixel coordinates).
+func (r Raster) Transform(column float64, row float64) (float64, float64) {
+	return r.UpperLeftX + column*r.ScaleX + row*r.SkewX,
+		r.UpperLeftY + column*r.SkewY + row*r.ScaleY
+}
@@ -0,0 +1,221 @@
+package raster_test
+
+import (
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/landru29/gogis/ewkb"
+	"github.com/landru29/gogis/raster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// 2x2 raster, SRID 4326, one 8BUI band with nodata 0 and pixels 1, 2, 3, 4.
+	rasterLittleEndian8BUI = "0100000100000000000000F03F000000000000F0BF000000000000E03F000000000000E03F00000000000000000000000000000000E610000002000200440001020304"
+
+	// 2x1 raster, SRID 2154, big endian, a 16BSI band with nodata -9999 and a 64BF band.
+	rasterBigEndianTwoBands = "00000000024024000000000000C02400000000000040590000000000004069000000000000000000000000000000000000000000000000086A0002000145D8F1D8F104B00B00000000000000003FF8000000000000C002000000000000"
+
+	// 3x3 raster with an out-db 32BF band.
+	rasterOutDB = "0100000100000000000000F03F000000000000F0BF00000000000000000000000000000000000000000000000000000000000000000000000003000300CA000080BF002F746D702F64656D2E74696600"
+)
+
+func TestRasterScan(t *testing.T) {
+	t.Run("8BUI little endian", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		require.NoError(t, rast.Scan(rasterLittleEndian8BUI))
+		assert.Equal(t, raster.Raster{
+			ScaleX:     1,
+			ScaleY:     -1,
+			UpperLeftX: 0.5,
+			UpperLeftY: 0.5,
+			SRID:       ewkb.SystemReferenceWGS84,
+			Width:      2,
+			Height:     2,
+			Bands: []raster.Band{
+				{
+					PixelType: raster.PixelType8BUI,
+					HasNoData: true,
+					NoData:    0,
+					Data:      []uint8{1, 2, 3, 4},
+				},
+			},
+		}, rast)
+	})
+
+	t.Run("two bands big endian", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		require.NoError(t, rast.Scan([]byte(rasterBigEndianTwoBands)))
+		assert.Equal(t, raster.Raster{
+			ScaleX:     10,
+			ScaleY:     -10,
+			UpperLeftX: 100,
+			UpperLeftY: 200,
+			SRID:       2154,
+			Width:      2,
+			Height:     1,
+			Bands: []raster.Band{
+				{
+					PixelType: raster.PixelType16BSI,
+					HasNoData: true,
+					NoData:    -9999,
+					Data:      []int16{-9999, 1200},
+				},
+				{
+					PixelType: raster.PixelType64BF,
+					Data:      []float64{1.5, -2.25},
+				},
+			},
+		}, rast)
+	})
+
+	t.Run("out-db band", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		require.NoError(t, rast.Scan(rasterOutDB))
+		require.Len(t, rast.Bands, 1)
+		assert.Equal(t, raster.Band{
+			PixelType:       raster.PixelType32BF,
+			HasNoData:       true,
+			NoData:          -1,
+			IsOffline:       true,
+			OutDBBandNumber: 0,
+			OutDBPath:       "/tmp/dem.tif",
+		}, rast.Bands[0])
+
+		_, err := rast.Bands[0].Values()
+		assert.ErrorIs(t, err, raster.ErrOutDBBand)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		rast := raster.NullRaster{}
+
+		require.NoError(t, rast.Scan(nil))
+		assert.False(t, rast.Valid)
+
+		require.NoError(t, rast.Scan(rasterLittleEndian8BUI))
+		assert.True(t, rast.Valid)
+		assert.Equal(t, uint16(2), rast.Raster.Width)
+	})
+
+	t.Run("wrong byte order", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		assert.ErrorIs(t, rast.Scan("02"+rasterLittleEndian8BUI[2:]), raster.ErrWrongByteOrder)
+	})
+
+	t.Run("wrong version", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		assert.ErrorIs(t, rast.Scan("010100"+rasterLittleEndian8BUI[6:]), raster.ErrUnsupportedVersion)
+	})
+
+	t.Run("wrong pixel type", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		data := strings.Replace(rasterLittleEndian8BUI, "0200020044", "0200020049", 1)
+		assert.ErrorIs(t, rast.Scan(data), raster.ErrWrongPixelType)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		assert.Error(t, rast.Scan(rasterLittleEndian8BUI[:len(rasterLittleEndian8BUI)-2]))
+	})
+
+	t.Run("incompatible format", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		assert.ErrorIs(t, rast.Scan(42), raster.ErrIncompatibleFormat)
+	})
+
+	t.Run("claimed size larger than the input", func(t *testing.T) {
+		rast := raster.Raster{}
+
+		// 65535x65535 pixels of 64BF, with 4 pixels in the input.
+		data := strings.Replace(rasterLittleEndian8BUI, "0200020044", "FFFFFFFF4B", 1)
+		data = strings.Replace(data, "00"+"01020304", "0000000000000000"+strings.Repeat("0000000000000000", 4), 1)
+
+		var before, after runtime.MemStats
+
+		runtime.ReadMemStats(&before)
+		assert.ErrorIs(t, rast.Scan(data), io.ErrUnexpectedEOF)
+		runtime.ReadMemStats(&after)
+
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+	})
+}
+
+func TestRasterValue(t *testing.T) {
+	t.Run("round trip little endian", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterLittleEndian8BUI))
+
+		out, err := rast.Value()
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToLower(rasterLittleEndian8BUI), string(out.([]byte))) //nolint: forcetypeassert
+	})
+
+	t.Run("round trip out-db", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterOutDB))
+
+		out, err := raster.Marshal(rast)
+		require.NoError(t, err)
+		assert.Equal(t, strings.ToLower(rasterOutDB), string(out))
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		rast := raster.Raster{}
+		require.NoError(t, rast.Scan(rasterBigEndianTwoBands))
+
+		data, err := rast.MarshalBinary()
+		require.NoError(t, err)
+
+		decoded := raster.Raster{}
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, rast, decoded)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		out, err := raster.NullRaster{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("wrong band size", func(t *testing.T) {
+		_, err := raster.Raster{
+			Width:  2,
+			Height: 2,
+			Bands: []raster.Band{
+				{PixelType: raster.PixelType8BUI, Data: []uint8{1, 2, 3}},
+			},
+		}.Value()
+		assert.ErrorIs(t, err, raster.ErrWrongBandSize)
+	})
+
+	t.Run("wrong pixel type", func(t *testing.T) {
+		_, err := raster.Raster{
+			Width:  1,
+			Height: 1,
+			Bands: []raster.Band{
+				{PixelType: raster.PixelType16BUI, Data: []uint8{1}},
+			},
+		}.Value()
+		assert.ErrorIs(t, err, raster.ErrWrongPixelType)
+	})
+}
+
+func TestRasterTransform(t *testing.T) {
+	rast := raster.Raster{}
+	require.NoError(t, rast.Scan(rasterBigEndianTwoBands))
+
+	x, y := rast.Transform(1, 0)
+	assert.Equal(t, 110.0, x)
+	assert.Equal(t, 200.0, y)
+}
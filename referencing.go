@@ -0,0 +1,446 @@
+package gogis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+const (
+	// ErrNoMeasure occurs when a linear referencing operation on measures has
+	// vertices without M.
+	ErrNoMeasure = ewkb.Error("geometry has no measure")
+
+	// ErrInvalidFraction occurs when a fraction of a length is not between 0 and
+	// 1, or when a range of fractions is reversed.
+	ErrInvalidFraction = ewkb.Error("invalid fraction")
+)
+
+// LocateAlong computes the points of the linestring whose M is the measure
+// (ST_LocateAlong), interpolating the other values along the segments; a
+// segment with a constant measure gives its vertices. It fails without M
+// (ErrNoMeasure).
+func (l LineString) LocateAlong(measure float64) (MultiPoint, error) {
+	return MultiLineString{l}.LocateAlong(measure)
+}
+
+// LocateAlong computes the points of the linestrings whose M is the measure (ST_LocateAlong).
+func (m MultiLineString) LocateAlong(measure float64) (MultiPoint, error) {
+	if err := checkMeasure(m); err != nil {
+		return nil, err
+	}
+
+	output := MultiPoint{}
+
+	for _, part := range clipMeasure(m, measure, measure) {
+		for _, point := range part {
+			if len(output) == 0 || !samePoint(output[len(output)-1], point) {
+				output = append(output, point)
+			}
+		}
+	}
+
+	return output, nil
+}
+
+// LocateBetween computes the parts of the linestring whose M is in the range
+// (ST_LocateBetween), whose bounds are in any order: a multilinestring, or a
+// geometry collection when the linestring only touches the range at some
+// points. It fails without M (ErrNoMeasure).
+//
+//	section, err := track.LocateBetween(1200, 1850)
+func (l LineString) LocateBetween(from float64, to float64) (Geometry, error) {
+	return MultiLineString{l}.LocateBetween(from, to)
+}
+
+// LocateBetween computes the parts of the linestrings whose M is in the range (ST_LocateBetween).
+func (m MultiLineString) LocateBetween(from float64, to float64) (Geometry, error) {
+	if err := checkMeasure(m); err != nil {
+		return Geometry{}, err
+	}
+
+	parts := clipMeasure(m, from, to)
+	lines := MultiLineString{}
+	collection := GeometryCollection{SRID: m.srid(), Valid: true}
+
+	for idx, part := range parts {
+		if len(part) == 1 {
+			collection.Collection = append(collection.Collection, &parts[idx][0])
+
+			continue
+		}
+
+		lines = append(lines, part)
+		collection.Collection = append(collection.Collection, &parts[idx])
+	}
+
+	if len(lines) < len(parts) {
+		return collection.Geometry(), nil
+	}
+
+	return lines.Geometry(), nil
+}
+
+// InterpolatePoint computes the M of the closest point of the linestring to
+// the point (ST_InterpolatePoint). It fails without M (ErrNoMeasure), or on an
+// empty linestring (ErrEmptyGeometry).
+func (l LineString) InterpolatePoint(point Point) (float64, error) {
+	return MultiLineString{l}.InterpolatePoint(point)
+}
+
+// InterpolatePoint computes the M of the closest point of the linestrings to the point (ST_InterpolatePoint).
+func (m MultiLineString) InterpolatePoint(point Point) (float64, error) {
+	if err := checkMeasure(m); err != nil {
+		return 0, err
+	}
+
+	position, found := closestPosition(m, xyOf(point.Coordinate))
+	if !found {
+		return 0, ErrEmptyGeometry
+	}
+
+	return position.point.Coordinate['m'], nil
+}
+
+// LineInterpolatePoint computes the point at a fraction (from 0 to 1) of the 2D
+// length of the linestring (ST_LineInterpolatePoint), interpolating Z and M. It
+// is empty for an empty linestring, and fails when the fraction is out of range
+// (ErrInvalidFraction).
+func (l LineString) LineInterpolatePoint(fraction float64) (Point, error) {
+	return MultiLineString{l}.LineInterpolatePoint(fraction)
+}
+
+// LineInterpolatePoint computes the point at a fraction of the 2D length of the
+// linestrings (ST_LineInterpolatePoint), taken one after the other.
+func (m MultiLineString) LineInterpolatePoint(fraction float64) (Point, error) {
+	if fraction < 0 || fraction > 1 {
+		return Point{}, fmt.Errorf("%w: %v", ErrInvalidFraction, fraction)
+	}
+
+	target := fraction * planarLength(m)
+	along := 0.0
+	output := Point{}
+
+	for _, line := range m {
+		for idx := range line {
+			output = line[idx]
+
+			if idx == 0 {
+				continue
+			}
+
+			length := distance(xyOf(line[idx-1].Coordinate), xyOf(line[idx].Coordinate))
+			if length > 0 && along+length >= target {
+				return pointAt(line[idx-1], line[idx], (target-along)/length), nil
+			}
+
+			along += length
+		}
+	}
+
+	return output, nil
+}
+
+// LineLocatePoint computes the position of the closest point of the linestring
+// to the point, as a fraction of its 2D length (ST_LineLocatePoint). It fails on
+// an empty linestring (ErrEmptyGeometry).
+func (l LineString) LineLocatePoint(point Point) (float64, error) {
+	return MultiLineString{l}.LineLocatePoint(point)
+}
+
+// LineLocatePoint computes the position of the closest point of the linestrings
+// to the point, as a fraction of their 2D length (ST_LineLocatePoint), taken one
+// after the other.
+func (m MultiLineString) LineLocatePoint(point Point) (float64, error) {
+	position, found := closestPosition(m, xyOf(point.Coordinate))
+	if !found {
+		return 0, ErrEmptyGeometry
+	}
+
+	total := planarLength(m)
+	if total == 0 {
+		return 0, nil
+	}
+
+	return position.along / total, nil
+}
+
+// LineSubstring computes the part of the linestring between two fractions of
+// its 2D length (ST_LineSubstring), interpolating Z and M: a linestring, or a
+// point when the fractions are equal. It fails when the fractions are out of
+// range or reversed (ErrInvalidFraction).
+//
+//	firstHalf, err := track.LineSubstring(0, 0.5)
+func (l LineString) LineSubstring(from float64, to float64) (Geometry, error) {
+	parts, err := MultiLineString{l}.lineSubstring(from, to)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	switch {
+	case len(parts) == 0:
+		return LineString{}.Geometry(), nil
+	case from == to:
+		return parts[0][0].Geometry(), nil
+	}
+
+	return parts[0].Geometry(), nil
+}
+
+// LineSubstring computes the parts of the linestrings between two fractions of
+// their 2D length (ST_LineSubstring), taken one after the other: a
+// multilinestring, or a point when the fractions are equal.
+func (m MultiLineString) LineSubstring(from float64, to float64) (Geometry, error) {
+	parts, err := m.lineSubstring(from, to)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	if from == to && len(parts) > 0 {
+		return parts[0][0].Geometry(), nil
+	}
+
+	return parts.Geometry(), nil
+}
+
+// lineSubstring computes the parts of the linestrings between two fractions of
+// their 2D length; equal fractions give a single part of two identical points.
+func (m MultiLineString) lineSubstring(from float64, to float64) (MultiLineString, error) {
+	if from < 0 || to > 1 || from > to {
+		return nil, fmt.Errorf("%w: %v, %v", ErrInvalidFraction, from, to)
+	}
+
+	total := planarLength(m)
+	start, end := from*total, to*total
+	output := MultiLineString{}
+	along := 0.0
+
+	for _, line := range m {
+		length := planarLength(MultiLineString{line})
+		low, high := math.Max(start, along), math.Min(end, along+length)
+
+		if low < high || (low == high && start == end && len(output) == 0) {
+			if part := substring(line, low-along, high-along); len(part) > 0 {
+				output = append(output, part)
+			}
+		}
+
+		along += length
+	}
+
+	return output, nil
+}
+
+// checkMeasure fails when a vertex of the linestrings has no M.
+func checkMeasure(lines MultiLineString) error {
+	for lineIdx, line := range lines {
+		for idx, point := range line {
+			if _, found := point.Coordinate['m']; !found {
+				return fmt.Errorf("%w: linestring %d, vertex %d", ErrNoMeasure, lineIdx, idx)
+			}
+		}
+	}
+
+	return nil
+}
+
+// clipMeasure computes the parts of the linestrings whose M is in the range; a
+// part is a single point when a linestring only touches the range.
+func clipMeasure(lines MultiLineString, from float64, to float64) []LineString {
+	if from > to {
+		from, to = to, from
+	}
+
+	output := []LineString{}
+
+	for _, line := range lines {
+		if len(line) == 1 && from <= line[0].Coordinate['m'] && line[0].Coordinate['m'] <= to {
+			output = append(output, LineString{line[0]})
+		}
+
+		current := LineString{}
+
+		// The current part goes on with the next segment when it reaches its start.
+		open := false
+
+		for idx := 1; idx < len(line); idx++ {
+			low, high, inside := measureRange(line[idx-1].Coordinate['m'], line[idx].Coordinate['m'], from, to)
+			if !inside || !open || low > 0 {
+				if len(current) > 0 {
+					output = append(output, current)
+				}
+
+				current = LineString{}
+			}
+
+			open = inside && high == 1
+
+			if !inside {
+				continue
+			}
+
+			for _, point := range []Point{pointAt(line[idx-1], line[idx], low), pointAt(line[idx-1], line[idx], high)} {
+				if len(current) == 0 || !samePoint(current[len(current)-1], point) {
+					current = append(current, point)
+				}
+			}
+		}
+
+		if len(current) > 0 {
+			output = append(output, current)
+		}
+	}
+
+	return output
+}
+
+// measureRange computes the ratios of the segment whose measure is in the range.
+func measureRange(fromMeasure float64, toMeasure float64, from float64, to float64) (float64, float64, bool) {
+	if fromMeasure == toMeasure {
+		return 0, 1, from <= fromMeasure && fromMeasure <= to
+	}
+
+	low, high := (from-fromMeasure)/(toMeasure-fromMeasure), (to-fromMeasure)/(toMeasure-fromMeasure)
+	if low > high {
+		low, high = high, low
+	}
+
+	low, high = math.Max(low, 0), math.Min(high, 1)
+
+	return low, high, low <= high
+}
+
+// linePosition is the closest point of linestrings to a position, and its 2D
+// distance from the start of the linestrings.
+type linePosition struct {
+	point Point
+	along float64
+}
+
+// closestPosition finds the closest point of the linestrings to a position; it
+// fails when they are empty.
+func closestPosition(lines MultiLineString, position xy) (linePosition, bool) {
+	output := linePosition{}
+	minimum := math.Inf(1)
+	along := 0.0
+
+	for _, line := range lines {
+		for idx := range line {
+			from, to := line[idx], line[idx]
+
+			switch {
+			case idx+1 < len(line):
+				to = line[idx+1]
+			case idx > 0:
+				continue
+			}
+
+			start, end := xyOf(from.Coordinate), xyOf(to.Coordinate)
+			ratio := projectionRatio(position, start, end)
+			point := pointAt(from, to, ratio)
+
+			if squared := squaredDistance(position, xyOf(point.Coordinate)); squared < minimum {
+				minimum = squared
+				output = linePosition{point: point, along: along + ratio*distance(start, end)}
+			}
+
+			along += distance(start, end)
+		}
+	}
+
+	return output, !math.IsInf(minimum, 1)
+}
+
+// projectionRatio is the position of the projection of p on the segment (a, b),
+// from 0 to 1.
+func projectionRatio(p xy, a xy, b xy) float64 {
+	length := squaredDistance(a, b)
+	if length == 0 {
+		return 0
+	}
+
+	return math.Max(0, math.Min(1, ((p.x-a.x)*(b.x-a.x)+(p.y-a.y)*(b.y-a.y))/length))
+}
+
+// substring computes the part of a linestring between two 2D distances from its start.
+func substring(line LineString, from float64, to float64) LineString {
+	output := LineString{}
+	along := 0.0
+
+	for idx := 1; idx < len(line); idx++ {
+		length := distance(xyOf(line[idx-1].Coordinate), xyOf(line[idx].Coordinate))
+		next := along + length
+
+		ratio := func(position float64) float64 {
+			if length == 0 {
+				return 0
+			}
+
+			return math.Max(0, math.Min(1, (position-along)/length))
+		}
+
+		if len(output) == 0 && (from < next || idx == len(line)-1) {
+			output = append(output, pointAt(line[idx-1], line[idx], ratio(from)))
+		}
+
+		if len(output) > 0 {
+			if to <= next || idx == len(line)-1 {
+				return append(output, pointAt(line[idx-1], line[idx], ratio(to)))
+			}
+
+			output = append(output, line[idx])
+		}
+
+		along = next
+	}
+
+	return output
+}
+
+// pointAt interpolates the point at a ratio of a segment.
+func pointAt(from Point, to Point, ratio float64) Point {
+	switch ratio {
+	case 0:
+		return from
+	case 1:
+		return to
+	}
+
+	start, end := xyOf(from.Coordinate), xyOf(to.Coordinate)
+
+	return Point{
+		SRID: from.SRID,
+		Coordinate: interpolateCoordinate(from.Coordinate, to.Coordinate, ratio, xy{
+			x: start.x + ratio*(end.x-start.x),
+			y: start.y + ratio*(end.y-start.y),
+		}),
+	}
+}
+
+func samePoint(a Point, b Point) bool {
+	if len(a.Coordinate) != len(b.Coordinate) {
+		return false
+	}
+
+	for name, value := range a.Coordinate {
+		if b.Coordinate[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// planarLength is the 2D length of linestrings.
+func planarLength(lines MultiLineString) float64 {
+	output := 0.0
+
+	for _, line := range lines {
+		for idx := 1; idx < len(line); idx++ {
+			output += distance(xyOf(line[idx-1].Coordinate), xyOf(line[idx].Coordinate))
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,193 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// measured creates a linestring from x, y, m values.
+func measured(values ...float64) gogis.LineString {
+	output := make(gogis.LineString, 0, len(values)/3)
+
+	for idx := 0; idx+2 < len(values); idx += 3 {
+		output = append(output, gogis.Point{Coordinate: ewkb.Coordinate{'x': values[idx], 'y': values[idx+1], 'm': values[idx+2]}})
+	}
+
+	return output
+}
+
+func at(x float64, y float64) gogis.Point {
+	return gogis.Point{Coordinate: ewkb.Coordinate{'x': x, 'y': y}}
+}
+
+func TestLinearReferencing(t *testing.T) {
+	// A track with its chainage in M, stopping between 100 and 150.
+	track := measured(0, 0, 0, 10, 0, 100, 10, 5, 100, 10, 10, 150, 20, 10, 250)
+
+	t.Run("locate along", func(t *testing.T) {
+		output, err := track.LocateAlong(50)
+		require.NoError(t, err)
+		assert.Equal(t, gogis.MultiPoint(measured(5, 0, 50)), output)
+
+		output, err = track.LocateAlong(100)
+		require.NoError(t, err)
+		assert.Equal(t, gogis.MultiPoint(measured(10, 0, 100, 10, 5, 100)), output)
+
+		output, err = track.LocateAlong(300)
+		require.NoError(t, err)
+		assert.Empty(t, output)
+
+		// A line going back and forth.
+		output, err = measured(0, 0, 0, 10, 0, 10, 20, 0, 0).LocateAlong(5)
+		require.NoError(t, err)
+		assert.Equal(t, gogis.MultiPoint(measured(5, 0, 5, 15, 0, 5)), output)
+	})
+
+	t.Run("locate between", func(t *testing.T) {
+		output, err := track.LocateBetween(200, 50)
+		require.NoError(t, err)
+		assert.Equal(t, ewkb.GeometryTypeMultiLineString, output.Type)
+		assert.Equal(t,
+			&gogis.MultiLineString{measured(5, 0, 50, 10, 0, 100, 10, 5, 100, 10, 10, 150, 15, 10, 200)},
+			output.Geometry,
+		)
+
+		output, err = measured(0, 0, 0, 10, 0, 10, 20, 0, 0, 30, 0, 10, 40, 0, 20).LocateBetween(8, 30)
+		require.NoError(t, err)
+		assert.Equal(t,
+			&gogis.MultiLineString{measured(8, 0, 8, 10, 0, 10, 12, 0, 8), measured(28, 0, 8, 30, 0, 10, 40, 0, 20)},
+			output.Geometry,
+		)
+
+		// The line only touches the range at a vertex.
+		output, err = measured(0, 0, 0, 10, 0, 10, 20, 0, 0, 30, 0, 20).LocateBetween(10, 15)
+		require.NoError(t, err)
+		require.Equal(t, ewkb.GeometryTypeGeometryCollection, output.Type)
+
+		collection, ok := output.Geometry.(*gogis.GeometryCollection)
+		require.True(t, ok)
+		require.Len(t, collection.Collection, 2)
+
+		point := measured(10, 0, 10)[0]
+		assert.Equal(t, &point, collection.Collection[0])
+		assert.Equal(t, &gogis.LineString{measured(25, 0, 10)[0], measured(27.5, 0, 15)[0]}, collection.Collection[1])
+	})
+
+	t.Run("interpolate point", func(t *testing.T) {
+		measure, err := track.InterpolatePoint(at(4, -3))
+		require.NoError(t, err)
+		assert.InDelta(t, 40, measure, 1e-9)
+
+		measure, err = track.InterpolatePoint(at(12, 7.5))
+		require.NoError(t, err)
+		assert.InDelta(t, 125, measure, 1e-9)
+
+		measure, err = track.InterpolatePoint(at(30, 20))
+		require.NoError(t, err)
+		assert.InDelta(t, 250, measure, 1e-9)
+
+		measure, err = gogis.MultiLineString{measured(0, 0, 0, 10, 0, 10), measured(0, 5, 100, 10, 5, 110)}.InterpolatePoint(at(5, 4))
+		require.NoError(t, err)
+		assert.InDelta(t, 105, measure, 1e-9)
+	})
+
+	t.Run("line interpolate point", func(t *testing.T) {
+		point, err := track.LineInterpolatePoint(0.5)
+		require.NoError(t, err)
+		assert.Equal(t, measured(10, 5, 100)[0], point)
+
+		point, err = track.LineInterpolatePoint(0.25)
+		require.NoError(t, err)
+		assert.InDelta(t, 7.5, point.Coordinate['x'], 1e-9)
+		assert.InDelta(t, 0, point.Coordinate['y'], 1e-9)
+		assert.InDelta(t, 75, point.Coordinate['m'], 1e-9)
+
+		point, err = track.LineInterpolatePoint(1)
+		require.NoError(t, err)
+		assert.Equal(t, track[4], point)
+
+		point, err = gogis.MultiLineString{line(0, 0, 10, 0), line(0, 5, 30, 5)}.LineInterpolatePoint(0.5)
+		require.NoError(t, err)
+		assert.Equal(t, at(10, 5), point)
+
+		point, err = gogis.LineString{}.LineInterpolatePoint(0.5)
+		require.NoError(t, err)
+		assert.True(t, point.IsEmpty())
+	})
+
+	t.Run("line locate point", func(t *testing.T) {
+		fraction, err := track.LineLocatePoint(at(12, 7.5))
+		require.NoError(t, err)
+		assert.InDelta(t, 17.5/30, fraction, 1e-9)
+
+		fraction, err = track.LineLocatePoint(at(-5, 5))
+		require.NoError(t, err)
+		assert.InDelta(t, 0, fraction, 1e-9)
+
+		fraction, err = gogis.MultiLineString{line(0, 0, 10, 0), line(0, 5, 30, 5)}.LineLocatePoint(at(20, 6))
+		require.NoError(t, err)
+		assert.InDelta(t, 0.75, fraction, 1e-9)
+
+		_, err = gogis.LineString{}.LineLocatePoint(at(0, 0))
+		require.ErrorIs(t, err, gogis.ErrEmptyGeometry)
+	})
+
+	t.Run("line substring", func(t *testing.T) {
+		substring := func(from float64, to float64) gogis.LineString {
+			output, err := track.LineSubstring(from, to)
+			require.NoError(t, err)
+			require.Equal(t, ewkb.GeometryTypeLineString, output.Type)
+
+			lineString, ok := output.Geometry.(*gogis.LineString)
+			require.True(t, ok)
+
+			return *lineString
+		}
+
+		assert.Equal(t, measured(7.5, 0, 75, 10, 0, 100, 10, 5, 100, 10, 10, 150, 12.5, 10, 175), substring(0.25, 0.75))
+		assert.Equal(t, measured(0, 0, 0, 7.5, 0, 75), substring(0, 0.25))
+		assert.Equal(t, measured(0, 0, 0, 10, 0, 100, 10, 5, 100), substring(0, 0.5))
+		assert.Equal(t, track, substring(0, 1))
+
+		output, err := track.LineSubstring(0.5, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, ewkb.GeometryTypePoint, output.Type)
+		assert.Equal(t, &measured(10, 5, 100)[0], output.Geometry)
+
+		lines, err := gogis.MultiLineString{line(0, 0, 10, 0), line(0, 5, 30, 5)}.LineSubstring(0.125, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, &gogis.MultiLineString{line(5, 0, 10, 0), line(0, 5, 10, 5)}, lines.Geometry)
+
+		output, err = gogis.MultiLineString{line(0, 0, 10, 0), line(0, 5, 30, 5)}.LineSubstring(0.5, 0.5)
+		require.NoError(t, err)
+		assert.Equal(t, &gogis.Point{Coordinate: ewkb.Coordinate{'x': 10, 'y': 5}}, output.Geometry)
+
+		_, err = track.LineSubstring(0.5, 0.25)
+		require.ErrorIs(t, err, gogis.ErrInvalidFraction)
+
+		_, err = track.LineInterpolatePoint(1.5)
+		require.ErrorIs(t, err, gogis.ErrInvalidFraction)
+	})
+
+	t.Run("no measure", func(t *testing.T) {
+		_, err := line(0, 0, 10, 0).LocateAlong(5)
+		require.ErrorIs(t, err, gogis.ErrNoMeasure)
+
+		_, err = line(0, 0, 10, 0).LocateBetween(5, 6)
+		require.ErrorIs(t, err, gogis.ErrNoMeasure)
+
+		_, err = line(0, 0, 10, 0).InterpolatePoint(at(0, 0))
+		require.ErrorIs(t, err, gogis.ErrNoMeasure)
+
+		// The second linestring, and the last vertex of the first one, have no M.
+		_, err = gogis.MultiLineString{measured(0, 0, 0, 10, 0, 10), line(0, 5, 10, 5)}.LocateAlong(5)
+		require.ErrorIs(t, err, gogis.ErrNoMeasure)
+
+		_, err = append(measured(0, 0, 0, 10, 0, 10), at(20, 0)).LocateBetween(5, 6)
+		require.ErrorIs(t, err, gogis.ErrNoMeasure)
+	})
+}
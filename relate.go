@@ -0,0 +1,1001 @@
+package gogis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// ErrUnsupportedGeometry occurs when an operation does not support a geometry
+// type (curves).
+const ErrUnsupportedGeometry = ewkb.Error("unsupported geometry")
+
+// snapTolerance is the relative distance of positions at a rounding error from
+// each other.
+const snapTolerance = 1e-12
+
+// IntersectionMatrix is the DE-9IM matrix of two geometries: the dimension of
+// the intersection of the interior, the boundary and the exterior of the first
+// geometry (rows) with the interior, the boundary and the exterior of the
+// second one (columns). An empty intersection is -1 (F).
+//
+//	matrix, err := gogis.Relate(polygon, point)
+//	// matrix.String() = "0F2FF1FF2": the point is inside the polygon.
+//
+// The boundary of a polygon is its rings, the boundary of a linestring is its
+// ends (mod-2 rule for multilinestrings: an end shared by two linestrings is in
+// the interior), and points have no boundary.
+type IntersectionMatrix [3][3]int
+
+// Relate computes the DE-9IM intersection matrix of two geometries, as
+// ST_Relate does. Curves are not supported (ErrUnsupportedGeometry), and Z and M
+// are ignored.
+func Relate(a EWKBConverter, b EWKBConverter) (IntersectionMatrix, error) {
+	first, err := newRelateGeometry(a.ToEWKB())
+	if err != nil {
+		return IntersectionMatrix{}, err
+	}
+
+	second, err := newRelateGeometry(b.ToEWKB())
+	if err != nil {
+		return IntersectionMatrix{}, err
+	}
+
+	return relate(first, second), nil
+}
+
+// Intersects checks if the geometries share at least a point (ST_Intersects).
+func Intersects(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Intersects)
+}
+
+// Disjoint checks if the geometries share no point (ST_Disjoint).
+func Disjoint(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Disjoint)
+}
+
+// Contains checks if no point of b is outside a, and at least a point of the
+// interior of b is in the interior of a (ST_Contains): a polygon does not
+// contain its boundary.
+func Contains(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Contains)
+}
+
+// Within checks if a is contained by b (ST_Within).
+func Within(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Within)
+}
+
+// Covers checks if no point of b is outside a (ST_Covers): unlike Contains, a
+// polygon covers its boundary.
+func Covers(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Covers)
+}
+
+// Touches checks if the geometries share a point, but their interiors do not
+// intersect (ST_Touches).
+func Touches(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Touches)
+}
+
+// Crosses checks if the geometries share some interior points, but not all of
+// them (ST_Crosses).
+func Crosses(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Crosses)
+}
+
+// Overlaps checks if the geometries have the same dimension, and share a part
+// of that dimension without one covering the other (ST_Overlaps).
+func Overlaps(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Overlaps)
+}
+
+// Equals checks if the geometries are topologically equal (ST_Equals): the
+// order and the repetition of the vertices do not matter.
+func Equals(a EWKBConverter, b EWKBConverter) (bool, error) {
+	return relatePredicate(a, b, IntersectionMatrix.Equals)
+}
+
+func relatePredicate(a EWKBConverter, b EWKBConverter, predicate func(IntersectionMatrix) bool) (bool, error) {
+	matrix, err := Relate(a, b)
+	if err != nil {
+		return false, err
+	}
+
+	return predicate(matrix), nil
+}
+
+// String formats the matrix as ST_Relate does ("212101212").
+func (m IntersectionMatrix) String() string {
+	output := make([]byte, 0, 9) //nolint: gomnd
+
+	for _, row := range m {
+		for _, dimension := range row {
+			if dimension < 0 {
+				output = append(output, 'F')
+
+				continue
+			}
+
+			output = append(output, byte('0'+dimension))
+		}
+	}
+
+	return string(output)
+}
+
+// Matches checks the matrix against a DE-9IM pattern, as ST_Relate(a, b, pattern)
+// does: T is any dimension, F is empty, * is anything, and 0, 1 or 2 is that
+// dimension. An invalid pattern matches nothing.
+func (m IntersectionMatrix) Matches(pattern string) bool {
+	if len(pattern) != 9 { //nolint: gomnd
+		return false
+	}
+
+	for idx := 0; idx < len(pattern); idx++ {
+		dimension := m[idx/3][idx%3]
+
+		switch pattern[idx] {
+		case '*':
+		case 'T', 't':
+			if dimension < 0 {
+				return false
+			}
+		case 'F', 'f':
+			if dimension >= 0 {
+				return false
+			}
+		case '0', '1', '2':
+			if dimension != int(pattern[idx]-'0') {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Intersects checks if the geometries share at least a point.
+func (m IntersectionMatrix) Intersects() bool {
+	return !m.Disjoint()
+}
+
+// Disjoint checks if the geometries share no point (FF*FF****).
+func (m IntersectionMatrix) Disjoint() bool {
+	return m.Matches("FF*FF****")
+}
+
+// Contains checks if the first geometry contains the second one (T*****FF*).
+func (m IntersectionMatrix) Contains() bool {
+	return m.Matches("T*****FF*")
+}
+
+// Within checks if the first geometry is within the second one (T*F**F***).
+func (m IntersectionMatrix) Within() bool {
+	return m.Matches("T*F**F***")
+}
+
+// Covers checks if the first geometry covers the second one.
+func (m IntersectionMatrix) Covers() bool {
+	return m.Matches("T*****FF*") || m.Matches("*T****FF*") ||
+		m.Matches("***T**FF*") || m.Matches("****T*FF*")
+}
+
+// Touches checks if the geometries touch; points never touch each other.
+func (m IntersectionMatrix) Touches() bool {
+	if first, second := m.dimensions(); first == 0 && second == 0 {
+		return false
+	}
+
+	return m.Matches("FT*******") || m.Matches("F**T*****") || m.Matches("F***T****")
+}
+
+// Crosses checks if the geometries cross; the pattern depends on their dimensions.
+func (m IntersectionMatrix) Crosses() bool {
+	first, second := m.dimensions()
+
+	switch {
+	case first == 1 && second == 1:
+		return m.Matches("0********")
+	case first >= 0 && first < second:
+		return m.Matches("T*T******")
+	case second >= 0 && first > second:
+		return m.Matches("T*****T**")
+	}
+
+	return false
+}
+
+// Overlaps checks if the geometries overlap; they must have the same dimension.
+func (m IntersectionMatrix) Overlaps() bool {
+	first, second := m.dimensions()
+
+	switch {
+	case first != second:
+		return false
+	case first == 1:
+		return m.Matches("1*T***T**")
+	case first >= 0:
+		return m.Matches("T*T***T**")
+	}
+
+	return false
+}
+
+// Equals checks if the geometries are topologically equal (T*F**FFF*); empty
+// geometries are equal.
+func (m IntersectionMatrix) Equals() bool {
+	first, second := m.dimensions()
+
+	switch {
+	case first != second:
+		return false
+	case first < 0:
+		return true
+	}
+
+	return m.Matches("T*F**FFF*")
+}
+
+// dimensions computes the dimensions of the geometries: the dimension of an
+// interior is the dimension of its intersection with the whole plane.
+func (m IntersectionMatrix) dimensions() (int, int) {
+	first, second := -1, -1
+
+	for idx := 0; idx < 3; idx++ {
+		first = maxInt(first, m[0][idx])
+		second = maxInt(second, m[idx][0])
+	}
+
+	return first, second
+}
+
+func (m *IntersectionMatrix) set(first location, second location, dimension int) {
+	row, column := matrixIndex(first), matrixIndex(second)
+
+	m[row][column] = maxInt(m[row][column], dimension)
+}
+
+// matrixIndex is the row (or the column) of a location in the matrix.
+func matrixIndex(loc location) int {
+	switch loc {
+	case locationInterior:
+		return 0
+	case locationBoundary:
+		return 1
+	case locationExterior:
+	}
+
+	return 2 //nolint: gomnd
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// relateGeometry is the planar topology of a geometry: its points, its lines and
+// its polygons, with the indexes of their vertices and segments.
+type relateGeometry struct {
+	points   []xy
+	lines    [][]xy
+	polygons [][][]xy
+
+	// boundary is the mod-2 boundary of the lines.
+	boundary     map[xy]bool
+	lineVertices map[xy]bool
+	ringVertices map[xy]bool
+	edges        map[edgeKey]edge
+
+	// shellBoxes are the boxes of the shells of the polygons.
+	shellBoxes []ewkb.Box
+}
+
+// edgeKey is a segment, from its lowest end to its highest one.
+type edgeKey struct {
+	from xy
+	to   xy
+}
+
+// edge is a segment of the lines or of the rings of a geometry.
+type edge struct {
+	line bool
+	ring bool
+
+	// leftInterior is set when the interior of the polygon is on the left of the
+	// ring, from the lowest end to the highest one.
+	leftInterior bool
+}
+
+func newEdgeKey(from xy, to xy) (edgeKey, bool) {
+	if from.x < to.x || (from.x == to.x && from.y < to.y) {
+		return edgeKey{from: from, to: to}, true
+	}
+
+	return edgeKey{from: to, to: from}, false
+}
+
+func newRelateGeometry(geometry ewkb.Geometry) (*relateGeometry, error) {
+	output := relateGeometry{}
+
+	if err := output.add(geometry); err != nil {
+		return nil, err
+	}
+
+	output.index()
+
+	return &output, nil
+}
+
+func (g *relateGeometry) add(geometry ewkb.Geometry) error {
+	switch geo := geometry.(type) {
+	case *ewkb.Point:
+		g.addPoint(geo.Coordinate)
+	case *ewkb.MultiPoint:
+		for _, pnt := range geo.Points {
+			g.addPoint(pnt.Coordinate)
+		}
+	case *ewkb.LineString:
+		g.addLine(geo.CoordinateSet)
+	case *ewkb.MultiLineString:
+		for _, line := range geo.LineStrings {
+			g.addLine(line.CoordinateSet)
+		}
+	case *ewkb.Triangle:
+		g.addPolygon(ewkb.CoordinateGroup{geo.CoordinateSet})
+	case *ewkb.Polygon:
+		g.addPolygon(geo.CoordinateGroup)
+	case *ewkb.MultiPolygon:
+		for _, polygon := range geo.Polygons {
+			g.addPolygon(polygon.CoordinateGroup)
+		}
+	case *ewkb.GeometryCollection:
+		for _, sub := range geo.Collection {
+			if err := g.add(sub); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedGeometry, geometry.Type())
+	}
+
+	return nil
+}
+
+// addPoint adds a point; empty and invalid points are ignored.
+func (g *relateGeometry) addPoint(coordinate ewkb.Coordinate) {
+	if position := xyOf(coordinate); !coordinate.Coord().IsEmpty() && position.isFinite() {
+		g.points = append(g.points, position)
+	}
+}
+
+// addLine adds a linestring; a linestring of a single position is a point.
+func (g *relateGeometry) addLine(set ewkb.CoordinateSet) {
+	line := finitePositions(set)
+
+	switch len(line) {
+	case 0:
+	case 1:
+		g.points = append(g.points, line[0])
+	default:
+		g.lines = append(g.lines, line)
+	}
+}
+
+// addPolygon adds a polygon; degenerate rings are ignored, and so is a polygon
+// with a degenerate shell.
+func (g *relateGeometry) addPolygon(group ewkb.CoordinateGroup) {
+	rings := make([][]xy, 0, len(group))
+
+	for _, set := range group {
+		ring := finitePositions(set)
+		if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+			ring = append(ring, ring[0])
+		}
+
+		if len(ring) < 4 { //nolint: gomnd
+			if len(rings) == 0 {
+				return
+			}
+
+			continue
+		}
+
+		rings = append(rings, ring)
+	}
+
+	if len(rings) > 0 {
+		g.polygons = append(g.polygons, rings)
+	}
+}
+
+// finitePositions converts the coordinates, without the invalid and the
+// repeated positions.
+func finitePositions(set ewkb.CoordinateSet) []xy {
+	output := make([]xy, 0, len(set))
+
+	for _, position := range xysOf(set) {
+		if position.isFinite() {
+			output = append(output, position)
+		}
+	}
+
+	return removeRepeated(output)
+}
+
+// index indexes the vertices and the segments, and computes the boundary of the lines.
+func (g *relateGeometry) index() {
+	g.boundary = map[xy]bool{}
+	g.lineVertices = map[xy]bool{}
+	g.ringVertices = map[xy]bool{}
+	g.edges = map[edgeKey]edge{}
+	g.shellBoxes = make([]ewkb.Box, len(g.polygons))
+
+	for _, line := range g.lines {
+		g.boundary[line[0]] = !g.boundary[line[0]]
+		g.boundary[line[len(line)-1]] = !g.boundary[line[len(line)-1]]
+
+		for idx, position := range line {
+			g.lineVertices[position] = true
+
+			if idx > 0 {
+				key, _ := newEdgeKey(line[idx-1], position)
+				current := g.edges[key]
+				current.line = true
+				g.edges[key] = current
+			}
+		}
+	}
+
+	for position, odd := range g.boundary {
+		if !odd {
+			delete(g.boundary, position)
+		}
+	}
+
+	for polygonIdx, polygon := range g.polygons {
+		box := ewkb.EmptyBox()
+
+		for _, position := range polygon[0] {
+			box = box.Union(ewkb.Box{XMin: position.x, YMin: position.y, XMax: position.x, YMax: position.y})
+		}
+
+		g.shellBoxes[polygonIdx] = box
+
+		for ringIdx, ring := range polygon {
+			// The interior is on the left of a counter clockwise shell, and of a clockwise hole.
+			interiorLeft := (signedArea(ring) > 0) == (ringIdx == 0)
+
+			for idx, position := range ring {
+				g.ringVertices[position] = true
+
+				if idx > 0 {
+					key, forward := newEdgeKey(ring[idx-1], position)
+					current := g.edges[key]
+					current.ring = true
+					current.leftInterior = interiorLeft == forward
+					g.edges[key] = current
+				}
+			}
+		}
+	}
+}
+
+// dimension is the dimension of the geometry, -1 when empty.
+func (g *relateGeometry) dimension() int {
+	switch {
+	case len(g.polygons) > 0:
+		return 2 //nolint: gomnd
+	case len(g.lines) > 0:
+		return 1
+	case len(g.points) > 0:
+		return 0
+	}
+
+	return -1
+}
+
+// boundaryDimension is the dimension of the boundary, -1 when empty.
+func (g *relateGeometry) boundaryDimension() int {
+	switch {
+	case len(g.polygons) > 0:
+		return 1
+	case len(g.boundary) > 0:
+		return 0
+	}
+
+	return -1
+}
+
+func (g *relateGeometry) envelope() ewkb.Box {
+	output := ewkb.EmptyBox()
+
+	extend := func(position xy) {
+		output = output.Union(ewkb.Box{XMin: position.x, YMin: position.y, XMax: position.x, YMax: position.y})
+	}
+
+	for _, position := range g.points {
+		extend(position)
+	}
+
+	for _, line := range g.lines {
+		for _, position := range line {
+			extend(position)
+		}
+	}
+
+	for _, polygon := range g.polygons {
+		for _, position := range polygon[0] {
+			extend(position)
+		}
+	}
+
+	return output
+}
+
+// locate locates a position relatively to the geometry: in the interior when
+// it is in the interior of any component, else on the boundary when it is on
+// the boundary of any component.
+func (g *relateGeometry) locate(position xy) location {
+	output := g.locateArea(position)
+
+	switch {
+	case output == locationInterior:
+		return output
+	case g.boundary[position]:
+		return locationBoundary
+	case g.lineVertices[position]:
+		return locationInterior
+	}
+
+	for _, line := range g.lines {
+		for idx := 1; idx < len(line); idx++ {
+			if onSegment(position, line[idx-1], line[idx]) {
+				return locationInterior
+			}
+		}
+	}
+
+	for _, point := range g.points {
+		if point == position {
+			return locationInterior
+		}
+	}
+
+	return output
+}
+
+// locateArea locates a position relatively to the polygons only.
+func (g *relateGeometry) locateArea(position xy) location {
+	output := locationExterior
+
+	if g.ringVertices[position] {
+		output = locationBoundary
+	}
+
+	for idx, polygon := range g.polygons {
+		if !g.shellBoxes[idx].ContainsCoord(position.coord()) {
+			continue
+		}
+
+		if loc := locateInPolygon(position, polygon); loc > output {
+			output = loc
+		}
+
+		if output == locationInterior {
+			break
+		}
+	}
+
+	return output
+}
+
+// locateSegment locates a segment relatively to the lines and the polygons: the
+// segment is noded, so that it is either a segment of the geometry, or only
+// meets the geometry at its ends.
+func (g *relateGeometry) locateSegment(from xy, to xy) location {
+	key, _ := newEdgeKey(from, to)
+	if current, ok := g.edges[key]; ok {
+		switch {
+		case current.line:
+			return locationInterior
+		case current.ring:
+			return locationBoundary
+		}
+	}
+
+	return g.locateArea(middle(from, to))
+}
+
+// sides locates the left and the right sides of a noded segment relatively to
+// the polygons. It fails when the side of the segment is undecided.
+func (g *relateGeometry) sides(from xy, to xy) (location, location, bool) {
+	key, forward := newEdgeKey(from, to)
+	if current, ok := g.edges[key]; ok && current.ring {
+		if current.leftInterior == forward {
+			return locationInterior, locationExterior, true
+		}
+
+		return locationExterior, locationInterior, true
+	}
+
+	loc := g.locateArea(middle(from, to))
+
+	return loc, loc, loc != locationBoundary
+}
+
+func middle(a xy, b xy) xy {
+	return xy{x: (a.x + b.x) / 2, y: (a.y + b.y) / 2} //nolint: gomnd
+}
+
+// relate computes the matrix of the geometries.
+func relate(a *relateGeometry, b *relateGeometry) IntersectionMatrix {
+	output := IntersectionMatrix{{-1, -1, -1}, {-1, -1, -1}, {-1, -1, 2}}
+
+	if !a.envelope().Expand(snapToleranceOf(a, b)).Intersects(b.envelope()) {
+		output[0][2] = a.dimension()
+		output[1][2] = a.boundaryDimension()
+		output[2][0] = b.dimension()
+		output[2][1] = b.boundaryDimension()
+
+		return output
+	}
+
+	node(a, b)
+
+	a.relateTo(b, func(first location, second location, dimension int) {
+		output.set(first, second, dimension)
+	})
+
+	b.relateTo(a, func(second location, first location, dimension int) {
+		output.set(first, second, dimension)
+	})
+
+	return output
+}
+
+// relateTo computes the contribution of the points, the segments and the areas
+// of the geometry to the matrix.
+func (g *relateGeometry) relateTo(other *relateGeometry, set func(location, location, int)) {
+	for _, position := range g.points {
+		set(g.locate(position), other.locate(position), 0)
+	}
+
+	path := func(positions []xy, ring bool) {
+		for idx, position := range positions {
+			set(g.locate(position), other.locate(position), 0)
+
+			if idx == 0 {
+				continue
+			}
+
+			from := positions[idx-1]
+
+			set(g.locateSegment(from, position), other.locateSegment(from, position), 1)
+
+			if !ring {
+				continue
+			}
+
+			left, right, ok := g.sides(from, position)
+			otherLeft, otherRight, otherOK := other.sides(from, position)
+
+			if ok && otherOK {
+				set(left, otherLeft, 2)   //nolint: gomnd
+				set(right, otherRight, 2) //nolint: gomnd
+			}
+		}
+	}
+
+	for _, line := range g.lines {
+		path(line, false)
+	}
+
+	for _, polygon := range g.polygons {
+		for _, ring := range polygon {
+			path(ring, true)
+		}
+	}
+}
+
+// node splits the segments of both geometries at their intersections, so that
+// two segments either are equal, or only meet at their ends. The vertices and
+// the segments are indexed again.
+func node(a *relateGeometry, b *relateGeometry) {
+	snapVertices(a, b)
+
+	pathsA, pathsB := a.paths(), b.paths()
+	splitsA, splitsB := make([][][]xy, len(pathsA)), make([][][]xy, len(pathsB))
+
+	for idxA, pathA := range pathsA {
+		splitsA[idxA] = make([][]xy, len(*pathA))
+	}
+
+	for idxB, pathB := range pathsB {
+		splitsB[idxB] = make([][]xy, len(*pathB))
+	}
+
+	// The boxes of a are expanded by the tolerance, so that the vertices at a
+	// rounding error from a segment are found.
+	sweepSegments(pathSegmentsOf(pathsA, snapToleranceOf(a, b)), pathSegmentsOf(pathsB, 0), func(segmentA pathSegment, segmentB pathSegment) {
+		nodeSegments(
+			segmentA.from, segmentA.to, segmentB.from, segmentB.to,
+			&splitsA[segmentA.path][segmentA.index], &splitsB[segmentB.path][segmentB.index],
+		)
+	})
+
+	for idx, path := range pathsA {
+		*path = splitPath(*path, splitsA[idx])
+	}
+
+	for idx, path := range pathsB {
+		*path = splitPath(*path, splitsB[idx])
+	}
+
+	a.index()
+	b.index()
+}
+
+// snapVertices moves the points and the vertices of b at a rounding error from
+// a vertex of a onto it, so that both geometries share the node instead of two
+// positions a sliver apart. The positions are found in a grid of cells of the
+// size of the tolerance.
+func snapVertices(a *relateGeometry, b *relateGeometry) {
+	tolerance := snapToleranceOf(a, b)
+	if tolerance == 0 {
+		return
+	}
+
+	cellOf := func(position xy) [2]float64 {
+		return [2]float64{math.Floor(position.x / tolerance), math.Floor(position.y / tolerance)}
+	}
+
+	cells := map[[2]float64][]xy{}
+
+	for _, path := range a.paths() {
+		for _, position := range *path {
+			cells[cellOf(position)] = append(cells[cellOf(position)], position)
+		}
+	}
+
+	for _, position := range a.points {
+		cells[cellOf(position)] = append(cells[cellOf(position)], position)
+	}
+
+	snap := func(position xy) xy {
+		cell := cellOf(position)
+
+		for column := -1.0; column <= 1; column++ {
+			for row := -1.0; row <= 1; row++ {
+				for _, vertex := range cells[[2]float64{cell[0] + column, cell[1] + row}] {
+					if math.Abs(vertex.x-position.x) <= tolerance && math.Abs(vertex.y-position.y) <= tolerance {
+						return vertex
+					}
+				}
+			}
+		}
+
+		return position
+	}
+
+	for _, path := range b.paths() {
+		for idx, position := range *path {
+			(*path)[idx] = snap(position)
+		}
+
+		*path = removeRepeated(*path)
+	}
+
+	for idx, position := range b.points {
+		b.points[idx] = snap(position)
+	}
+}
+
+// snapToleranceOf is the distance of positions of the geometries at a rounding
+// error from each other, relative to their extent; it is 0 when they have no extent.
+func snapToleranceOf(a *relateGeometry, b *relateGeometry) float64 {
+	box := a.envelope().Union(b.envelope())
+
+	tolerance := snapTolerance * math.Max(box.XMax-box.XMin, box.YMax-box.YMin)
+	if !(tolerance > 0) || math.IsInf(tolerance, 0) {
+		return 0
+	}
+
+	return tolerance
+}
+
+// paths lists the lines and the rings of the geometry.
+func (g *relateGeometry) paths() []*[]xy {
+	output := []*[]xy{}
+
+	for idx := range g.lines {
+		output = append(output, &g.lines[idx])
+	}
+
+	for _, polygon := range g.polygons {
+		for idx := range polygon {
+			output = append(output, &polygon[idx])
+		}
+	}
+
+	return output
+}
+
+// pathSegment is the segment of a path ending at an index, with its box.
+type pathSegment struct {
+	path     int
+	index    int
+	from, to xy
+	min, max xy
+}
+
+// pathSegmentsOf lists the segments of the paths, with their boxes expanded by the
+// margin, sorted by the left of the boxes.
+func pathSegmentsOf(paths []*[]xy, margin float64) []pathSegment {
+	output := []pathSegment{}
+
+	for pathIdx, path := range paths {
+		for idx := 1; idx < len(*path); idx++ {
+			from, to := (*path)[idx-1], (*path)[idx]
+
+			output = append(output, pathSegment{
+				path:  pathIdx,
+				index: idx,
+				from:  from,
+				to:    to,
+				min:   xy{x: math.Min(from.x, to.x) - margin, y: math.Min(from.y, to.y) - margin},
+				max:   xy{x: math.Max(from.x, to.x) + margin, y: math.Max(from.y, to.y) + margin},
+			})
+		}
+	}
+
+	sort.Slice(output, func(i int, j int) bool {
+		return output[i].min.x < output[j].min.x
+	})
+
+	return output
+}
+
+// sweepSegments calls visit for the pairs of segments of a and b whose boxes
+// intersect. Both lists are sorted by the left of the boxes: a line sweeps them
+// from left to right, and only the segments it crosses are compared.
+func sweepSegments(a []pathSegment, b []pathSegment, visit func(pathSegment, pathSegment)) {
+	activeA, activeB := []pathSegment{}, []pathSegment{}
+	idxA, idxB := 0, 0
+
+	for idxA < len(a) || idxB < len(b) {
+		if idxB == len(b) || (idxA < len(a) && a[idxA].min.x <= b[idxB].min.x) {
+			segment := a[idxA]
+			idxA++
+
+			activeB = crossedSegments(activeB, segment, func(other pathSegment) {
+				visit(segment, other)
+			})
+			activeA = append(activeA, segment)
+
+			continue
+		}
+
+		segment := b[idxB]
+		idxB++
+
+		activeA = crossedSegments(activeA, segment, func(other pathSegment) {
+			visit(other, segment)
+		})
+		activeB = append(activeB, segment)
+	}
+}
+
+// crossedSegments drops the active segments left of the segment, and calls
+// visit for the ones whose box intersects its box.
+func crossedSegments(active []pathSegment, segment pathSegment, visit func(pathSegment)) []pathSegment {
+	kept := active[:0]
+
+	for _, other := range active {
+		if other.max.x < segment.min.x {
+			continue
+		}
+
+		kept = append(kept, other)
+
+		if other.min.y <= segment.max.y && segment.min.y <= other.max.y {
+			visit(other)
+		}
+	}
+
+	return kept
+}
+
+// nodeSegments collects the intersections of the segments (fromA, toA) and
+// (fromB, toB) in their split positions.
+func nodeSegments(fromA xy, toA xy, fromB xy, toB xy, splitsA *[]xy, splitsB *[]xy) {
+	intersection := intersectSegments(fromA, toA, fromB, toB)
+
+	// A vertex at a rounding error from the other segment splits it, so that
+	// both paths share the same edges instead of a sliver; it then replaces
+	// the computed crossing.
+	positions := []xy{}
+
+	for _, position := range []xy{fromB, toB} {
+		if snapped(position, fromA, toA) {
+			positions = append(positions, position)
+		}
+	}
+
+	for _, position := range []xy{fromA, toA} {
+		if snapped(position, fromB, toB) {
+			positions = append(positions, position)
+		}
+	}
+
+	switch intersection.kind {
+	case intersectionProper:
+		if len(positions) == 0 {
+			positions = append(positions, intersection.point)
+		}
+	case intersectionTouch:
+		positions = append(positions, intersection.point)
+	case intersectionOverlap:
+		positions = append(positions, intersection.point, intersection.end)
+	case intersectionNone:
+	}
+
+	for _, position := range positions {
+		if position != fromA && position != toA {
+			*splitsA = append(*splitsA, position)
+		}
+
+		if position != fromB && position != toB {
+			*splitsB = append(*splitsB, position)
+		}
+	}
+}
+
+// snapped checks whether the position is inside the segment (from, to), at a
+// rounding error from it: its projection is inside the segment, even when the
+// position is just out of the box of an horizontal or vertical segment.
+func snapped(position xy, from xy, to xy) bool {
+	length := squaredDistance(from, to)
+	if position == from || position == to || length == 0 {
+		return false
+	}
+
+	if ratio := ((position.x-from.x)*(to.x-from.x) + (position.y-from.y)*(to.y-from.y)) / length; ratio <= 0 || ratio >= 1 {
+		return false
+	}
+
+	return math.Abs(cross(from, to, position)) <= snapTolerance*length
+}
+
+// splitPath inserts the split positions in the segments, in order.
+func splitPath(path []xy, splits [][]xy) []xy {
+	output := make([]xy, 0, len(path))
+
+	for idx, position := range path {
+		if idx > 0 && len(splits[idx]) > 0 {
+			from := path[idx-1]
+			inserted := splits[idx]
+
+			sort.Slice(inserted, func(i int, j int) bool {
+				return squaredDistance(from, inserted[i]) < squaredDistance(from, inserted[j])
+			})
+
+			output = append(output, inserted...)
+		}
+
+		output = append(output, position)
+	}
+
+	return removeRepeated(output)
+}
+
+func squaredDistance(a xy, b xy) float64 {
+	return (b.x-a.x)*(b.x-a.x) + (b.y-a.y)*(b.y-a.y)
+}
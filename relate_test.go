@@ -0,0 +1,240 @@
+package gogis_test
+
+import (
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelate(t *testing.T) {
+	square := gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}
+	point := func(x float64, y float64) *gogis.Point {
+		return &gogis.Point{Coordinate: ewkb.Coordinate{'x': x, 'y': y}}
+	}
+
+	// The top edge of the strip is split in 100 segments, and the vertex of the
+	// triangle is at a rounding error above it, out of the boxes of the segments.
+	strip := []float64{0, 0, 100, 0}
+	for x := 100.0; x >= 0; x-- {
+		strip = append(strip, x, 10)
+	}
+
+	strip = append(strip, 0, 0)
+
+	for _, testCase := range []struct {
+		name     string
+		first    gogis.EWKBConverter
+		second   gogis.EWKBConverter
+		expected string
+	}{
+		{
+			name:     "point in polygon",
+			first:    square,
+			second:   point(5, 5),
+			expected: "0F2FF1FF2",
+		},
+		{
+			name:     "point on the boundary",
+			first:    square,
+			second:   point(10, 5),
+			expected: "FF20F1FF2",
+		},
+		{
+			name:     "point in a hole",
+			first:    gogis.Polygon{square[0], line(2, 2, 2, 4, 4, 4, 4, 2, 2, 2)},
+			second:   point(3, 3),
+			expected: "FF2FF10F2",
+		},
+		{
+			name:     "point outside",
+			first:    point(20, 20),
+			second:   square,
+			expected: "FF0FFF212",
+		},
+		{
+			name:     "overlapping polygons",
+			first:    square,
+			second:   gogis.Polygon{line(5, 5, 15, 5, 15, 15, 5, 15, 5, 5)},
+			expected: "212101212",
+		},
+		{
+			name:     "adjacent polygons",
+			first:    square,
+			second:   gogis.Polygon{line(10, 0, 20, 0, 20, 10, 10, 10, 10, 0)},
+			expected: "FF2F11212",
+		},
+		{
+			// 9, 2.6999999999999997 (0.3 * 9) is at a rounding error from the diagonal.
+			name:     "vertex snapped to an edge",
+			first:    gogis.Polygon{line(0, 0, 10, 3, 0, 3, 0, 0)},
+			second:   gogis.Polygon{line(0, 0, 10, 0, 10, 3, 9, 2.6999999999999997, 0, 0)},
+			expected: "FF2F11212",
+		},
+		{
+			name:     "vertex snapped to a vertical edge",
+			first:    square,
+			second:   gogis.Polygon{line(10.000000000000002, 5, 20, 0, 20, 10, 10.000000000000002, 5)},
+			expected: "FF2F01212",
+		},
+		{
+			name:     "vertices snapped together",
+			first:    square,
+			second:   gogis.Polygon{line(10.000000000000002, 0, 20, 0, 20, 10, 10, 10.000000000000002, 10.000000000000002, 0)},
+			expected: "FF2F11212",
+		},
+		{
+			name:     "vertex snapped to one of many edges",
+			first:    gogis.Polygon{line(strip...)},
+			second:   gogis.Polygon{line(50.5, 10.000000000000002, 60, 20, 40, 20, 50.5, 10.000000000000002)},
+			expected: "FF2F01212",
+		},
+		{
+			name:     "polygons touching at a corner",
+			first:    square,
+			second:   gogis.Polygon{line(10, 10, 20, 10, 20, 20, 10, 20, 10, 10)},
+			expected: "FF2F01212",
+		},
+		{
+			name:     "nested polygons",
+			first:    square,
+			second:   gogis.Polygon{line(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)},
+			expected: "212FF1FF2",
+		},
+		{
+			name:     "equal polygons",
+			first:    square,
+			second:   gogis.Polygon{line(10, 10, 0, 10, 0, 0, 10, 0, 10, 5, 10, 10)},
+			expected: "2FFF1FFF2",
+		},
+		{
+			name:     "polygon sharing part of an edge",
+			first:    square,
+			second:   gogis.Polygon{line(2, 0, 4, 0, 4, 4, 2, 4, 2, 0)},
+			expected: "212F11FF2",
+		},
+		{
+			name:     "multipolygon",
+			first:    gogis.MultiPolygon{{square[0]}, {line(20, 0, 30, 0, 30, 10, 20, 10, 20, 0)}},
+			second:   gogis.LineString(line(5, 5, 25, 5)),
+			expected: "1020F11F2",
+		},
+		{
+			name:     "line crossing a polygon",
+			first:    line(-5, 5, 15, 5),
+			second:   square,
+			expected: "101FF0212",
+		},
+		{
+			name:     "line along the boundary",
+			first:    line(0, 0, 10, 0),
+			second:   square,
+			expected: "F1FF0F212",
+		},
+		{
+			name:     "crossing lines",
+			first:    line(0, 0, 2, 2),
+			second:   line(0, 2, 2, 0),
+			expected: "0F1FF0102",
+		},
+		{
+			name:     "overlapping lines",
+			first:    line(0, 0, 2, 0),
+			second:   line(1, 0, 3, 0),
+			expected: "1010F0102",
+		},
+		{
+			name:     "mod-2 boundary",
+			first:    point(1, 0),
+			second:   gogis.MultiLineString{line(0, 0, 1, 0), line(1, 0, 2, 0)},
+			expected: "0FFFFF102",
+		},
+		{
+			name:     "closed line",
+			first:    line(0, 0, 1, 0, 1, 1, 0, 0),
+			second:   point(0, 0),
+			expected: "0F1FFFFF2",
+		},
+		{
+			name:     "multipoint",
+			first:    &gogis.MultiPoint{*point(5, 5), *point(20, 20)},
+			second:   square,
+			expected: "0F0FFF212",
+		},
+		{
+			name:     "empty",
+			first:    &gogis.Point{},
+			second:   square,
+			expected: "FFFFFF212",
+		},
+	} {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			matrix, err := gogis.Relate(testCase.first, testCase.second)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, matrix.String())
+
+			transposed, err := gogis.Relate(testCase.second, testCase.first)
+			require.NoError(t, err)
+			assert.Equal(t, transpose(testCase.expected), transposed.String())
+		})
+	}
+
+	t.Run("curve", func(t *testing.T) {
+		_, err := gogis.Relate(square, gogis.CircularString(line(0, 0, 1, 1, 2, 0)))
+		require.ErrorIs(t, err, gogis.ErrUnsupportedGeometry)
+	})
+}
+
+func TestPredicates(t *testing.T) {
+	square := gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}
+	inside := &gogis.Point{Coordinate: ewkb.Coordinate{'x': 5, 'y': 5}}
+	onBoundary := &gogis.Point{Coordinate: ewkb.Coordinate{'x': 0, 'y': 5}}
+
+	check := func(predicate func(gogis.EWKBConverter, gogis.EWKBConverter) (bool, error), a gogis.EWKBConverter, b gogis.EWKBConverter) bool {
+		output, err := predicate(a, b)
+		require.NoError(t, err)
+
+		return output
+	}
+
+	assert.True(t, check(gogis.Contains, square, inside))
+	assert.False(t, check(gogis.Contains, square, onBoundary))
+	assert.True(t, check(gogis.Covers, square, onBoundary))
+	assert.True(t, check(gogis.Within, inside, square))
+	assert.True(t, check(gogis.Touches, onBoundary, square))
+	assert.False(t, check(gogis.Touches, inside, square))
+	assert.True(t, check(gogis.Intersects, square, onBoundary))
+	assert.True(t, check(gogis.Disjoint, square, &gogis.Point{Coordinate: ewkb.Coordinate{'x': 11, 'y': 5}}))
+	assert.True(t, check(gogis.Crosses, line(-5, 5, 5, 5), square))
+	assert.False(t, check(gogis.Crosses, line(1, 5, 5, 5), square))
+	assert.True(t, check(gogis.Crosses, line(0, 0, 2, 2), line(0, 2, 2, 0)))
+	assert.True(t, check(gogis.Overlaps, square, gogis.Polygon{line(5, 5, 15, 5, 15, 15, 5, 15, 5, 5)}))
+	assert.False(t, check(gogis.Overlaps, square, gogis.Polygon{line(2, 2, 4, 2, 4, 4, 2, 4, 2, 2)}))
+	assert.True(t, check(gogis.Overlaps, line(0, 0, 2, 0), line(1, 0, 3, 0)))
+	assert.True(t, check(gogis.Equals, square, gogis.Polygon{line(0, 0, 0, 10, 10, 10, 10, 0, 0, 0)}))
+	assert.True(t, check(gogis.Equals, line(0, 0, 1, 0, 2, 0), line(2, 0, 0, 0)))
+	assert.False(t, check(gogis.Equals, inside, onBoundary))
+
+	matrix, err := gogis.Relate(square, inside)
+	require.NoError(t, err)
+	assert.True(t, matrix.Matches("0F2FF1FF2"))
+	assert.True(t, matrix.Matches("T*****FF*"))
+	assert.False(t, matrix.Matches("**F******"))
+	assert.False(t, matrix.Matches("0F2"))
+}
+
+func transpose(matrix string) string {
+	output := []byte(matrix)
+
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			output[row*3+column] = matrix[column*3+row]
+		}
+	}
+
+	return string(output)
+}
@@ -0,0 +1,534 @@
+package gogis
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Simplifications of linestrings and polygons, as PostGIS computes them with
+// ST_Simplify (Douglas-Peucker), ST_SimplifyPreserveTopology and ST_SimplifyVW
+// (Visvalingam-Whyatt), in 2D. The kept vertices are the points of the input,
+// with their Z, M and SRID, so that the result can be stored as it is.
+
+// SimplifyOptions are the parameters of Simplify and SimplifyVW.
+type SimplifyOptions struct {
+	// PreserveCollapsed keeps the parts too small to survive the simplification
+	// (preserveCollapsed of ST_Simplify): the rings keep 4 points and the
+	// linestrings their ends, instead of being dropped.
+	PreserveCollapsed bool
+}
+
+// WithPreserveCollapsed keeps the parts too small to survive the simplification.
+func WithPreserveCollapsed(preserve bool) func(*SimplifyOptions) {
+	return func(options *SimplifyOptions) {
+		options.PreserveCollapsed = preserve
+	}
+}
+
+// Simplify removes the vertices closer than the tolerance to the simplified
+// linestring, with the Douglas-Peucker algorithm (ST_Simplify). The linestring
+// is empty when it collapses to a point.
+func (l LineString) Simplify(tolerance float64, opts ...func(*SimplifyOptions)) LineString {
+	return simplifyLine(l, douglasPeucker(tolerance), simplifyOptionsOf(opts))
+}
+
+// SimplifyVW removes the vertices forming with their neighbours a triangle
+// smaller than the area, with the Visvalingam-Whyatt algorithm (ST_SimplifyVW).
+func (l LineString) SimplifyVW(area float64, opts ...func(*SimplifyOptions)) LineString {
+	return simplifyLine(l, visvalingamWhyatt(area), simplifyOptionsOf(opts))
+}
+
+// SimplifyPreserveTopology simplifies the linestring as Simplify does, without
+// creating self-intersections (ST_SimplifyPreserveTopology).
+func (l LineString) SimplifyPreserveTopology(tolerance float64) LineString {
+	return simplifyPreservingTopology([]LineString{l}, []bool{false}, tolerance)[0]
+}
+
+// Simplify simplifies each linestring (ST_Simplify); the collapsed linestrings are dropped.
+func (m MultiLineString) Simplify(tolerance float64, opts ...func(*SimplifyOptions)) MultiLineString {
+	return simplifyLines(m, douglasPeucker(tolerance), simplifyOptionsOf(opts))
+}
+
+// SimplifyVW simplifies each linestring (ST_SimplifyVW); the collapsed linestrings are dropped.
+func (m MultiLineString) SimplifyVW(area float64, opts ...func(*SimplifyOptions)) MultiLineString {
+	return simplifyLines(m, visvalingamWhyatt(area), simplifyOptionsOf(opts))
+}
+
+// SimplifyPreserveTopology simplifies the linestrings without creating
+// intersections between them (ST_SimplifyPreserveTopology).
+func (m MultiLineString) SimplifyPreserveTopology(tolerance float64) MultiLineString {
+	return MultiLineString(simplifyPreservingTopology(m, make([]bool, len(m)), tolerance))
+}
+
+// Simplify simplifies each ring (ST_Simplify): the rings left with less than 4
+// points are dropped, and the polygon is empty when its shell is dropped.
+func (p Polygon) Simplify(tolerance float64, opts ...func(*SimplifyOptions)) Polygon {
+	return simplifyPolygon(p, douglasPeucker(tolerance), simplifyOptionsOf(opts))
+}
+
+// SimplifyVW simplifies each ring (ST_SimplifyVW): the rings left with less than
+// 4 points are dropped, and the polygon is empty when its shell is dropped.
+func (p Polygon) SimplifyVW(area float64, opts ...func(*SimplifyOptions)) Polygon {
+	return simplifyPolygon(p, visvalingamWhyatt(area), simplifyOptionsOf(opts))
+}
+
+// SimplifyPreserveTopology simplifies the rings without creating intersections
+// (ST_SimplifyPreserveTopology): the rings keep at least 4 points, and a valid
+// polygon stays valid.
+func (p Polygon) SimplifyPreserveTopology(tolerance float64) Polygon {
+	return Polygon(simplifyPreservingTopology(p, ringFlags(len(p)), tolerance))
+}
+
+// Simplify simplifies each polygon (ST_Simplify); the empty polygons are dropped.
+func (p MultiPolygon) Simplify(tolerance float64, opts ...func(*SimplifyOptions)) MultiPolygon {
+	return simplifyPolygons(p, douglasPeucker(tolerance), simplifyOptionsOf(opts))
+}
+
+// SimplifyVW simplifies each polygon (ST_SimplifyVW); the empty polygons are dropped.
+func (p MultiPolygon) SimplifyVW(area float64, opts ...func(*SimplifyOptions)) MultiPolygon {
+	return simplifyPolygons(p, visvalingamWhyatt(area), simplifyOptionsOf(opts))
+}
+
+// SimplifyPreserveTopology simplifies the rings of all the polygons without
+// creating intersections between them (ST_SimplifyPreserveTopology).
+func (p MultiPolygon) SimplifyPreserveTopology(tolerance float64) MultiPolygon {
+	rings := []LineString{}
+
+	for _, polygon := range p {
+		rings = append(rings, polygon...)
+	}
+
+	simplified := simplifyPreservingTopology(rings, ringFlags(len(rings)), tolerance)
+	output := make(MultiPolygon, len(p))
+
+	for idx, polygon := range p {
+		output[idx], simplified = Polygon(simplified[:len(polygon):len(polygon)]), simplified[len(polygon):]
+	}
+
+	return output
+}
+
+func simplifyOptionsOf(opts []func(*SimplifyOptions)) SimplifyOptions {
+	options := SimplifyOptions{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+func ringFlags(count int) []bool {
+	output := make([]bool, count)
+
+	for idx := range output {
+		output[idx] = true
+	}
+
+	return output
+}
+
+// simplifier selects the vertices to keep, in order; it never keeps less than
+// minimum vertices, nor drops the ends.
+type simplifier func(positions []xy, minimum int) []int
+
+func simplifyLine(line LineString, simplify simplifier, options SimplifyOptions) LineString {
+	if len(line) < 3 { //nolint: gomnd
+		return line
+	}
+
+	output := pickPoints(line, simplify(positionsOf(line), 2)) //nolint: gomnd
+
+	// Only the ends are left, at the same position.
+	if len(output) == 2 && xyOf(output[0].Coordinate) == xyOf(output[1].Coordinate) && !options.PreserveCollapsed {
+		return LineString{}
+	}
+
+	return output
+}
+
+func simplifyLines(lines MultiLineString, simplify simplifier, options SimplifyOptions) MultiLineString {
+	output := MultiLineString{}
+
+	for _, line := range lines {
+		if simplified := simplifyLine(line, simplify, options); len(simplified) > 0 {
+			output = append(output, simplified)
+		}
+	}
+
+	return output
+}
+
+// simplifyRing simplifies a closed ring; it is empty when less than 4 points remain.
+func simplifyRing(ring LineString, simplify simplifier, options SimplifyOptions) LineString {
+	if len(ring) < 4 { //nolint: gomnd
+		return ring
+	}
+
+	minimum := 3
+	if options.PreserveCollapsed {
+		minimum = 4
+	}
+
+	output := pickPoints(ring, simplify(positionsOf(ring), minimum))
+	if len(output) < 4 { //nolint: gomnd
+		return LineString{}
+	}
+
+	return output
+}
+
+func simplifyPolygon(polygon Polygon, simplify simplifier, options SimplifyOptions) Polygon {
+	output := Polygon{}
+
+	for idx, ring := range polygon {
+		simplified := simplifyRing(ring, simplify, options)
+
+		switch {
+		case len(simplified) > 0:
+			output = append(output, simplified)
+		case idx == 0:
+			return Polygon{}
+		}
+	}
+
+	return output
+}
+
+func simplifyPolygons(polygons MultiPolygon, simplify simplifier, options SimplifyOptions) MultiPolygon {
+	output := MultiPolygon{}
+
+	for _, polygon := range polygons {
+		if simplified := simplifyPolygon(polygon, simplify, options); len(simplified) > 0 {
+			output = append(output, simplified)
+		}
+	}
+
+	return output
+}
+
+func positionsOf(points []Point) []xy {
+	output := make([]xy, len(points))
+
+	for idx, point := range points {
+		output[idx] = xyOf(point.Coordinate)
+	}
+
+	return output
+}
+
+func pickPoints(points []Point, indexes []int) LineString {
+	output := make(LineString, len(indexes))
+
+	for idx, index := range indexes {
+		output[idx] = points[index]
+	}
+
+	return output
+}
+
+// douglasPeucker keeps the vertex farthest from the segment between the kept
+// vertices while it is farther than the tolerance.
+func douglasPeucker(tolerance float64) simplifier {
+	return func(positions []xy, minimum int) []int {
+		keep := make([]bool, len(positions))
+		keep[0], keep[len(positions)-1] = true, true
+
+		for sections := [][2]int{{0, len(positions) - 1}}; len(sections) > 0; {
+			section := sections[len(sections)-1]
+			sections = sections[:len(sections)-1]
+
+			if far, dist := farthest(positions, section[0], section[1]); far >= 0 && dist > tolerance {
+				keep[far] = true
+				sections = append(sections, [2]int{section[0], far}, [2]int{far, section[1]})
+			}
+		}
+
+		return keptIndexes(positions, keep, minimum)
+	}
+}
+
+// farthest finds the vertex between from and to farthest from their segment;
+// it is -1 when there is none.
+func farthest(positions []xy, from int, to int) (int, float64) {
+	output, best := -1, -1.0
+
+	for idx := from + 1; idx < to; idx++ {
+		if dist := segmentDistance(positions[idx], positions[from], positions[to]); dist > best {
+			output, best = idx, dist
+		}
+	}
+
+	return output, best
+}
+
+// keptIndexes lists the kept vertices, adding the farthest ones from the
+// simplified line until there are minimum vertices.
+func keptIndexes(positions []xy, keep []bool, minimum int) []int {
+	output := []int{}
+
+	for idx, kept := range keep {
+		if kept {
+			output = append(output, idx)
+		}
+	}
+
+	for len(output) < minimum {
+		far, best := -1, -1.0
+
+		for idx := 1; idx < len(output); idx++ {
+			if current, dist := farthest(positions, output[idx-1], output[idx]); current >= 0 && dist > best {
+				far, best = current, dist
+			}
+		}
+
+		if far < 0 {
+			break
+		}
+
+		keep[far] = true
+		output = output[:0]
+
+		for idx, kept := range keep {
+			if kept {
+				output = append(output, idx)
+			}
+		}
+	}
+
+	return output
+}
+
+// visvalingamWhyatt removes the vertex forming the smallest triangle with its
+// neighbours while this triangle is smaller than the area.
+func visvalingamWhyatt(area float64) simplifier {
+	return func(positions []xy, minimum int) []int {
+		size := len(positions)
+		previous, next := make([]int, size), make([]int, size)
+		areas := make([]float64, size)
+		queue := &vertexQueue{}
+
+		triangle := func(idx int) float64 {
+			return math.Abs(cross(positions[previous[idx]], positions[idx], positions[next[idx]])) / 2 //nolint: gomnd
+		}
+
+		for idx := range positions {
+			previous[idx], next[idx] = idx-1, idx+1
+		}
+
+		for idx := 1; idx < size-1; idx++ {
+			areas[idx] = triangle(idx)
+			heap.Push(queue, vertexArea{index: idx, area: areas[idx]})
+		}
+
+		removed := make([]bool, size)
+
+		for count := size; count > minimum && queue.Len() > 0; {
+			current := heap.Pop(queue).(vertexArea) //nolint: forcetypeassert
+			if removed[current.index] || current.area != areas[current.index] {
+				continue
+			}
+
+			if current.area >= area {
+				break
+			}
+
+			removed[current.index] = true
+			count--
+
+			before, after := previous[current.index], next[current.index]
+			next[before], previous[after] = after, before
+
+			for _, neighbour := range []int{before, after} {
+				if neighbour > 0 && neighbour < size-1 {
+					areas[neighbour] = triangle(neighbour)
+					heap.Push(queue, vertexArea{index: neighbour, area: areas[neighbour]})
+				}
+			}
+		}
+
+		output := []int{}
+
+		for idx := 0; idx < size; idx = next[idx] {
+			output = append(output, idx)
+		}
+
+		return output
+	}
+}
+
+// vertexArea is the area of the triangle of a vertex with its neighbours.
+type vertexArea struct {
+	index int
+	area  float64
+}
+
+// vertexQueue is a min-heap of vertices by area.
+type vertexQueue []vertexArea
+
+func (q vertexQueue) Len() int { return len(q) }
+
+func (q vertexQueue) Less(i int, j int) bool {
+	if q[i].area == q[j].area {
+		return q[i].index < q[j].index
+	}
+
+	return q[i].area < q[j].area
+}
+
+func (q vertexQueue) Swap(i int, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *vertexQueue) Push(value interface{}) {
+	*q = append(*q, value.(vertexArea)) //nolint: forcetypeassert
+}
+
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	output := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return output
+}
+
+// topologySimplifier simplifies linestrings and rings together with
+// Douglas-Peucker: a section is only replaced by a segment when this segment
+// does not intersect the current segments of the parts, so that no
+// intersection is created. The kept vertices of a part are linked by next.
+type topologySimplifier struct {
+	tolerance float64
+	positions [][]xy
+	next      [][]int
+}
+
+// simplifyPreservingTopology simplifies the parts, the rings keeping at least 4 points.
+func simplifyPreservingTopology(parts []LineString, rings []bool, tolerance float64) []LineString {
+	simplifier := topologySimplifier{
+		tolerance: tolerance,
+		positions: make([][]xy, len(parts)),
+		next:      make([][]int, len(parts)),
+	}
+
+	for idx, part := range parts {
+		simplifier.positions[idx] = positionsOf(part)
+		simplifier.next[idx] = make([]int, len(part))
+
+		for vertex := range part {
+			simplifier.next[idx][vertex] = vertex + 1
+		}
+	}
+
+	for idx, part := range parts {
+		if len(part) < 3 { //nolint: gomnd
+			continue
+		}
+
+		seeds := []int{0, len(part) - 1}
+		if rings[idx] {
+			seeds = simplifier.ringSeeds(idx)
+		}
+
+		for seed := 1; seed < len(seeds); seed++ {
+			simplifier.section(idx, seeds[seed-1], seeds[seed])
+		}
+	}
+
+	output := make([]LineString, len(parts))
+
+	for idx, part := range parts {
+		indexes := []int{}
+
+		for vertex := 0; vertex < len(part); vertex = simplifier.next[idx][vertex] {
+			indexes = append(indexes, vertex)
+		}
+
+		output[idx] = pickPoints(part, indexes)
+	}
+
+	return output
+}
+
+// ringSeeds splits a ring at the vertex farthest from its start, and at the
+// vertex farthest from this diagonal, so that it keeps at least 4 points.
+func (s topologySimplifier) ringSeeds(part int) []int {
+	positions := s.positions[part]
+	last := len(positions) - 1
+	first, best := 0, -1.0
+
+	for idx := 1; idx < last; idx++ {
+		if dist := distance(positions[0], positions[idx]); dist > best {
+			first, best = idx, dist
+		}
+	}
+
+	second, best := 0, -1.0
+
+	for idx := 1; idx < last; idx++ {
+		if dist := segmentDistance(positions[idx], positions[0], positions[first]); idx != first && dist > best {
+			second, best = idx, dist
+		}
+	}
+
+	if second == 0 {
+		return []int{0, first, last}
+	}
+
+	if second < first {
+		return []int{0, second, first, last}
+	}
+
+	return []int{0, first, second, last}
+}
+
+// section simplifies the vertices between from and to.
+func (s topologySimplifier) section(part int, from int, to int) {
+	if to-from < 2 { //nolint: gomnd
+		return
+	}
+
+	far, dist := farthest(s.positions[part], from, to)
+	if dist <= s.tolerance && !s.conflicts(part, from, to) {
+		s.next[part][from] = to
+
+		return
+	}
+
+	s.section(part, from, far)
+	s.section(part, far, to)
+}
+
+// conflicts checks whether the segment replacing a section intersects a current
+// segment of the parts, elsewhere than at their shared ends, or whether another
+// part lies between the section and the segment: it would jump to the other side.
+func (s topologySimplifier) conflicts(part int, from int, to int) bool {
+	start, end := s.positions[part][from], s.positions[part][to]
+	area := append(append([]xy{}, s.positions[part][from:to+1]...), start)
+
+	for other, positions := range s.positions {
+		if other != part && len(positions) > 0 && locateInRing(positions[0], area) == locationInterior {
+			return true
+		}
+
+		for vertex := 0; vertex < len(positions)-1; vertex = s.next[other][vertex] {
+			following := s.next[other][vertex]
+			if other == part && vertex >= from && following <= to {
+				continue
+			}
+
+			segmentStart, segmentEnd := positions[vertex], positions[following]
+			intersection := intersectSegments(start, end, segmentStart, segmentEnd)
+
+			switch intersection.kind {
+			case intersectionNone:
+			case intersectionTouch:
+				if (intersection.point != start && intersection.point != end) ||
+					(intersection.point != segmentStart && intersection.point != segmentEnd) {
+					return true
+				}
+			case intersectionProper, intersectionOverlap:
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+package gogis_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimplify(t *testing.T) {
+	t.Run("linestring", func(t *testing.T) {
+		input := line(0, 0, 5, 0.5, 10, 0, 11, 5, 20, 0)
+
+		assert.Equal(t, line(0, 0, 10, 0, 11, 5, 20, 0), input.Simplify(1))
+		assert.Equal(t, input, input.Simplify(0.1))
+		assert.Equal(t, line(0, 0, 20, 0), input.Simplify(10))
+		assert.Equal(t, line(0, 0, 10, 0, 11, 5, 20, 0), input.SimplifyPreserveTopology(1))
+	})
+
+	t.Run("collapsed linestring", func(t *testing.T) {
+		input := line(0, 0, 1, 0.1, 0, 0)
+
+		assert.Empty(t, input.Simplify(2))
+		assert.Equal(t, line(0, 0, 0, 0), input.Simplify(2, gogis.WithPreserveCollapsed(true)))
+		assert.Equal(t, gogis.MultiLineString{line(0, 0, 10, 0)}, gogis.MultiLineString{input, line(0, 0, 5, 0.1, 10, 0)}.Simplify(2))
+	})
+
+	t.Run("z and m", func(t *testing.T) {
+		input := gogis.LineString{
+			{Coordinate: ewkb.Coordinate{'x': 0, 'y': 0, 'z': 1, 'm': 10}},
+			{Coordinate: ewkb.Coordinate{'x': 1, 'y': 0.1, 'z': 2, 'm': 11}},
+			{Coordinate: ewkb.Coordinate{'x': 2, 'y': 0, 'z': 3, 'm': 12}},
+		}
+
+		output := input.Simplify(1)
+		require.Len(t, output, 2)
+		assert.Equal(t, input[0], output[0])
+		assert.Equal(t, input[2], output[1])
+
+		value, err := output.Value()
+		require.NoError(t, err)
+		assert.NotNil(t, value)
+	})
+
+	t.Run("polygon", func(t *testing.T) {
+		shell := line(0, 0, 5, 0.1, 10, 0, 10, 10, 0, 10, 0, 0)
+		hole := line(4, 4, 4, 5, 5, 5, 5, 4, 4, 4)
+
+		assert.Equal(t, gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}, gogis.Polygon{shell, hole}.Simplify(2))
+		assert.Equal(t,
+			gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0), line(4, 4, 4, 5, 5, 5, 4, 4)},
+			gogis.Polygon{shell, hole}.Simplify(2, gogis.WithPreserveCollapsed(true)),
+		)
+		assert.Empty(t, gogis.Polygon{hole}.Simplify(2))
+
+		output := gogis.MultiPolygon{{shell}, {hole}}.Simplify(2)
+		assert.Equal(t, gogis.MultiPolygon{{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}}, output)
+
+		value, err := gogis.Polygon{hole}.Simplify(2).Value()
+		require.NoError(t, err)
+		assert.NotNil(t, value)
+	})
+
+	t.Run("visvalingam-whyatt", func(t *testing.T) {
+		input := line(0, 0, 1, 0.1, 2, 0, 3, 3, 4, 0)
+
+		assert.Equal(t, line(0, 0, 2, 0, 3, 3, 4, 0), input.SimplifyVW(0.5))
+		assert.Equal(t, line(0, 0, 4, 0), input.SimplifyVW(10))
+		assert.Equal(t, gogis.MultiLineString{line(0, 0, 2, 0, 3, 3, 4, 0)}, gogis.MultiLineString{input}.SimplifyVW(0.5))
+
+		square := line(0, 0, 5, 0.1, 10, 0, 10, 10, 0, 10, 0, 0)
+		assert.Equal(t, gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)}, gogis.Polygon{square}.SimplifyVW(1))
+		assert.Empty(t, gogis.Polygon{square}.SimplifyVW(1000))
+		assert.Len(t, gogis.Polygon{square}.SimplifyVW(1000, gogis.WithPreserveCollapsed(true))[0], 4)
+		assert.Empty(t, gogis.MultiPolygon{{square}}.SimplifyVW(1000))
+	})
+
+	t.Run("preserve topology", func(t *testing.T) {
+		// The hole is in the bulge of the shell, that Douglas-Peucker removes.
+		polygon := gogis.Polygon{
+			line(0, 0, 10, 0, 10, 10, 5, 12, 0, 10, 0, 0),
+			line(4, 10.5, 6, 10.5, 5, 11.5, 4, 10.5),
+		}
+
+		assert.NotEmpty(t, gogis.Validate(polygon.Simplify(3, gogis.WithPreserveCollapsed(true))))
+
+		simplified := polygon.SimplifyPreserveTopology(3)
+		assert.Empty(t, gogis.Validate(simplified))
+		assert.Equal(t, polygon, simplified)
+
+		// The shell keeps 4 points, whatever the tolerance.
+		assert.Equal(t, gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 0)}, gogis.Polygon{polygon[0]}.SimplifyPreserveTopology(100))
+
+		// Douglas-Peucker makes the first segment cross the last ones.
+		crossing := line(0, 0, 10, 0, 10, 1, 5, 1.4, 5, -5, 6, -5)
+		assert.Equal(t, line(0, 0, 10, 0, 10, 1, 5, 1.4, 5, -5, 6, -5), crossing.SimplifyPreserveTopology(1))
+		assert.Equal(t, line(0, 0, 10, 1, 5, 1.4, 6, -5), crossing.Simplify(1))
+
+		lines := gogis.MultiLineString{line(0, 0, 5, 1, 10, 0), line(5, 0.5, 5, -5)}
+		assert.Equal(t, lines, lines.SimplifyPreserveTopology(2))
+		assert.Equal(t, gogis.MultiLineString{line(0, 0, 10, 0), line(5, 0.5, 5, -5)}, lines.Simplify(2))
+	})
+
+	t.Run("random polygons stay valid", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1)) //nolint: gosec
+
+		for idx := 0; idx < 50; idx++ {
+			shell := []float64{}
+
+			for vertex := 0; vertex < 60; vertex++ {
+				angle := 2 * math.Pi * float64(vertex) / 60
+				radius := 20 + random.Float64()*15
+				shell = append(shell, 50+radius*math.Cos(angle), 50+radius*math.Sin(angle))
+			}
+
+			shell = append(shell, shell[0], shell[1])
+			hole := line(37, 37, 37, 63, 63, 63, 63, 37, 37, 37)
+			polygon := gogis.MultiPolygon{{line(shell...), hole}}
+
+			require.Empty(t, gogis.Validate(polygon))
+			assert.Empty(t, gogis.Validate(polygon.SimplifyPreserveTopology(15)))
+		}
+	})
+}
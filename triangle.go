@@ -10,6 +10,7 @@ import (
 type Triangle []Point
 
 // NullTriangle represents a Triangle that may be null.
+// It has the same semantics as Null[*Triangle].
 // NullTriangle implements the SQL driver.Scanner interface so it
 // can be used as a scan destination:
 //
@@ -28,26 +29,14 @@ type NullTriangle struct {
 
 // Scan implements the SQL driver.Scanner interface.
 func (t *NullTriangle) Scan(value interface{}) error {
-	if dataBytes, ok := value.([]byte); ok && dataBytes == nil {
-		return nil
-	}
-
-	triangle := ewkb.Triangle{}
-
-	if err := ewkb.Unmarshal(&triangle, value); err != nil {
-		return err
-	}
-
-	t.Valid = true
-
-	return (&t.Triangle).FromEWKB(triangle)
+	return scanNull(value, &t.Triangle, &t.Valid)
 }
 
 // Scan implements the SQL driver.Scanner interface.
 func (t *Triangle) Scan(value interface{}) error {
 	triangle := ewkb.Triangle{}
 
-	if err := ewkb.Unmarshal(&triangle, value); err != nil {
+	if err := unmarshal(&triangle, value, decoding{}); err != nil {
 		return err
 	}
 
@@ -68,6 +57,11 @@ func (t NullTriangle) Value() (driver.Value, error) {
 	return t.Triangle.Value()
 }
 
+// State is the NULL / EMPTY / value state of the Triangle.
+func (t NullTriangle) State() State {
+	return stateOf(t.Valid, t.Triangle)
+}
+
 // FromEWKB implements the ModelConverter interface.
 func (t *Triangle) FromEWKB(from interface{}) error {
 	triangle, ok := fromPtr(from).(ewkb.Triangle)
@@ -105,6 +99,11 @@ func (t Triangle) ToEWKB() ewkb.Geometry { //nolint: ireturn
 	return &triangle
 }
 
+// IsEmpty checks if the Triangle has no point (TRIANGLE EMPTY).
+func (t Triangle) IsEmpty() bool {
+	return len(t) == 0
+}
+
 // Geometry converts to a generic geometry.
 func (t Triangle) Geometry(opts ...func(interface{})) Geometry {
 	output := Geometry{
@@ -119,3 +118,37 @@ func (t Triangle) Geometry(opts ...func(interface{})) Geometry {
 
 	return output
 }
+
+// Envelope computes the extent of the triangle.
+func (t Triangle) Envelope() ewkb.Box {
+	return ewkb.EnvelopeOf(t.ToEWKB())
+}
+
+// TriangleArray is an array of Triangle (geometry[] in database).
+// NULL elements are rejected with ErrNullArrayElement.
+type TriangleArray []Triangle
+
+// Scan implements the SQL driver.Scanner interface.
+func (t *TriangleArray) Scan(value interface{}) error {
+	return scanArray(
+		value,
+		false,
+		func(size int) {
+			*t = make(TriangleArray, size)
+		},
+		func(idx int, element []byte) error {
+			return (&(*t)[idx]).Scan(element)
+		},
+	)
+}
+
+// Value implements the driver.Valuer interface.
+func (t TriangleArray) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	return arrayValue(len(t), func(idx int) (driver.Value, error) {
+		return t[idx].Value()
+	})
+}
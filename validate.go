@@ -0,0 +1,458 @@
+package gogis
+
+import (
+	"fmt"
+
+	"github.com/landru29/gogis/ewkb"
+)
+
+// Reason is the reason of a validity violation, with the message of PostGIS (ST_IsValidReason).
+type Reason string
+
+const (
+	// ReasonInvalidCoordinate is a NaN or infinite coordinate.
+	ReasonInvalidCoordinate Reason = "Invalid Coordinate"
+
+	// ReasonRingNotClosed is a ring whose last point is not its first point.
+	ReasonRingNotClosed Reason = "Ring is not closed"
+
+	// ReasonTooFewPoints is a line with less than 2 distinct points, or a ring
+	// with less than 4 points.
+	ReasonTooFewPoints Reason = "Too few points in geometry component"
+
+	// ReasonSelfIntersection is a crossing, or an overlap, of the boundaries.
+	ReasonSelfIntersection Reason = "Self-intersection"
+
+	// ReasonRingSelfIntersection is a ring touching itself.
+	ReasonRingSelfIntersection Reason = "Ring Self-intersection"
+
+	// ReasonHoleOutsideShell is a hole outside the shell of its polygon.
+	ReasonHoleOutsideShell Reason = "Hole lies outside shell"
+
+	// ReasonNestedHoles is a hole inside another hole.
+	ReasonNestedHoles Reason = "Holes are nested"
+
+	// ReasonDisconnectedInterior is a polygon whose holes split the interior.
+	ReasonDisconnectedInterior Reason = "Interior is disconnected"
+
+	// ReasonNestedShells is a polygon of a multipolygon inside another one.
+	ReasonNestedShells Reason = "Nested shells"
+
+	// minRingSize is the minimum number of points of a ring.
+	minRingSize = 4
+)
+
+// Violation is an OGC validity violation.
+type Violation struct {
+	Reason Reason
+
+	// Location is the position of the violation.
+	Location Point
+
+	// Path is the component of the geometry, such as MultiPolygon[1].Ring[2].
+	Path string
+}
+
+// String formats the violation as ST_IsValidReason does.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s[%s]", v.Reason, xyOf(v.Location.Coordinate))
+}
+
+// Validate checks the validity of a geometry, as ST_IsValid does. It returns the
+// violations of the first failing check (coordinates, rings, self-intersections,
+// holes, connectivity, and members of multipolygons); the first violation is the
+// one reported by ST_IsValidReason. A valid geometry has no violation.
+//
+// Self-intersections are searched by comparing all the segments of a polygon,
+// which is quadratic: this is designed for hand drawn shapes, not for large
+// datasets.
+func Validate(geometry EWKBConverter) []Violation {
+	return validate(geometry.ToEWKB(), "")
+}
+
+func validate(geometry ewkb.Geometry, prefix string) []Violation {
+	path := prefix + geometry.Type().String()
+
+	switch geo := geometry.(type) {
+	case *ewkb.Point:
+		return validatePoint(path, geo.Coordinate)
+	case *ewkb.LineString:
+		return validateLine(path, geo.CoordinateSet, 2) //nolint: gomnd
+	case *ewkb.CircularString:
+		return validateLine(path, geo.CoordinateSet, 3) //nolint: gomnd
+	case *ewkb.Triangle:
+		if geo.IsEmpty() {
+			return nil
+		}
+
+		return validatePolygon(path, ewkb.CoordinateGroup{geo.CoordinateSet})
+	case *ewkb.Polygon:
+		return validatePolygon(path, geo.CoordinateGroup)
+	case *ewkb.MultiPoint:
+		output := []Violation{}
+
+		for idx, pnt := range geo.Points {
+			output = append(output, validatePoint(fmt.Sprintf("%s[%d]", path, idx), pnt.Coordinate)...)
+		}
+
+		return output
+	case *ewkb.MultiLineString:
+		output := []Violation{}
+
+		for idx, line := range geo.LineStrings {
+			output = append(output, validateLine(fmt.Sprintf("%s[%d]", path, idx), line.CoordinateSet, 2)...) //nolint: gomnd
+		}
+
+		return output
+	case *ewkb.MultiPolygon:
+		return validateMultiPolygon(path, geo)
+	case *ewkb.GeometryCollection:
+		output := []Violation{}
+
+		for idx, sub := range geo.Collection {
+			output = append(output, validate(sub, fmt.Sprintf("%s[%d].", path, idx))...)
+		}
+
+		return output
+	}
+
+	return nil
+}
+
+func validatePoint(path string, coord ewkb.Coordinate) []Violation {
+	if coord.IsEmpty() || xyOf(coord).isFinite() {
+		return nil
+	}
+
+	return []Violation{{Reason: ReasonInvalidCoordinate, Location: xyOf(coord).point(), Path: path}}
+}
+
+// validateCoordinates checks that all the coordinates are finite.
+func validateCoordinates(path string, set ewkb.CoordinateSet) []Violation {
+	output := []Violation{}
+
+	for idx, coord := range set {
+		if position := xyOf(coord); !position.isFinite() {
+			output = append(output, Violation{
+				Reason:   ReasonInvalidCoordinate,
+				Location: position.point(),
+				Path:     fmt.Sprintf("%s.Vertex[%d]", path, idx),
+			})
+		}
+	}
+
+	return output
+}
+
+func validateLine(path string, set ewkb.CoordinateSet, minSize int) []Violation {
+	if violations := validateCoordinates(path, set); len(violations) > 0 {
+		return violations
+	}
+
+	if len(set) > 0 && len(removeRepeated(xysOf(set))) < minSize {
+		return []Violation{{Reason: ReasonTooFewPoints, Location: xyOf(set[0]).point(), Path: path}}
+	}
+
+	return nil
+}
+
+// polygonRings are the rings of a polygon being validated.
+type polygonRings struct {
+	paths  []string
+	points [][]xy
+}
+
+// segment is a segment of a ring.
+type segment struct {
+	ring  int
+	index int
+	from  xy
+	to    xy
+}
+
+// violations collects violations, once per reason and location.
+type violations struct {
+	list []Violation
+	seen map[string]struct{}
+}
+
+func (v *violations) add(reason Reason, location xy, path string) {
+	key := fmt.Sprintf("%s[%s]", reason, location)
+
+	if v.seen == nil {
+		v.seen = map[string]struct{}{}
+	}
+
+	if _, found := v.seen[key]; found {
+		return
+	}
+
+	v.seen[key] = struct{}{}
+	v.list = append(v.list, Violation{Reason: reason, Location: location.point(), Path: path})
+}
+
+// checkRings checks the coordinates, the closure and the size of the rings.
+func checkRings(path string, group ewkb.CoordinateGroup) (polygonRings, []Violation) {
+	rings := polygonRings{}
+	output := []Violation{}
+
+	for idx, set := range group {
+		ringPath := fmt.Sprintf("%s.Ring[%d]", path, idx)
+
+		output = append(output, validateCoordinates(ringPath, set)...)
+
+		rings.paths = append(rings.paths, ringPath)
+		rings.points = append(rings.points, xysOf(set))
+	}
+
+	if len(output) > 0 {
+		return rings, output
+	}
+
+	for idx, ring := range rings.points {
+		switch {
+		case len(ring) == 0:
+			output = append(output, Violation{Reason: ReasonTooFewPoints, Path: rings.paths[idx]})
+		case ring[0] != ring[len(ring)-1]:
+			output = append(output, Violation{Reason: ReasonRingNotClosed, Location: ring[0].point(), Path: rings.paths[idx]})
+		case len(removeRepeated(ring)) < minRingSize:
+			output = append(output, Violation{Reason: ReasonTooFewPoints, Location: ring[0].point(), Path: rings.paths[idx]})
+		}
+	}
+
+	return rings, output
+}
+
+// segmentsOf lists the segments of the rings, without repeated points.
+func segmentsOf(rings [][]xy, ringOffset int) [][]segment {
+	output := make([][]segment, len(rings))
+
+	for ringIdx, ring := range rings {
+		ring = removeRepeated(ring)
+
+		for idx := 1; idx < len(ring); idx++ {
+			output[ringIdx] = append(output[ringIdx], segment{
+				ring:  ringIdx + ringOffset,
+				index: idx - 1,
+				from:  ring[idx-1],
+				to:    ring[idx],
+			})
+		}
+	}
+
+	return output
+}
+
+// ringTouch is a point where two rings touch.
+type ringTouch struct {
+	first  int
+	second int
+	point  xy
+}
+
+func validatePolygon(path string, group ewkb.CoordinateGroup) []Violation {
+	if len(group) == 0 {
+		return nil
+	}
+
+	rings, output := checkRings(path, group)
+	if len(output) > 0 {
+		return output
+	}
+
+	segments := segmentsOf(rings.points, 0)
+	crossings := violations{}
+	selfTouches := violations{}
+	touches := []ringTouch{}
+
+	for firstRing := range segments {
+		for secondRing := firstRing; secondRing < len(segments); secondRing++ {
+			for _, first := range segments[firstRing] {
+				for _, second := range segments[secondRing] {
+					if firstRing == secondRing && second.index <= first.index {
+						continue
+					}
+
+					intersection := intersectSegments(first.from, first.to, second.from, second.to)
+
+					switch {
+					case intersection.kind == intersectionNone:
+					case intersection.kind == intersectionProper || intersection.kind == intersectionOverlap:
+						crossings.add(ReasonSelfIntersection, intersection.point, rings.paths[firstRing])
+					case firstRing != secondRing:
+						touches = append(touches, ringTouch{first: firstRing, second: secondRing, point: intersection.point})
+					case !adjacent(first, second, len(segments[firstRing])):
+						selfTouches.add(ReasonRingSelfIntersection, intersection.point, rings.paths[firstRing])
+					}
+				}
+			}
+		}
+	}
+
+	for _, list := range [][]Violation{crossings.list, selfTouches.list, checkHoles(rings), checkConnectivity(rings, touches)} {
+		if len(list) > 0 {
+			return list
+		}
+	}
+
+	return nil
+}
+
+// adjacent checks if two segments of a ring of size segments follow each other.
+func adjacent(first segment, second segment, size int) bool {
+	return second.index == first.index+1 || (first.index == 0 && second.index == size-1)
+}
+
+// firstNotOn finds the first point of a ring that is not on the boundary of another ring.
+func firstNotOn(ring []xy, other []xy) (xy, bool) {
+	for _, point := range ring {
+		if locateInRing(point, other) != locationBoundary {
+			return point, true
+		}
+	}
+
+	return xy{}, false
+}
+
+// checkHoles checks that holes are inside the shell, and not nested.
+func checkHoles(rings polygonRings) []Violation {
+	outside := violations{}
+	nested := violations{}
+
+	shell := rings.points[0]
+
+	for holeIdx := 1; holeIdx < len(rings.points); holeIdx++ {
+		hole := rings.points[holeIdx]
+
+		if point, found := firstNotOn(hole, shell); found && locateInRing(point, shell) == locationExterior {
+			outside.add(ReasonHoleOutsideShell, point, rings.paths[holeIdx])
+		}
+
+		for otherIdx := 1; otherIdx < len(rings.points); otherIdx++ {
+			if otherIdx == holeIdx {
+				continue
+			}
+
+			other := rings.points[otherIdx]
+
+			if point, found := firstNotOn(hole, other); found && locateInRing(point, other) == locationInterior {
+				nested.add(ReasonNestedHoles, point, rings.paths[holeIdx])
+			}
+		}
+	}
+
+	if len(outside.list) > 0 {
+		return outside.list
+	}
+
+	return nested.list
+}
+
+// checkConnectivity checks that the rings touching each other do not split
+// the interior: a cycle of touching rings encloses a part of the interior.
+func checkConnectivity(rings polygonRings, touches []ringTouch) []Violation {
+	parents := make([]int, len(rings.points))
+	for idx := range parents {
+		parents[idx] = idx
+	}
+
+	var find func(int) int
+	find = func(idx int) int {
+		if parents[idx] != idx {
+			parents[idx] = find(parents[idx])
+		}
+
+		return parents[idx]
+	}
+
+	output := violations{}
+	seen := map[ringTouch]struct{}{}
+
+	for _, touch := range touches {
+		if _, found := seen[touch]; found {
+			continue
+		}
+
+		seen[touch] = struct{}{}
+
+		first, second := find(touch.first), find(touch.second)
+		if first == second {
+			output.add(ReasonDisconnectedInterior, touch.point, rings.paths[touch.second])
+
+			continue
+		}
+
+		parents[first] = second
+	}
+
+	return output.list
+}
+
+func validateMultiPolygon(path string, multi *ewkb.MultiPolygon) []Violation {
+	output := []Violation{}
+
+	polygons := make([]polygonRings, len(multi.Polygons))
+
+	for idx, polygon := range multi.Polygons {
+		polygonPath := fmt.Sprintf("%s[%d]", path, idx)
+
+		output = append(output, validatePolygon(polygonPath, polygon.CoordinateGroup)...)
+		polygons[idx], _ = checkRings(polygonPath, polygon.CoordinateGroup)
+	}
+
+	if len(output) > 0 {
+		return output
+	}
+
+	crossings := violations{}
+
+	for firstIdx := range polygons {
+		for secondIdx := firstIdx + 1; secondIdx < len(polygons); secondIdx++ {
+			for firstRing, firstSegments := range segmentsOf(polygons[firstIdx].points, 0) {
+				for _, secondSegments := range segmentsOf(polygons[secondIdx].points, 0) {
+					for _, first := range firstSegments {
+						for _, second := range secondSegments {
+							intersection := intersectSegments(first.from, first.to, second.from, second.to)
+
+							if intersection.kind == intersectionProper || intersection.kind == intersectionOverlap {
+								crossings.add(ReasonSelfIntersection, intersection.point, polygons[firstIdx].paths[firstRing])
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	if len(crossings.list) > 0 {
+		return crossings.list
+	}
+
+	nested := violations{}
+
+	for firstIdx, first := range polygons {
+		for secondIdx, second := range polygons {
+			if firstIdx == secondIdx || len(first.points) == 0 || len(second.points) == 0 {
+				continue
+			}
+
+			if point, found := firstNotOnPolygon(first.points[0], second.points); found &&
+				locateInPolygon(point, second.points) == locationInterior {
+				nested.add(ReasonNestedShells, point, first.paths[0])
+			}
+		}
+	}
+
+	return nested.list
+}
+
+// firstNotOnPolygon finds the first point of a ring that is not on the boundary of a polygon.
+func firstNotOnPolygon(ring []xy, polygon [][]xy) (xy, bool) {
+	for _, point := range ring {
+		if locateInPolygon(point, polygon) != locationBoundary {
+			return point, true
+		}
+	}
+
+	return xy{}, false
+}
@@ -0,0 +1,193 @@
+package gogis_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/landru29/gogis"
+	"github.com/landru29/gogis/ewkb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// line builds a 2D LineString (or a ring) from x, y values.
+func line(values ...float64) gogis.LineString {
+	output := make(gogis.LineString, 0, len(values)/2)
+
+	for idx := 0; idx+1 < len(values); idx += 2 {
+		output = append(output, gogis.Point{Coordinate: ewkb.Coordinate{'x': values[idx], 'y': values[idx+1]}})
+	}
+
+	return output
+}
+
+func TestValidate(t *testing.T) {
+	square := line(0, 0, 10, 0, 10, 10, 0, 10, 0, 0)
+
+	// Expected messages are formatted as PostGIS does (SELECT ST_IsValidReason(geometry)).
+	for name, fixture := range map[string]struct {
+		geometry gogis.EWKBConverter
+		reason   string
+		path     string
+	}{
+		"valid linestring": {
+			// LINESTRING(220227 150406,2220227 150407,222020 150410).
+			geometry: line(220227, 150406, 2220227, 150407, 222020, 150410),
+		},
+		"valid polygon with holes": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,2 1,2 2,1 1),(5 5,6 5,6 6,5 5)).
+			geometry: gogis.Polygon{square, line(1, 1, 2, 1, 2, 2, 1, 1), line(5, 5, 6, 5, 6, 6, 5, 5)},
+		},
+		"valid hole touching the shell": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(0 0,5 2,5 5,0 0)).
+			geometry: gogis.Polygon{square, line(0, 0, 5, 2, 5, 5, 0, 0)},
+		},
+		"valid touching multipolygon": {
+			// MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((10 10,20 10,20 20,10 20,10 10))).
+			geometry: gogis.MultiPolygon{{square}, {line(10, 10, 20, 10, 20, 20, 10, 20, 10, 10)}},
+		},
+		"valid island in a lake": {
+			// MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0),(2 2,8 2,8 8,2 8,2 2)),((4 4,6 4,6 6,4 6,4 4))).
+			geometry: gogis.MultiPolygon{
+				{square, line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)},
+				{line(4, 4, 6, 4, 6, 6, 4, 6, 4, 4)},
+			},
+		},
+		"empty": {
+			geometry: gogis.Polygon{},
+		},
+		"too few points": {
+			// LINESTRING(0 0,0 0).
+			geometry: line(0, 0, 0, 0),
+			reason:   "Too few points in geometry component[0 0]",
+			path:     "LineString",
+		},
+		"too few points in ring": {
+			// POLYGON((0 0,1 1,0 0,0 0)).
+			geometry: gogis.Polygon{line(0, 0, 1, 1, 0, 0, 0, 0)},
+			reason:   "Too few points in geometry component[0 0]",
+			path:     "Polygon.Ring[0]",
+		},
+		"ring not closed": {
+			geometry: gogis.Polygon{line(0, 0, 10, 0, 10, 10, 0, 10)},
+			reason:   "Ring is not closed[0 0]",
+			path:     "Polygon.Ring[0]",
+		},
+		"invalid coordinate": {
+			geometry: line(0, 0, math.NaN(), 1),
+			reason:   "Invalid Coordinate[NaN 1]",
+			path:     "LineString.Vertex[1]",
+		},
+		"bow tie": {
+			// POLYGON((100 200,100 100,200 200,200 100,100 200)).
+			geometry: gogis.Polygon{line(100, 200, 100, 100, 200, 200, 200, 100, 100, 200)},
+			reason:   "Self-intersection[150 150]",
+			path:     "Polygon.Ring[0]",
+		},
+		"ring self-intersection": {
+			// POLYGON((0 0,10 0,10 10,5 0,0 10,0 0)).
+			geometry: gogis.Polygon{line(0, 0, 10, 0, 10, 10, 5, 0, 0, 10, 0, 0)},
+			reason:   "Ring Self-intersection[5 0]",
+			path:     "Polygon.Ring[0]",
+		},
+		"hole outside shell": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(15 15,15 20,20 20,20 15,15 15)).
+			geometry: gogis.Polygon{square, line(15, 15, 15, 20, 20, 20, 20, 15, 15, 15)},
+			reason:   "Hole lies outside shell[15 15]",
+			path:     "Polygon.Ring[1]",
+		},
+		"hole crossing the shell": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(5 5,15 5,15 6,5 6,5 5)).
+			geometry: gogis.Polygon{square, line(5, 5, 15, 5, 15, 6, 5, 6, 5, 5)},
+			reason:   "Self-intersection[10 5]",
+			path:     "Polygon.Ring[0]",
+		},
+		"nested holes": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,9 1,9 9,1 9,1 1),(2 2,8 2,8 8,2 8,2 2)).
+			geometry: gogis.Polygon{square, line(1, 1, 9, 1, 9, 9, 1, 9, 1, 1), line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)},
+			reason:   "Holes are nested[2 2]",
+			path:     "Polygon.Ring[2]",
+		},
+		"overlapping holes": {
+			// POLYGON((0 0,10 0,10 10,0 10,0 0),(1 1,6 1,6 6,1 6,1 1),(4 4,8 4,8 8,4 8,4 4)).
+			geometry: gogis.Polygon{square, line(1, 1, 6, 1, 6, 6, 1, 6, 1, 1), line(4, 4, 8, 4, 8, 8, 4, 8, 4, 4)},
+			reason:   "Self-intersection[6 4]",
+			path:     "Polygon.Ring[1]",
+		},
+		"overlapping multipolygon": {
+			// MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((5 5,15 5,15 15,5 15,5 5))).
+			geometry: gogis.MultiPolygon{{square}, {line(5, 5, 15, 5, 15, 15, 5, 15, 5, 5)}},
+			reason:   "Self-intersection[10 5]",
+			path:     "MultiPolygon[0].Ring[0]",
+		},
+		"nested shells": {
+			// MULTIPOLYGON(((0 0,10 0,10 10,0 10,0 0)),((2 2,8 2,8 8,2 8,2 2))).
+			geometry: gogis.MultiPolygon{{square}, {line(2, 2, 8, 2, 8, 8, 2, 8, 2, 2)}},
+			reason:   "Nested shells[2 2]",
+			path:     "MultiPolygon[1].Ring[0]",
+		},
+		"invalid member of multipolygon": {
+			geometry: gogis.MultiPolygon{{square}, {line(100, 200, 100, 100, 200, 200, 200, 100, 100, 200)}},
+			reason:   "Self-intersection[150 150]",
+			path:     "MultiPolygon[1].Ring[0]",
+		},
+		"triangle": {
+			// TRIANGLE((0 0,1 1,0 0,0 0)).
+			geometry: gogis.Triangle(line(0, 0, 1, 1, 0, 0, 0, 0)),
+			reason:   "Too few points in geometry component[0 0]",
+			path:     "Triangle.Ring[0]",
+		},
+	} {
+		fixture := fixture
+
+		t.Run(name, func(t *testing.T) {
+			violations := gogis.Validate(fixture.geometry)
+
+			if fixture.reason == "" {
+				assert.Empty(t, violations)
+
+				return
+			}
+
+			require.NotEmpty(t, violations)
+			assert.Equal(t, fixture.reason, violations[0].String())
+			assert.Equal(t, fixture.path, violations[0].Path)
+		})
+	}
+
+	t.Run("interior is disconnected", func(t *testing.T) {
+		// The hole touches the shell at (0 5) and (10 5).
+		violations := gogis.Validate(gogis.Polygon{square, line(0, 5, 5, 2, 10, 5, 5, 8, 0, 5)})
+
+		require.Len(t, violations, 1)
+		assert.Equal(t, gogis.ReasonDisconnectedInterior, violations[0].Reason)
+		assert.Equal(t, "Polygon.Ring[1]", violations[0].Path)
+	})
+
+	t.Run("shared edge in multipolygon", func(t *testing.T) {
+		violations := gogis.Validate(gogis.MultiPolygon{{square}, {line(10, 0, 20, 0, 20, 10, 10, 10, 10, 0)}})
+
+		require.NotEmpty(t, violations)
+		assert.Equal(t, gogis.ReasonSelfIntersection, violations[0].Reason)
+	})
+
+	t.Run("all violations", func(t *testing.T) {
+		violations := gogis.Validate(gogis.MultiPolygon{
+			{line(100, 200, 100, 100, 200, 200, 200, 100, 100, 200)},
+			{line(0, 0, 10, 0, 10, 10, 5, 0, 0, 10, 0, 0)},
+		})
+
+		assert.Equal(t, []gogis.Violation{
+			{
+				Reason:   gogis.ReasonSelfIntersection,
+				Location: gogis.Point{Coordinate: ewkb.Coordinate{'x': 150, 'y': 150}},
+				Path:     "MultiPolygon[0].Ring[0]",
+			},
+			{
+				Reason:   gogis.ReasonRingSelfIntersection,
+				Location: gogis.Point{Coordinate: ewkb.Coordinate{'x': 5, 'y': 0}},
+				Path:     "MultiPolygon[1].Ring[0]",
+			},
+		}, violations)
+	})
+}